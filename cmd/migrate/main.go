@@ -0,0 +1,117 @@
+// Command migrate manages the database schema with the migrations built into the server
+// It connects with the same environment variables as the server:
+//
+//	go run ./cmd/migrate up          # apply every pending migration
+//	go run ./cmd/migrate up 1        # apply the next migration
+//	go run ./cmd/migrate down 1      # undo the last migration
+//	go run ./cmd/migrate down -all   # undo every migration, dropping all data
+//	go run ./cmd/migrate version     # print the current version
+//	go run ./cmd/migrate force 2     # mark version 2 as applied and clean after a failed migration
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"race-cars/internal/config"
+	"race-cars/migrations"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: migrate up [N] | down N | down -all | version | force VERSION")
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// A .env file is optional here, the variables may come from the environment
+	if err := config.LoadEnv(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Fatal("Error loading .env file: ", err)
+	}
+	db, err := config.OpenDB()
+	if err != nil {
+		log.Fatal("Error connecting to database: ", err)
+	}
+	defer db.Close()
+
+	migrator, err := migrations.New(config.GetDBDriver(), db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer migrator.Close()
+
+	if err := run(migrator, flag.Arg(0), flag.Args()[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run carries out a command on the schema and prints the version it leaves
+func run(migrator *migrate.Migrate, command string, args []string) error {
+	var err error
+	switch {
+	case command == "up" && len(args) == 0:
+		err = migrator.Up()
+	case command == "up" && len(args) == 1:
+		steps, parseErr := parseCount(args[0])
+		if parseErr != nil {
+			return parseErr
+		}
+		err = migrator.Steps(steps)
+	case command == "down" && len(args) == 1 && args[0] == "-all":
+		err = migrator.Down()
+	case command == "down" && len(args) == 1:
+		steps, parseErr := parseCount(args[0])
+		if parseErr != nil {
+			return parseErr
+		}
+		err = migrator.Steps(-steps)
+	case command == "force" && len(args) == 1:
+		version, parseErr := strconv.Atoi(args[0])
+		if parseErr != nil || version < -1 {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		err = migrator.Force(version)
+	case command == "version" && len(args) == 0:
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if errors.Is(err, migrate.ErrNoChange) {
+		log.Println("No change")
+	} else if err != nil {
+		return err
+	}
+
+	version, dirty, err := migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		fmt.Println("No migrations applied")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if dirty {
+		fmt.Printf("Version %d (dirty, fix the schema and force a version)\n", version)
+		return nil
+	}
+	fmt.Printf("Version %d\n", version)
+	return nil
+}
+
+// parseCount reads the number of migrations to step through
+func parseCount(value string) (int, error) {
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("invalid number of migrations %q", value)
+	}
+	return count, nil
+}
@@ -0,0 +1,53 @@
+// Command play runs a race in the terminal for up to six players sharing one keyboard
+// The screen is cleared between players so nobody sees another player's hand; bots can fill the other seats:
+//
+//	go run ./cmd/play -players Ada,Brian
+//	go run ./cmd/play -players Ada -bots hard,normal -track Italy -laps 1
+//	go run ./cmd/play -players Ada,Brian,Cleo -modules garage -seed 42
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"os"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/flags"
+	"race-cars/internal/hotseat"
+	"race-cars/internal/tracks"
+)
+
+func main() {
+	players := flag.String("players", "Player 1,Player 2", "comma-separated names of the players at the keyboard, in seat order")
+	bots := flag.String("bots", "", "comma-separated bots for the seats after the players: easy, normal, hard, mcts or mcts:<rollouts>")
+	trackName := flag.String("track", "USA", "name of a built-in track")
+	trackFile := flag.String("track-file", "", "JSON file with a custom track, overrides -track")
+	laps := flag.Int("laps", 0, "laps to race, 0 uses the track's default")
+	modules := flag.String("modules", "", "comma-separated modules: garage, sponsors")
+	seed := flag.Int64("seed", 1, "seed of the race, the same seed and moves replay the same race")
+	flag.Parse()
+
+	track, err := tracks.Load(*trackName, *trackFile)
+	if err != nil {
+		log.Fatal("Error loading track: ", err)
+	}
+
+	session, err := hotseat.NewSession(hotseat.Config{
+		Race: engine.Config{
+			Track:   track,
+			Laps:    *laps,
+			Seed:    *seed,
+			Modules: flags.SplitList(*modules),
+		},
+		Players: flags.SplitList(*players),
+		Bots:    flags.SplitList(*bots),
+	}, os.Stdin, os.Stdout)
+	if err != nil {
+		log.Fatal("Error setting up race: ", err)
+	}
+
+	if err := session.Run(); err != nil && !errors.Is(err, hotseat.ErrQuit) {
+		log.Fatal("Error playing race: ", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Command rlenv serves a reinforcement-learning environment over standard input and output
+// Every line in is a JSON request and every line out is its JSON response, so a training loop in any language
+// can drive races through a pipe:
+//
+//	{"command":"spec"}
+//	{"command":"reset","seed":1}
+//	{"command":"step","action":1}
+//
+//	go run ./cmd/rlenv -track USA -opponents hard,normal < requests.jsonl
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/flags"
+	"race-cars/internal/rl"
+	"race-cars/internal/tracks"
+)
+
+func main() {
+	trackName := flag.String("track", "USA", "name of a built-in track")
+	trackFile := flag.String("track-file", "", "JSON file with a custom track, overrides -track")
+	laps := flag.Int("laps", 0, "laps to race, 0 uses the track's default")
+	opponents := flag.String("opponents", "hard", "comma-separated bot per opponent seat: easy, normal, hard, mcts or mcts:<rollouts>")
+	modules := flag.String("modules", "", "comma-separated modules: garage, sponsors")
+	upgrades := flag.String("upgrades", "", "comma-separated Garage upgrades added to every starting deck")
+	maxRounds := flag.Int("max-rounds", 0, "round limit per race, 0 uses the engine's default")
+	flag.Parse()
+
+	track, err := tracks.Load(*trackName, *trackFile)
+	if err != nil {
+		log.Fatal("Error loading track: ", err)
+	}
+
+	env, err := rl.NewEnv(rl.Config{
+		Race: engine.Config{
+			Track:     track,
+			Laps:      *laps,
+			Modules:   flags.SplitList(*modules),
+			MaxRounds: *maxRounds,
+		},
+		Opponents: flags.SplitList(*opponents),
+		Upgrades:  flags.SplitList(*upgrades),
+	})
+	if err != nil {
+		log.Fatal("Error creating environment: ", err)
+	}
+
+	if err := rl.Serve(env, os.Stdin, os.Stdout); err != nil {
+		log.Fatal("Error serving environment: ", err)
+	}
+}
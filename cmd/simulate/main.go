@@ -0,0 +1,102 @@
+// Command simulate runs races between bots without a database or HTTP server and prints aggregate statistics
+// It is meant for balancing tracks and cards:
+//
+//	go run ./cmd/simulate -track USA -bots hard,normal,easy -games 500
+//	go run ./cmd/simulate -track-file my-track.json -bots hard,hard -upgrades Brakes
+//	go run ./cmd/simulate -games 100000 -workers 16 -out results.jsonl
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"race-cars/internal/flags"
+	"race-cars/internal/simulation"
+	"race-cars/internal/tracks"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run parses the flags, runs the races and prints the summary
+// Errors come back here rather than ending the process, so the output file is always closed and flushed
+func run() (err error) {
+	trackName := flag.String("track", "USA", "name of a built-in track")
+	trackFile := flag.String("track-file", "", "JSON file with a custom track, overrides -track")
+	laps := flag.Int("laps", 0, "laps to race, 0 uses the track's default")
+	bots := flag.String("bots", "hard,hard", "comma-separated bot per seat: easy, normal, hard, mcts or mcts:<rollouts>")
+	modules := flag.String("modules", "", "comma-separated modules: garage, sponsors")
+	upgrades := flag.String("upgrades", "", "comma-separated Garage upgrades added to every starting deck")
+	games := flag.Int("games", 100, "number of races to run")
+	seed := flag.Int64("seed", 1, "seed of the first race, race i uses seed + i")
+	maxRounds := flag.Int("max-rounds", 0, "round limit per race, 0 uses the engine's default")
+	asJSON := flag.Bool("json", false, "print the summary as JSON")
+	workers := flag.Int("workers", 0, "goroutines running races, 0 uses every CPU")
+	shardSize := flag.Int("shard-size", 0, "consecutive seeds handed to a worker at once, 0 uses the default")
+	outPath := flag.String("out", "", "file to stream every race result to, .jsonl or .csv")
+	format := flag.String("format", "", "format of -out: jsonl or csv, taken from the file extension when empty")
+	flag.Parse()
+
+	track, err := tracks.Load(*trackName, *trackFile)
+	if err != nil {
+		return fmt.Errorf("error loading track: %w", err)
+	}
+
+	config := simulation.Config{
+		Track:     track,
+		Laps:      *laps,
+		Bots:      flags.SplitList(*bots),
+		Modules:   flags.SplitList(*modules),
+		Upgrades:  flags.SplitList(*upgrades),
+		Seed:      *seed,
+		Games:     *games,
+		MaxRounds: *maxRounds,
+	}
+
+	var out simulation.ResultWriter
+	if *outPath != "" {
+		file, createErr := os.Create(*outPath)
+		if createErr != nil {
+			return fmt.Errorf("error creating output file: %w", createErr)
+		}
+		defer func() {
+			if closeErr := file.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("error closing output file: %w", closeErr)
+			}
+		}()
+
+		if *format == "" {
+			*format = strings.TrimPrefix(filepath.Ext(*outPath), ".")
+		}
+		if out, err = simulation.NewResultWriter(*format, file); err != nil {
+			return fmt.Errorf("error creating output file: %w", err)
+		}
+	}
+
+	options := simulation.RunOptions{Workers: *workers, ShardSize: *shardSize}
+	stats, err := simulation.RunParallel(config, options, out)
+	if err != nil {
+		return fmt.Errorf("error running simulation: %w", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(stats.GetSummary())
+	} else {
+		fmt.Printf("%s, %d games, seeds %d to %d\n\n", track.Name, *games, *seed, *seed+int64(*games)-1)
+		err = simulation.WriteSummary(os.Stdout, stats.GetSummary())
+	}
+	if err != nil {
+		return fmt.Errorf("error writing summary: %w", err)
+	}
+	return nil
+}
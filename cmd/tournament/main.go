@@ -0,0 +1,72 @@
+// Command tournament pits bot strategies against each other in head-to-head races and rates them
+// Every pairing races every track from both grid positions:
+//
+//	go run ./cmd/tournament -entrants easy,normal,hard,mcts:200
+//	go run ./cmd/tournament -entrants easy,normal,hard,legends:2 -format swiss -rounds 3
+//	go run ./cmd/tournament -entrants hard,heuristic-normal -tracks USA,Italy -games 10 -json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"race-cars/internal/flags"
+	"race-cars/internal/tournament"
+	"race-cars/internal/tracks"
+)
+
+func main() {
+	entrants := flag.String("entrants", "easy,normal,hard", "comma-separated strategies: easy, normal, hard, mcts, mcts:<rollouts>, legends or legends:<level>")
+	format := flag.String("format", string(tournament.RoundRobin), "pairing format: round-robin or swiss")
+	trackNames := flag.String("tracks", "", "comma-separated built-in tracks, every track when empty")
+	laps := flag.Int("laps", 0, "laps to race, 0 uses each track's default")
+	modules := flag.String("modules", "", "comma-separated modules: garage, sponsors")
+	games := flag.Int("games", 0, "races per pairing on each track, 0 uses the default")
+	rounds := flag.Int("rounds", 0, "Swiss rounds, 0 uses enough to separate the entrants")
+	seed := flag.Int64("seed", 1, "seed of the first race, race i uses seed + i")
+	maxRounds := flag.Int("max-rounds", 0, "round limit per race, 0 uses the engine's default")
+	workers := flag.Int("workers", 0, "goroutines running races, 0 uses every CPU")
+	bootstrap := flag.Int("bootstrap", 0, "resamples for the confidence intervals, 0 uses the default")
+	asJSON := flag.Bool("json", false, "print the report, with every race, as JSON")
+	flag.Parse()
+
+	config := tournament.Config{
+		Entrants:      flags.SplitList(*entrants),
+		Format:        tournament.Format(*format),
+		Laps:          *laps,
+		Modules:       flags.SplitList(*modules),
+		GamesPerTrack: *games,
+		Rounds:        *rounds,
+		Seed:          *seed,
+		MaxRounds:     *maxRounds,
+		Workers:       *workers,
+		Bootstrap:     *bootstrap,
+	}
+	for _, name := range flags.SplitList(*trackNames) {
+		track, err := tracks.GetTrack(name)
+		if err != nil {
+			log.Fatal("Error loading track: ", err)
+		}
+		config.Tracks = append(config.Tracks, track)
+	}
+
+	report, err := tournament.Run(config)
+	if err != nil {
+		log.Fatal("Error running tournament: ", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	} else {
+		fmt.Printf("%s tournament, %d races, seeds %d to %d\n\n", report.Format, report.Races, *seed, *seed+int64(report.Races)-1)
+		err = tournament.WriteStandings(os.Stdout, report)
+	}
+	if err != nil {
+		log.Fatal("Error writing report: ", err)
+	}
+}
@@ -3,8 +3,17 @@ module race-cars
 go 1.21
 
 require (
+	github.com/golang-migrate/migrate/v4 v4.16.2
 	github.com/gorilla/mux v1.8.1
+	github.com/gorilla/websocket v1.5.1
 	github.com/joho/godotenv v1.5.1
 	github.com/lib/pq v1.10.9
-	github.com/golang-migrate/migrate/v4 v4.16.2
-) 
\ No newline at end of file
+	github.com/mattn/go-sqlite3 v1.14.16
+)
+
+require (
+	github.com/hashicorp/errwrap v1.1.0 // indirect
+	github.com/hashicorp/go-multierror v1.1.1 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+	golang.org/x/net v0.17.0 // indirect
+)
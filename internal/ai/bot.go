@@ -0,0 +1,92 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"race-cars/internal/engine"
+)
+
+// Bot chooses the actions of a seat
+type Bot interface {
+	// GetName returns the bot's name for reports
+	// Returns: the name as a string
+	GetName() string
+
+	// Choose picks the action for a seat the game is waiting for
+	// Input: game - the game being played
+	//
+	//	seat - the seat to choose for
+	//
+	// Returns: a legal Action, an error if the seat has nothing to do
+	Choose(game engine.Game, seat int) (engine.Action, error)
+}
+
+// DefaultMCTSIterations is the rollout budget of an "mcts" bot created by name
+const DefaultMCTSIterations = 200
+
+// NewBot creates a bot from its name, as used on the command line and in reports
+// Heuristic bots are named by difficulty ("hard") or in full ("heuristic-hard");
+// "mcts" is a Monte Carlo bot with DefaultMCTSIterations rollouts, "mcts:500" sets the rollouts
+// Input: name - the bot's name
+//
+//	seed - the seed of the bot's own random source
+//
+// Returns: a new Bot, an error if the name is unknown
+func NewBot(name string, seed int64) (Bot, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if name == "mcts" || strings.HasPrefix(name, "mcts:") {
+		iterations := DefaultMCTSIterations
+		if budget, ok := strings.CutPrefix(name, "mcts:"); ok {
+			parsed, err := strconv.Atoi(budget)
+			if err != nil || parsed < 1 {
+				return nil, fmt.Errorf("invalid mcts budget %q", budget)
+			}
+			iterations = parsed
+		}
+		return NewMCTSBot(MCTSConfig{Iterations: iterations, RolloutRounds: 3, Seed: seed})
+	}
+
+	difficulty, err := ParseDifficulty(strings.TrimPrefix(name, "heuristic-"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown bot %q", name)
+	}
+	return NewHeuristicBot(difficulty, seed)
+}
+
+// PlayOut runs a game to the end with a bot on every player seat
+// Input: game - the game to play
+//
+//	bots - one bot per player, in seat order
+//
+// Returns: an error if a bot fails or the game stops making progress
+func PlayOut(game engine.Game, bots []Bot) error {
+	if len(bots) != len(game.GetPlayers()) {
+		return fmt.Errorf("need %d bots, got %d", len(game.GetPlayers()), len(bots))
+	}
+
+	for !game.IsFinished() {
+		waiting := false
+		for seat, bot := range bots {
+			if !game.IsWaitingFor(seat) {
+				continue
+			}
+			waiting = true
+
+			action, err := bot.Choose(game, seat)
+			if err != nil {
+				return err
+			}
+			if err := game.Submit(seat, action); err != nil {
+				return fmt.Errorf("%s chose an illegal action for seat %d: %w", bot.GetName(), seat, err)
+			}
+		}
+		if !waiting {
+			return errors.New("game is not waiting for any seat")
+		}
+	}
+	return nil
+}
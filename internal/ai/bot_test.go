@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// Helper function to create a race for bots
+func createTestGame(t *testing.T, seats int, seed int64, modules ...string) engine.Game {
+	track, _ := tracks.GetTrack("USA")
+	colors := []models.Color{models.Red, models.Blue, models.Green, models.Yellow, models.Orange, models.Black}
+	config := engine.Config{Track: track, Laps: 1, Seed: seed, Modules: modules}
+	for i := 0; i < seats; i++ {
+		config.Seats = append(config.Seats, engine.Seat{Name: string(colors[i]), Color: colors[i]})
+	}
+
+	game, err := engine.NewGame(config)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+	return game
+}
+
+// Helper function to create one heuristic bot per seat
+func createTestBots(t *testing.T, difficulties ...Difficulty) []Bot {
+	bots := make([]Bot, len(difficulties))
+	for i, difficulty := range difficulties {
+		bot, err := NewHeuristicBot(difficulty, int64(i))
+		if err != nil {
+			t.Fatalf("NewHeuristicBot() error = %v", err)
+		}
+		bots[i] = bot
+	}
+	return bots
+}
+
+func TestNewBot(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "hard", expected: "heuristic-hard"},
+		{name: "heuristic-easy", expected: "heuristic-easy"},
+		{name: " Normal ", expected: "heuristic-normal"},
+		{name: "mcts", expected: "mcts"},
+		{name: "mcts:50", expected: "mcts"},
+		{name: "mcts:0", wantErr: true},
+		{name: "mcts:many", wantErr: true},
+		{name: "random", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, err := NewBot(tt.name, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && bot.GetName() != tt.expected {
+				t.Errorf("GetName() = %s, want %s", bot.GetName(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlayOut(t *testing.T) {
+	game := createTestGame(t, 3, 1, engine.ModuleGarage, engine.ModuleSponsors)
+	if err := PlayOut(game, createTestBots(t, Easy, Normal, Hard)); err != nil {
+		t.Fatalf("PlayOut() error = %v", err)
+	}
+
+	if !game.IsFinished() || len(game.GetResults()) != 3 {
+		t.Error("PlayOut() should run the race to the end")
+	}
+}
+
+func TestPlayOut_WrongNumberOfBots(t *testing.T) {
+	game := createTestGame(t, 3, 1)
+	if err := PlayOut(game, createTestBots(t, Hard)); err == nil {
+		t.Error("PlayOut() with too few bots should fail")
+	}
+}
+
+func TestPlayOut_Deterministic(t *testing.T) {
+	play := func() []engine.Event {
+		game := createTestGame(t, 4, 9, engine.ModuleGarage)
+		if err := PlayOut(game, createTestBots(t, Easy, Normal, Hard, Easy)); err != nil {
+			t.Fatalf("PlayOut() error = %v", err)
+		}
+		return game.GetEvents()
+	}
+
+	if !reflect.DeepEqual(play(), play()) {
+		t.Error("Bots with the same seeds should play the same race")
+	}
+}
@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"fmt"
+)
+
+// Difficulty sets how well a heuristic bot plays
+type Difficulty string
+
+const (
+	// Easy bots play loosely: they misjudge their options and ignore the corners ahead
+	Easy Difficulty = "easy"
+
+	// Normal bots weigh every option but still make the odd mistake
+	Normal Difficulty = "normal"
+
+	// Hard bots always pick their best option and plan for the next corner
+	Hard Difficulty = "hard"
+)
+
+// profile holds the weights a heuristic bot scores actions with
+type profile struct {
+	// noise is the standard deviation of the random error added to every score
+	noise float64
+
+	// heatWeight is the cost of spending one heat with a full engine
+	heatWeight float64
+
+	// spinPenalty is the cost of spinning out in a corner
+	spinPenalty float64
+
+	// clogWeight rewards clearing Stress and Heat cards out of the hand
+	clogWeight float64
+
+	// lookahead makes the bot check whether its new gear can take the next corner
+	lookahead bool
+
+	// discardBelow is the speed under which discardable cards are thrown away
+	discardBelow int
+}
+
+// profiles maps each difficulty to its scoring weights
+var profiles = map[Difficulty]profile{
+	Easy:   {noise: 2.5, heatWeight: 0.5, spinPenalty: 4, clogWeight: 0.2, lookahead: false, discardBelow: 0},
+	Normal: {noise: 0.5, heatWeight: 1, spinPenalty: 10, clogWeight: 0.6, lookahead: false, discardBelow: 2},
+	Hard:   {noise: 0, heatWeight: 1.2, spinPenalty: 15, clogWeight: 0.8, lookahead: true, discardBelow: 2},
+}
+
+// GetDifficulties returns the difficulty levels, easiest first
+// Input: none
+// Returns: a slice of Difficulty
+func GetDifficulties() []Difficulty {
+	return []Difficulty{Easy, Normal, Hard}
+}
+
+// ParseDifficulty converts a name into a difficulty level
+// Input: name - the name of the difficulty
+// Returns: the Difficulty, an error if the name is unknown
+func ParseDifficulty(name string) (Difficulty, error) {
+	difficulty := Difficulty(name)
+	if _, ok := profiles[difficulty]; !ok {
+		return "", fmt.Errorf("unknown difficulty %q", name)
+	}
+	return difficulty, nil
+}
@@ -0,0 +1,29 @@
+package ai
+
+import (
+	"testing"
+)
+
+func TestParseDifficulty(t *testing.T) {
+	for _, difficulty := range GetDifficulties() {
+		parsed, err := ParseDifficulty(string(difficulty))
+		if err != nil || parsed != difficulty {
+			t.Errorf("ParseDifficulty(%q) = %q, %v", difficulty, parsed, err)
+		}
+	}
+
+	if _, err := ParseDifficulty("impossible"); err == nil {
+		t.Error("ParseDifficulty() with an unknown name should fail")
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	easy, normal, hard := profiles[Easy], profiles[Normal], profiles[Hard]
+
+	if !(easy.noise > normal.noise && normal.noise > hard.noise) {
+		t.Error("Harder bots should make fewer random mistakes")
+	}
+	if !(easy.spinPenalty < normal.spinPenalty && normal.spinPenalty < hard.spinPenalty) {
+		t.Error("Harder bots should fear spinning out more")
+	}
+}
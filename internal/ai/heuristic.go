@@ -0,0 +1,361 @@
+package ai
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/garage"
+	"race-cars/internal/models"
+)
+
+const (
+	// expectedStressSpeed is the average speed of the basic card a Stress card flips
+	expectedStressSpeed = 2.5
+
+	// expectedCardSpeed is the average speed of a card in the starting deck
+	expectedCardSpeed = 2.5
+)
+
+type heuristicBot struct {
+	difficulty Difficulty
+	profile    profile
+	rng        *rand.Rand
+}
+
+// NewHeuristicBot creates a bot that scores every legal action and plays the best one
+// Actions are scored on expected distance, heat budget, the corners ahead and clearing Stress and Heat from the hand
+// Input: difficulty - how well the bot plays
+//
+//	seed - the seed of the bot's own random source, so a bot replays the same game the same way
+//
+// Returns: a new Bot, an error if the difficulty is unknown
+func NewHeuristicBot(difficulty Difficulty, seed int64) (Bot, error) {
+	profile, ok := profiles[difficulty]
+	if !ok {
+		return nil, fmt.Errorf("unknown difficulty %q", difficulty)
+	}
+
+	return &heuristicBot{
+		difficulty: difficulty,
+		profile:    profile,
+		rng:        rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// GetName returns the bot's name for reports
+// Input: none
+// Returns: the name as a string
+func (b *heuristicBot) GetName() string {
+	return "heuristic-" + string(b.difficulty)
+}
+
+// Choose picks the highest scoring legal action for a seat
+// Input: game - the game being played
+//
+//	seat - the seat to choose for
+//
+// Returns: a legal Action, an error if the seat has nothing to do
+func (b *heuristicBot) Choose(game engine.Game, seat int) (engine.Action, error) {
+	actions := game.LegalActions(seat)
+	if len(actions) == 0 {
+		return engine.Action{}, fmt.Errorf("no legal actions for seat %d", seat)
+	}
+
+	best := 0
+	bestScore := math.Inf(-1)
+	for i, action := range actions {
+		score := b.score(game, seat, action)
+		if b.profile.noise > 0 {
+			score += b.rng.NormFloat64() * b.profile.noise
+		}
+		if score > bestScore {
+			best = i
+			bestScore = score
+		}
+	}
+	return actions[best], nil
+}
+
+// score rates an action for a seat, higher is better
+// Input: game - the game being played
+//
+//	seat - the seat the action is for
+//	action - the action to rate
+//
+// Returns: the score
+func (b *heuristicBot) score(game engine.Game, seat int, action engine.Action) float64 {
+	player := game.GetPlayers()[seat]
+
+	switch action.Type {
+	case engine.ActionDraft:
+		return scoreUpgrade(game.GetDraft().GetMarket()[action.Cards[0]])
+	case engine.ActionPlan:
+		return b.scorePlan(game.GetBoard(), player, action)
+	case engine.ActionReact:
+		return b.scoreReact(game.GetBoard(), player, action)
+	case engine.ActionSlipstream:
+		return b.scoreSlipstream(game.GetBoard(), player, action)
+	default:
+		return b.scoreDiscard(player, action)
+	}
+}
+
+// scoreUpgrade rates a Garage upgrade for the draft
+// Input: card - the upgrade card
+// Returns: the score
+func scoreUpgrade(card models.Card) float64 {
+	score := float64(card.GetSpeed()) - float64(garage.HeatCost(card))
+	for _, count := range card.GetIcons() {
+		score += 0.5 * float64(count)
+	}
+	return score
+}
+
+// scorePlan rates a gear and card selection
+// Input: board - the board the race is run on
+//
+//	player - the player planning
+//	action - the plan action
+//
+// Returns: the score
+func (b *heuristicBot) scorePlan(board models.Board, player models.Player, action engine.Action) float64 {
+	car := player.GetCar()
+	hand := player.GetHand()
+
+	speed := 0.0
+	heat := 0
+	stress := 0
+	for _, index := range action.Cards {
+		card := hand.GetCard(index)
+		if card.GetName() == models.Stress {
+			speed += expectedStressSpeed
+			stress++
+		} else {
+			speed += float64(card.GetSpeed())
+		}
+		heat += garage.HeatCost(card)
+	}
+	if shift := action.Gear - car.GetGear(); shift == 2 || shift == -2 {
+		heat++
+	}
+
+	from, err := board.FindCar(car)
+	if err != nil {
+		return speed
+	}
+	engineLeft := car.GetEngine() - heat
+	moved := int(math.Round(speed))
+
+	score := speed - float64(heat)*b.heatWeight(car.GetEngine())
+	score -= b.cornerCost(board, from, moved, moved, engineLeft)
+	score += float64(stress) * b.profile.clogWeight
+
+	if !board.GetWeather().NoCooling {
+		cooling := models.GearIcons(action.Gear)[models.IconCooling]
+		if cooling > 0 {
+			cooling += board.GetWeather().CoolingModifier
+		}
+		cooled := min(max(cooling, 0), countCards(hand, models.Heat))
+		score += float64(cooled) * (b.profile.clogWeight + b.heatWeight(engineLeft))
+	}
+
+	if b.profile.lookahead {
+		score -= b.nextCornerCost(board, (from+moved)%len(board.GetSpaces()), action.Gear, engineLeft)
+	}
+	return score
+}
+
+// scoreReact rates Direct Play cards, boosting and the optional icons accepted
+// Input: board - the board the race is run on
+//
+//	player - the active player
+//	action - the react action
+//
+// Returns: the score
+func (b *heuristicBot) scoreReact(board models.Board, player models.Player, action engine.Action) float64 {
+	car := player.GetCar()
+	hand := player.GetHand()
+
+	// The car has not moved yet, so it boosts from where it stands
+	from, err := board.FindCar(car)
+	if err != nil {
+		return float64(car.GetSpeed())
+	}
+
+	speed := float64(car.GetSpeed())
+	heat := 0
+	for _, index := range action.DirectPlay {
+		card := hand.GetCard(index)
+		speed += float64(card.GetSpeed())
+		heat += garage.HeatCost(card)
+	}
+
+	accepted := make(map[models.Icon]bool)
+	for _, icon := range action.Icons {
+		accepted[icon] = true
+	}
+	if action.Boost {
+		heat += engine.BoostCost(board, from)
+		accepted[models.IconBoost] = true
+	}
+	boosts := player.GetIcons()[models.IconBoost]
+	if action.Boost {
+		boosts++
+	}
+	if accepted[models.IconBoost] {
+		speed += float64(boosts) * expectedStressSpeed
+	}
+
+	engineLeft := car.GetEngine() - heat
+	moved := int(math.Round(speed))
+
+	score := speed - float64(heat)*b.heatWeight(car.GetEngine())
+	score -= b.cornerCost(board, from, moved, moved, engineLeft)
+
+	// Icons are added up in a fixed order so the score does not depend on map order
+	icons := make([]models.Icon, 0, len(accepted))
+	for icon := range accepted {
+		icons = append(icons, icon)
+	}
+	sort.Slice(icons, func(i, j int) bool { return icons[i] < icons[j] })
+	for _, icon := range icons {
+		switch icon {
+		case models.IconBoost:
+		case models.IconCooling:
+			cooled := min(player.GetIcons()[models.IconCooling], countCards(hand, models.Heat))
+			score += float64(cooled) * (b.profile.clogWeight + b.heatWeight(engineLeft))
+		default:
+			score += 0.5
+		}
+	}
+	return score
+}
+
+// scoreSlipstream rates taking or declining the slipstream
+// Input: board - the board the race is run on
+//
+//	player - the active player
+//	action - the slipstream action
+//
+// Returns: the score, 0 for declining
+func (b *heuristicBot) scoreSlipstream(board models.Board, player models.Player, action engine.Action) float64 {
+	if !action.Slipstream {
+		return 0
+	}
+
+	car := player.GetCar()
+	from, err := board.FindCar(car)
+	if err != nil {
+		return 0
+	}
+
+	// Corners already passed this turn are paid for at the same speed either way
+	distance := engine.SlipstreamDistance(board, from)
+	return float64(distance) - b.cornerCost(board, from, distance, car.GetSpeed(), car.GetEngine()-pendingHeat(board, car))
+}
+
+// scoreDiscard rates throwing away slow cards
+// Input: player - the player discarding
+//
+//	action - the discard action
+//
+// Returns: the score, 0 for keeping every card
+func (b *heuristicBot) scoreDiscard(player models.Player, action engine.Action) float64 {
+	hand := player.GetHand()
+	score := 0.0
+	for _, index := range action.Cards {
+		score += float64(b.profile.discardBelow - hand.GetCard(index).GetSpeed())
+	}
+	return score
+}
+
+// heatWeight returns the cost of spending one heat, which grows as the engine empties
+// Input: engine - the heat left in the engine
+// Returns: the cost
+func (b *heuristicBot) heatWeight(engine int) float64 {
+	return b.profile.heatWeight * (1 + 3/float64(max(engine, 0)+1))
+}
+
+// cornerCost estimates the cost of the corners a move passes
+// Input: board - the board the race is run on
+//
+//	from - the space the move starts on
+//	distance - the number of spaces moved
+//	speed - the speed the corners are taken at
+//	engineLeft - the heat left to pay with
+//
+// Returns: the cost of the heat owed, or the spin-out penalty when it cannot be paid
+func (b *heuristicBot) cornerCost(board models.Board, from int, distance int, speed int, engineLeft int) float64 {
+	spaces := board.GetSpaces()
+	heat := 0
+	for step := 1; step <= distance; step++ {
+		index := (from + step) % len(spaces)
+		if spaces[index].GetCorner() > 0 {
+			heat += engine.CornerHeat(board, index, speed)
+		}
+	}
+
+	if heat > engineLeft {
+		return b.profile.spinPenalty
+	}
+	return float64(heat) * b.heatWeight(engineLeft)
+}
+
+// nextCornerCost estimates how hard it will be to take the next corner in a gear
+// Input: board - the board the race is run on
+//
+//	space - the space the car will be on
+//	gear - the gear the car will be in
+//	engineLeft - the heat left after this turn
+//
+// Returns: the expected cost, 0 when the corner is out of reach next turn
+func (b *heuristicBot) nextCornerCost(board models.Board, space int, gear int, engineLeft int) float64 {
+	spaces := board.GetSpaces()
+
+	// Next turn the car can shift down one gear for free
+	reach := int(float64(max(gear-1, 1)) * expectedCardSpeed)
+	for step := 1; step <= reach; step++ {
+		index := (space + step) % len(spaces)
+		if spaces[index].GetCorner() > 0 {
+			heat := engine.CornerHeat(board, index, reach)
+			if heat > engineLeft {
+				return b.profile.spinPenalty / 2
+			}
+			return float64(heat) * b.heatWeight(engineLeft) / 2
+		}
+	}
+	return 0
+}
+
+// pendingHeat returns the heat a car already owes for the corners it passed this turn
+// Input: board - the board the race is run on
+//
+//	car - the car that moved
+//
+// Returns: the heat owed
+func pendingHeat(board models.Board, car models.Car) int {
+	heat := 0
+	for _, corner := range car.GetPassedCorners() {
+		heat += engine.CornerHeat(board, corner, car.GetSpeed())
+	}
+	return heat
+}
+
+// countCards returns how many cards in a hand have a name
+// Input: hand - the cards to search
+//
+//	name - the card name to count
+//
+// Returns: the number of cards
+func countCards(hand models.Hand, name string) int {
+	count := 0
+	for i := 0; i < hand.Size(); i++ {
+		if hand.GetCard(i).GetName() == name {
+			count++
+		}
+	}
+	return count
+}
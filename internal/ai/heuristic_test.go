@@ -0,0 +1,195 @@
+package ai
+
+import (
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/garage"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// Helper function to create a single-seat race with a slow corner on space 6
+func createCornerGame(t *testing.T) engine.Game {
+	return createCornerGameWith(t, nil)
+}
+
+// Helper function to create the corner race with road conditions in some sectors
+func createCornerGameWith(t *testing.T, sectors map[int]models.RoadCondition) engine.Game {
+	config := engine.Config{
+		Track: tracks.Track{
+			Name:    "Test",
+			Length:  30,
+			Laps:    1,
+			Corners: []tracks.Corner{{Space: 6, SpeedLimit: 2}},
+		},
+		Seats:            []engine.Seat{{Name: "Bot", Color: models.Red}},
+		SectorConditions: sectors,
+		Seed:             1,
+	}
+	game, err := engine.NewGame(config)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+	return game
+}
+
+// Helper function to replace a player's hand
+func setHand(player models.Player, cards []models.Card) {
+	for len(player.GetHand().GetCards()) > 0 {
+		player.GetHand().RemoveCard(0)
+	}
+	player.GetHand().AddCards(cards)
+}
+
+func TestNewHeuristicBot(t *testing.T) {
+	bot, err := NewHeuristicBot(Normal, 1)
+	if err != nil {
+		t.Fatalf("NewHeuristicBot() error = %v", err)
+	}
+	if bot.GetName() != "heuristic-normal" {
+		t.Errorf("GetName() = %s, want heuristic-normal", bot.GetName())
+	}
+
+	if _, err := NewHeuristicBot(Difficulty("impossible"), 1); err == nil {
+		t.Error("NewHeuristicBot() with an unknown difficulty should fail")
+	}
+}
+
+func TestHeuristicBot_ChoosesLegalActions(t *testing.T) {
+	for _, difficulty := range GetDifficulties() {
+		game := createTestGame(t, 2, 5, engine.ModuleGarage)
+		bot, _ := NewHeuristicBot(difficulty, 3)
+
+		for steps := 0; !game.IsFinished() && steps < 10000; steps++ {
+			for seat := range game.GetPlayers() {
+				if !game.IsWaitingFor(seat) {
+					continue
+				}
+				action, err := bot.Choose(game, seat)
+				if err != nil {
+					t.Fatalf("%s: Choose() error = %v", difficulty, err)
+				}
+				if err := game.Submit(seat, action); err != nil {
+					t.Fatalf("%s: Choose() returned an illegal action %+v: %v", difficulty, action, err)
+				}
+			}
+		}
+	}
+
+	game := createTestGame(t, 2, 5)
+	bot, _ := NewHeuristicBot(Hard, 3)
+	game.Submit(0, game.LegalActions(0)[0])
+	if _, err := bot.Choose(game, 0); err == nil {
+		t.Error("Choose() for a seat the game is not waiting for should fail")
+	}
+}
+
+func TestHeuristicBot_AvoidsSpinningOut(t *testing.T) {
+	for _, difficulty := range []Difficulty{Normal, Hard} {
+		game := createCornerGame(t)
+		player := game.GetPlayers()[0]
+		player.GetCar().SetEngine(1)
+		setHand(player, []models.Card{models.NewSpeedCard(4), models.NewSpeedCard(1), models.NewSpeedCard(4)})
+
+		bot, _ := NewHeuristicBot(difficulty, 1)
+		action, err := bot.Choose(game, 0)
+		if err != nil {
+			t.Fatalf("Choose() error = %v", err)
+		}
+
+		// Any plan with a Speed 4 card takes the corner 2 over the limit with 1 heat in the engine
+		if action.Gear != 1 || len(action.Cards) != 1 || action.Cards[0] != 1 {
+			t.Errorf("%s: Choose() = %+v, want first gear with the Speed 1 card", difficulty, action)
+		}
+	}
+}
+
+func TestHeuristicBot_CoolsHeat(t *testing.T) {
+	game := createCornerGame(t)
+	player := game.GetPlayers()[0]
+	player.GetCar().SetEngine(2)
+	setHand(player, []models.Card{models.NewHeatCard(), models.NewHeatCard(), models.NewSpeedCard(1), models.NewSpeedCard(2)})
+
+	bot, _ := NewHeuristicBot(Hard, 1)
+	action, _ := bot.Choose(game, 0)
+	if err := game.Submit(0, action); err != nil {
+		t.Fatalf("Submit() plan error = %v", err)
+	}
+
+	action, _ = bot.Choose(game, 0)
+	cooled := false
+	for _, icon := range action.Icons {
+		cooled = cooled || icon == models.IconCooling
+	}
+	if !cooled {
+		t.Errorf("Choose() = %+v, want the Cooling icons accepted with Heat in hand", action)
+	}
+}
+
+func TestHeuristicBot_HardBeatsEasy(t *testing.T) {
+	positions := map[Difficulty]int{}
+	for seed := int64(1); seed <= 10; seed++ {
+		game := createTestGame(t, 2, seed)
+		bots := createTestBots(t, Easy, Hard)
+		if seed%2 == 0 {
+			bots = createTestBots(t, Hard, Easy)
+		}
+		if err := PlayOut(game, bots); err != nil {
+			t.Fatalf("PlayOut() error = %v", err)
+		}
+
+		for _, result := range game.GetResults() {
+			name := bots[result.Seat].GetName()
+			positions[Difficulty(name[len("heuristic-"):])] += result.Position
+		}
+	}
+
+	if positions[Hard] >= positions[Easy] {
+		t.Errorf("Total positions hard = %d, easy = %d, want hard bots to finish ahead", positions[Hard], positions[Easy])
+	}
+}
+
+func TestHeuristicBot_FreeBoost(t *testing.T) {
+	// With one corner the whole track, grid included, is sector 0
+	free := map[int]models.RoadCondition{0: {Name: "Free Boost", FreeBoost: true}}
+
+	gains := make([]float64, 0, 2)
+	for _, sectors := range []map[int]models.RoadCondition{nil, free} {
+		game := createCornerGameWith(t, sectors)
+		player := game.GetPlayers()[0]
+		setHand(player, []models.Card{models.NewSpeedCard(1)})
+		if err := game.Submit(0, engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{0}}); err != nil {
+			t.Fatalf("Submit() plan error = %v", err)
+		}
+
+		bot, _ := NewHeuristicBot(Normal, 1)
+		heuristic := bot.(*heuristicBot)
+		boost := heuristic.scoreReact(game.GetBoard(), player, engine.Action{Type: engine.ActionReact, Boost: true})
+		coast := heuristic.scoreReact(game.GetBoard(), player, engine.Action{Type: engine.ActionReact})
+		gains = append(gains, boost-coast)
+	}
+
+	if gains[1] <= gains[0] {
+		t.Errorf("boost gains %.2f in a Free Boost sector and %.2f elsewhere, want more where it costs no heat", gains[1], gains[0])
+	}
+}
+
+func TestHeuristicBot_ScoreReactStable(t *testing.T) {
+	game := createCornerGame(t)
+	player := game.GetPlayers()[0]
+	setHand(player, []models.Card{models.NewHeatCard(), models.NewSpeedCard(1)})
+	if err := game.Submit(0, engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{1}}); err != nil {
+		t.Fatalf("Submit() plan error = %v", err)
+	}
+
+	bot, _ := NewHeuristicBot(Normal, 1)
+	heuristic := bot.(*heuristicBot)
+	action := engine.Action{Type: engine.ActionReact, Boost: true, Icons: []models.Icon{garage.IconHeatControl, models.IconCooling}}
+	want := heuristic.scoreReact(game.GetBoard(), player, action)
+	for i := 0; i < 50; i++ {
+		if got := heuristic.scoreReact(game.GetBoard(), player, action); got != want {
+			t.Fatalf("scoreReact() = %v, then %v for the same action", want, got)
+		}
+	}
+}
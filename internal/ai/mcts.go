@@ -0,0 +1,377 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+const (
+	// DefaultExploration is the UCB1 exploration constant used when none is configured
+	DefaultExploration = 0.7
+
+	// rolloutBatch is the smallest number of rollouts planned at once
+	// Batches do not depend on the number of workers, so an iteration budget always gives the same choice
+	rolloutBatch = 32
+)
+
+// MCTSConfig sets the search budget of a Monte Carlo bot
+// At least one of Iterations and Duration must be set; the search stops at whichever runs out first
+type MCTSConfig struct {
+	// Iterations is the number of rollouts per decision, 0 for no limit
+	Iterations int `json:"iterations,omitempty"`
+
+	// Duration is the time allowed per decision, 0 for no limit
+	// A time budget makes the bot's choices depend on the speed of the machine
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Workers is the number of goroutines running rollouts, 0 uses every CPU
+	Workers int `json:"workers,omitempty"`
+
+	// RolloutRounds stops a rollout after this many rounds and scores the race as it stands, 0 plays to the end
+	RolloutRounds int `json:"rollout_rounds,omitempty"`
+
+	// Exploration is the UCB1 exploration constant, 0 uses DefaultExploration
+	Exploration float64 `json:"exploration,omitempty"`
+
+	// Seed seeds the bot's own random source
+	Seed int64 `json:"seed"`
+}
+
+type mctsBot struct {
+	config MCTSConfig
+	rng    *rand.Rand
+
+	// policy plays every seat during rollouts; a bot without noise never touches its random source, so workers can share it
+	policy Bot
+}
+
+// actionStats collects the rollouts of one candidate action
+type actionStats struct {
+	visits int
+	total  float64
+}
+
+// NewMCTSBot creates a bot that searches with determinized Monte Carlo rollouts
+// For every rollout the game is cloned, the cards the seat cannot see are reshuffled,
+// and the race is played on by Hard heuristic bots; candidate actions are picked with UCB1
+// Input: config - the search budget
+// Returns: a new Bot, an error if the budget is missing or negative
+func NewMCTSBot(config MCTSConfig) (Bot, error) {
+	if config.Iterations < 0 || config.Duration < 0 || config.Workers < 0 || config.RolloutRounds < 0 || config.Exploration < 0 {
+		return nil, errors.New("mcts budget cannot be negative")
+	}
+	if config.Iterations == 0 && config.Duration == 0 {
+		return nil, errors.New("mcts bot needs an iteration or time budget")
+	}
+	if config.Workers == 0 {
+		config.Workers = runtime.GOMAXPROCS(0)
+	}
+	if config.Exploration == 0 {
+		config.Exploration = DefaultExploration
+	}
+
+	policy, err := NewHeuristicBot(Hard, config.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mctsBot{
+		config: config,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+		policy: policy,
+	}, nil
+}
+
+// GetName returns the bot's name for reports
+// Input: none
+// Returns: the name as a string
+func (b *mctsBot) GetName() string {
+	return "mcts"
+}
+
+// Choose runs rollouts for every legal action and plays the one with the best average result
+// The draft cannot be cloned, so draft picks are left to the rollout policy
+// Input: game - the game being played
+//
+//	seat - the seat to choose for
+//
+// Returns: a legal Action, an error if the seat has nothing to do or a rollout fails
+func (b *mctsBot) Choose(game engine.Game, seat int) (engine.Action, error) {
+	actions := game.LegalActions(seat)
+	if len(actions) == 0 {
+		return engine.Action{}, fmt.Errorf("no legal actions for seat %d", seat)
+	}
+	if len(actions) == 1 {
+		return actions[0], nil
+	}
+	if game.GetPhase() == engine.PhaseDraft {
+		return b.policy.Choose(game, seat)
+	}
+
+	var deadline time.Time
+	if b.config.Duration > 0 {
+		deadline = time.Now().Add(b.config.Duration)
+	}
+	seed := b.rng.Int63()
+
+	stats := make([]actionStats, len(actions))
+	for done := 0; b.config.Iterations == 0 || done < b.config.Iterations; {
+		size := max(len(actions), rolloutBatch)
+		if b.config.Iterations > 0 {
+			size = min(size, b.config.Iterations-done)
+		}
+
+		batch := b.plan(stats, size)
+		rewards, err := b.runBatch(game, seat, actions, batch, seed+int64(done), deadline)
+		if err != nil {
+			return engine.Action{}, err
+		}
+
+		// Rewards are added in batch order, so the sums do not depend on which worker finished first
+		for i, reward := range rewards {
+			if !math.IsNaN(reward) {
+				stats[batch[i]].visits++
+				stats[batch[i]].total += reward
+			}
+		}
+		done += size
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+	}
+
+	best := 0
+	bestMean := math.Inf(-1)
+	for i, stat := range stats {
+		if stat.visits == 0 {
+			continue
+		}
+		if mean := stat.total / float64(stat.visits); mean > bestMean {
+			best = i
+			bestMean = mean
+		}
+	}
+	return actions[best], nil
+}
+
+// plan picks the candidate action of every rollout in the next batch with UCB1
+// Actions picked earlier in the batch count as visited, so one batch spreads over several actions
+// Input: stats - the results so far, by action
+//
+//	size - the number of rollouts in the batch
+//
+// Returns: the action index of every rollout
+func (b *mctsBot) plan(stats []actionStats, size int) []int {
+	visits := make([]int, len(stats))
+	total := 0
+	for i, stat := range stats {
+		visits[i] = stat.visits
+		total += stat.visits
+	}
+
+	batch := make([]int, size)
+	for i := range batch {
+		best := 0
+		bestScore := math.Inf(-1)
+		for action, stat := range stats {
+			if visits[action] == 0 {
+				best = action
+				break
+			}
+			mean := 0.0
+			if stat.visits > 0 {
+				mean = stat.total / float64(stat.visits)
+			}
+			score := mean + b.config.Exploration*math.Sqrt(math.Log(float64(total))/float64(visits[action]))
+			if score > bestScore {
+				best = action
+				bestScore = score
+			}
+		}
+		batch[i] = best
+		visits[best]++
+		total++
+	}
+	return batch
+}
+
+// runBatch plays a batch of rollouts on the worker goroutines
+// Input: game - the game being played
+//
+//	seat - the seat choosing
+//	actions - the candidate actions
+//	batch - the action index of every rollout
+//	seed - the seed of the first rollout, each rollout adds its index
+//	deadline - rollouts not started by then are skipped, the zero time for no limit
+//
+// Returns: the reward of every rollout, NaN for skipped ones, and the first error
+func (b *mctsBot) runBatch(game engine.Game, seat int, actions []engine.Action, batch []int, seed int64, deadline time.Time) ([]float64, error) {
+	rewards := make([]float64, len(batch))
+	errs := make([]error, len(batch))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(b.config.Workers, len(batch)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if !deadline.IsZero() && time.Now().After(deadline) {
+					rewards[i] = math.NaN()
+					continue
+				}
+				rewards[i], errs[i] = b.rollout(game, seat, actions[batch[i]], seed+int64(i))
+			}
+		}()
+	}
+	for i := range batch {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rewards, nil
+}
+
+// rollout plays one candidate action on a determinized copy of the game and plays on with the policy
+// Input: game - the game being played
+//
+//	seat - the seat choosing
+//	action - the candidate action
+//	seed - the seed for the copy and the reshuffle
+//
+// Returns: the reward of the seat at the end of the rollout, an error if the copy cannot be played
+func (b *mctsBot) rollout(game engine.Game, seat int, action engine.Action, seed int64) (float64, error) {
+	clone, err := game.Clone(seed)
+	if err != nil {
+		return 0, err
+	}
+	determinize(clone, seat, rand.New(rand.NewSource(seed)))
+
+	if err := clone.Submit(seat, action); err != nil {
+		return 0, fmt.Errorf("rollout rejected %s action: %w", action.Type, err)
+	}
+
+	lastRound := math.MaxInt
+	if b.config.RolloutRounds > 0 {
+		lastRound = game.GetRound() + b.config.RolloutRounds
+	}
+	for !clone.IsFinished() && clone.GetRound() <= lastRound {
+		waiting := false
+		for s := range clone.GetPlayers() {
+			if !clone.IsWaitingFor(s) {
+				continue
+			}
+			waiting = true
+
+			choice, err := b.policy.Choose(clone, s)
+			if err != nil {
+				return 0, err
+			}
+			if err := clone.Submit(s, choice); err != nil {
+				return 0, fmt.Errorf("rollout policy chose an illegal action for seat %d: %w", s, err)
+			}
+		}
+		if !waiting {
+			return 0, errors.New("rollout is not waiting for any seat")
+		}
+	}
+
+	return evaluate(clone, seat), nil
+}
+
+// determinize reshuffles the cards a seat cannot see
+// The seat's own deck is shuffled; every other player's hand and deck are pooled, shuffled and dealt back,
+// so opponents keep their hand size but hold cards the seat could not know about.
+// Plans other seats made this round are hidden too, so they are dropped and planned again by the policy
+// Input: game - the copy of the game to change
+//
+//	seat - the seat whose view is kept
+//	rng - the random source for the reshuffle
+//
+// Returns: none
+func determinize(game engine.Game, seat int, rng *rand.Rand) {
+	for i, player := range game.GetPlayers() {
+		if i == seat {
+			player.GetDeck().Shuffle()
+			continue
+		}
+
+		game.ForgetPlan(i)
+		hand := player.GetHand()
+		size := hand.Size()
+		pool := make([]models.Card, 0, size)
+		for hand.Size() > 0 {
+			card, _ := hand.RemoveCard(0)
+			pool = append(pool, card)
+		}
+		for card := player.GetDeck().DrawCard(); card != nil; card = player.GetDeck().DrawCard() {
+			pool = append(pool, card)
+		}
+
+		rng.Shuffle(len(pool), func(a, b int) {
+			pool[a], pool[b] = pool[b], pool[a]
+		})
+		hand.AddCards(pool[:size])
+		player.GetDeck().AddCardsToTop(pool[size:])
+	}
+}
+
+// evaluate scores how well a seat is doing
+// The race position counts most, from 1 for the leader to 0 for the last car;
+// the laps covered per round played break ties, so a solo racer is rewarded for finishing sooner
+// Input: game - the game to score
+//
+//	seat - the seat to score
+//
+// Returns: the reward, higher is better
+func evaluate(game engine.Game, seat int) float64 {
+	board := game.GetBoard()
+	car := game.GetRacers()[seat].GetCar()
+	cars := len(game.GetRacers())
+
+	position := cars
+	round := max(game.GetRound(), 1)
+	if game.IsFinished() {
+		for _, result := range game.GetResults() {
+			if result.Seat == seat {
+				position = result.Position
+				if result.Round > 0 {
+					round = result.Round
+				}
+			}
+		}
+	} else {
+		for i, ranked := range board.GetRanking() {
+			if ranked == car {
+				position = i + 1
+			}
+		}
+	}
+
+	covered := float64(board.GetNumberOfLaps())
+	if car.GetLap() < board.GetNumberOfLaps() {
+		space, _ := board.FindCar(car)
+		covered = float64(car.GetLap()) + float64(space)/float64(len(board.GetSpaces()))
+	}
+
+	place := 1.0
+	if cars > 1 {
+		place = float64(cars-position) / float64(cars-1)
+	}
+	return place + covered/float64(round)
+}
@@ -0,0 +1,174 @@
+package ai
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+func TestNewMCTSBot(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  MCTSConfig
+		wantErr bool
+	}{
+		{name: "Iteration budget", config: MCTSConfig{Iterations: 10}},
+		{name: "Time budget", config: MCTSConfig{Duration: time.Millisecond}},
+		{name: "Both budgets", config: MCTSConfig{Iterations: 10, Duration: time.Second, Workers: 2}},
+		{name: "No budget", config: MCTSConfig{}, wantErr: true},
+		{name: "Negative iterations", config: MCTSConfig{Iterations: -1}, wantErr: true},
+		{name: "Negative workers", config: MCTSConfig{Iterations: 10, Workers: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, err := NewMCTSBot(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewMCTSBot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && bot.GetName() != "mcts" {
+				t.Errorf("GetName() = %s, want mcts", bot.GetName())
+			}
+		})
+	}
+}
+
+func TestMCTSBot_PlaysLegalRace(t *testing.T) {
+	game := createTestGame(t, 2, 3, engine.ModuleGarage)
+	mcts, err := NewMCTSBot(MCTSConfig{Iterations: 8, RolloutRounds: 2, Workers: 4, Seed: 1})
+	if err != nil {
+		t.Fatalf("NewMCTSBot() error = %v", err)
+	}
+	bots := append([]Bot{mcts}, createTestBots(t, Normal)...)
+
+	if err := PlayOut(game, bots); err != nil {
+		t.Fatalf("PlayOut() error = %v", err)
+	}
+	if len(game.GetResults()) != 2 {
+		t.Errorf("GetResults() = %d results, want 2", len(game.GetResults()))
+	}
+}
+
+func TestMCTSBot_SameChoiceForAnyWorkerCount(t *testing.T) {
+	choose := func(workers int) []engine.Action {
+		game := createTestGame(t, 2, 5)
+		bot, err := NewMCTSBot(MCTSConfig{Iterations: 24, RolloutRounds: 2, Workers: workers, Seed: 9})
+		if err != nil {
+			t.Fatalf("NewMCTSBot() error = %v", err)
+		}
+
+		choices := make([]engine.Action, 0)
+		for round := 0; round < 2 && !game.IsFinished(); {
+			for seat := range game.GetPlayers() {
+				if !game.IsWaitingFor(seat) {
+					continue
+				}
+				action, err := bot.Choose(game, seat)
+				if err != nil {
+					t.Fatalf("Choose() error = %v", err)
+				}
+				if err := game.Submit(seat, action); err != nil {
+					t.Fatalf("Submit() error = %v", err)
+				}
+				choices = append(choices, action)
+			}
+			round = game.GetRound() - 1
+		}
+		return choices
+	}
+
+	if !reflect.DeepEqual(choose(1), choose(8)) {
+		t.Error("An iteration budget should give the same choices with 1 and 8 workers")
+	}
+}
+
+func TestMCTSBot_TimeBudget(t *testing.T) {
+	game := createTestGame(t, 2, 1)
+	bot, err := NewMCTSBot(MCTSConfig{Duration: 50 * time.Millisecond, RolloutRounds: 2, Seed: 1})
+	if err != nil {
+		t.Fatalf("NewMCTSBot() error = %v", err)
+	}
+
+	start := time.Now()
+	action, err := bot.Choose(game, 0)
+	if err != nil {
+		t.Fatalf("Choose() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Choose() took %v with a 50ms budget", elapsed)
+	}
+	if err := game.Submit(0, action); err != nil {
+		t.Errorf("Choose() returned an illegal action: %v", err)
+	}
+}
+
+func TestMCTSBot_LooksPastTheCorner(t *testing.T) {
+	game := createCornerGame(t)
+	player := game.GetPlayers()[0]
+	player.GetCar().SetEngine(1)
+	setHand(player, []models.Card{models.NewSpeedCard(4), models.NewSpeedCard(1), models.NewSpeedCard(4)})
+
+	bot, err := NewMCTSBot(MCTSConfig{Iterations: 60, Seed: 1})
+	if err != nil {
+		t.Fatalf("NewMCTSBot() error = %v", err)
+	}
+	action, err := bot.Choose(game, 0)
+	if err != nil {
+		t.Fatalf("Choose() error = %v", err)
+	}
+
+	// The heuristic bots crawl through the corner with the Speed 1 card, but on a 30-space track
+	// spinning out with a Speed 4 card still finishes two rounds sooner, and the rollouts find that
+	fast := false
+	for _, index := range action.Cards {
+		fast = fast || index != 1
+	}
+	if !fast {
+		t.Errorf("Choose() = %+v, want a plan with a Speed 4 card", action)
+	}
+}
+
+func TestDeterminize(t *testing.T) {
+	game := createTestGame(t, 2, 4)
+	clone, err := game.Clone(1)
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	determinize(clone, 0, rand.New(rand.NewSource(2)))
+
+	own := game.GetPlayers()[0].GetHand().GetCards()
+	if !reflect.DeepEqual(clone.GetPlayers()[0].GetHand().GetCards(), own) {
+		t.Error("determinize() should keep the seat's own hand")
+	}
+
+	opponent := game.GetPlayers()[1]
+	copied := clone.GetPlayers()[1]
+	if len(copied.GetHand().GetCards()) != len(opponent.GetHand().GetCards()) {
+		t.Error("determinize() should keep the opponent's hand size")
+	}
+	total := len(copied.GetHand().GetCards()) + len(copied.GetDeck().GetCards())
+	if total != len(opponent.GetHand().GetCards())+len(opponent.GetDeck().GetCards()) {
+		t.Error("determinize() should keep every opponent card")
+	}
+	if !reflect.DeepEqual(opponent.GetHand().GetCards(), game.GetPlayers()[1].GetHand().GetCards()) {
+		t.Error("determinize() should not change the original game")
+	}
+}
+
+func BenchmarkMCTSBot_Choose(b *testing.B) {
+	track, _ := tracks.GetTrack("USA")
+	game, _ := engine.NewGame(engine.Config{Track: track, Laps: 1, Seed: 1, Seats: []engine.Seat{
+		{Name: "Red", Color: models.Red},
+		{Name: "Blue", Color: models.Blue},
+	}})
+	bot, _ := NewMCTSBot(MCTSConfig{Iterations: 64, RolloutRounds: 3, Seed: 1})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bot.Choose(game, 0)
+	}
+}
@@ -0,0 +1,275 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/garage"
+	"race-cars/internal/models"
+)
+
+// PlanOdds describes what a gear and card selection may lead to
+type PlanOdds struct {
+	Gear  int   `json:"gear"`
+	Cards []int `json:"cards"`
+
+	// Heat is the heat paid to shift and play the cards
+	Heat int `json:"heat"`
+
+	// Stress is the number of Stress cards played
+	Stress int `json:"stress"`
+
+	// Cooled is the number of Heat cards the Cooling icons move from the hand back into the engine
+	Cooled int `json:"cooled"`
+
+	// Move is the outcome without boosting
+	Move MoveOdds `json:"move"`
+
+	// Boost is the outcome of boosting as well, nil if the heat cannot be paid
+	Boost *MoveOdds `json:"boost,omitempty"`
+}
+
+// ReactOdds describes what moving may lead to once the played cards are revealed
+type ReactOdds struct {
+	// Cooled is the number of Heat cards the Cooling icons move from the hand back into the engine
+	Cooled int `json:"cooled"`
+
+	// Move is the outcome without boosting
+	Move MoveOdds `json:"move"`
+
+	// Boost is the outcome of boosting, nil if the heat cannot be paid
+	Boost *MoveOdds `json:"boost,omitempty"`
+}
+
+// Advisor works out the odds of a seat's moves from what the seat knows: its hand, the contents of its deck and
+// discard pile, its engine and the board
+// Cards flipped by Stress and Boost are worked out exactly; the other cars are taken to stay where they are,
+// every Cooling and Boost icon on the played cards is taken to be accepted, and slipstreaming is left out
+type Advisor interface {
+	// GetStressOdds returns the speed a number of Stress cards would add if the seat played them now
+	// Input: seat - the seat index
+	//
+	//	stress - the number of Stress cards
+	//
+	// Returns: the Distribution of the speed added, an error if the seat does not exist
+	GetStressOdds(seat int, stress int) (Distribution, error)
+
+	// GetPlanOdds returns the odds of every plan the seat may submit, like "expected distance in gear 4 with this hand"
+	// Input: seat - the seat index
+	// Returns: the odds in the order of the legal plans, an error if the seat is not planning
+	GetPlanOdds(seat int) ([]PlanOdds, error)
+
+	// EvaluatePlan returns the odds of one gear and card selection
+	// Input: seat - the seat index
+	//
+	//	action - the plan action
+	//
+	// Returns: the PlanOdds, an error if the seat does not exist or the plan is not allowed
+	EvaluatePlan(seat int, action engine.Action) (PlanOdds, error)
+
+	// GetReactOdds returns the odds of moving with and without a boost, like "probability I spin out if I Boost"
+	// Input: seat - the seat index
+	// Returns: the ReactOdds, an error if it is not the seat's turn to react
+	GetReactOdds(seat int) (ReactOdds, error)
+
+	// Suggest recommends the best few moves for a player: plans while planning and whether to boost while reacting
+	// Every suggestion comes with a plain-language rationale, like "gear 3 keeps you at the corner limit of 4 and costs no heat"
+	// Input: player - the player to advise
+	// Returns: up to DefaultSuggestions suggestions, best first, an error if the player is not in the game or has no move to make
+	Suggest(player models.Player) ([]Suggestion, error)
+}
+
+type advisor struct {
+	game engine.Game
+}
+
+// NewAdvisor creates an advisor for a game
+// Input: game - the game to advise on
+// Returns: a new Advisor
+func NewAdvisor(game engine.Game) Advisor {
+	return &advisor{game: game}
+}
+
+// GetStressOdds returns the speed a number of Stress cards would add if the seat played them now
+// Input: seat - the seat index
+//
+//	stress - the number of Stress cards
+//
+// Returns: the Distribution of the speed added, an error if the seat does not exist
+func (a *advisor) GetStressOdds(seat int, stress int) (Distribution, error) {
+	player, err := a.player(seat)
+	if err != nil {
+		return nil, err
+	}
+	if stress < 0 {
+		return nil, errors.New("number of stress cards cannot be negative")
+	}
+	return FlipOdds(player.GetDeck().GetCards(), player.GetDiscardPile().GetCards(), stress), nil
+}
+
+// GetPlanOdds returns the odds of every plan the seat may submit
+// Input: seat - the seat index
+// Returns: the odds in the order of the legal plans, an error if the seat is not planning
+func (a *advisor) GetPlanOdds(seat int) ([]PlanOdds, error) {
+	if _, err := a.player(seat); err != nil {
+		return nil, err
+	}
+	if a.game.GetPhase() != engine.PhasePlanning || !a.game.IsWaitingFor(seat) {
+		return nil, fmt.Errorf("seat %d is not planning", seat)
+	}
+
+	actions := a.game.LegalActions(seat)
+	odds := make([]PlanOdds, 0, len(actions))
+	for _, action := range actions {
+		plan, err := a.EvaluatePlan(seat, action)
+		if err != nil {
+			return nil, err
+		}
+		odds = append(odds, plan)
+	}
+	return odds, nil
+}
+
+// EvaluatePlan returns the odds of one gear and card selection
+// Input: seat - the seat index
+//
+//	action - the plan action
+//
+// Returns: the PlanOdds, an error if the seat does not exist or the plan is not allowed
+func (a *advisor) EvaluatePlan(seat int, action engine.Action) (PlanOdds, error) {
+	player, err := a.player(seat)
+	if err != nil {
+		return PlanOdds{}, err
+	}
+	if err := engine.ValidatePlan(player, action.Gear, action.Cards); err != nil {
+		return PlanOdds{}, err
+	}
+
+	car := player.GetCar()
+	hand := player.GetHand().GetCards()
+	odds := PlanOdds{Gear: action.Gear, Cards: action.Cards}
+
+	speed := 0
+	flips := 0
+	cooling := models.GearIcons(action.Gear)[models.IconCooling]
+	heatCards := countCards(hand, models.Heat)
+	for _, index := range action.Cards {
+		card := hand[index]
+		if card.GetName() == models.Stress {
+			odds.Stress++
+		}
+		if card.GetName() == models.Heat {
+			heatCards--
+		}
+		speed += card.GetSpeed()
+		odds.Heat += garage.HeatCost(card)
+		cooling += card.GetIcons()[models.IconCooling]
+		flips += card.GetIcons()[models.IconBoost]
+	}
+	if shift := action.Gear - car.GetGear(); shift == 2 || shift == -2 {
+		odds.Heat++
+	}
+	if a.game.HasAdrenaline(seat) {
+		speed++
+		cooling++
+	}
+
+	odds.Cooled = a.cooled(cooling, heatCards)
+	odds.Move, odds.Boost = a.moveOdds(player, speed, odds.Stress+flips, car.GetEngine()-odds.Heat, odds.Cooled)
+	return odds, nil
+}
+
+// GetReactOdds returns the odds of moving with and without a boost
+// Input: seat - the seat index
+// Returns: the ReactOdds, an error if it is not the seat's turn to react
+func (a *advisor) GetReactOdds(seat int) (ReactOdds, error) {
+	player, err := a.player(seat)
+	if err != nil {
+		return ReactOdds{}, err
+	}
+	if a.game.GetPhase() != engine.PhaseReact || a.game.GetActiveSeat() != seat {
+		return ReactOdds{}, fmt.Errorf("seat %d is not reacting", seat)
+	}
+
+	// Stress and adrenaline are already in the revealed speed; Boost icons on the played cards are still to flip
+	icons := player.GetIcons()
+	engineHeat := player.GetCar().GetEngine() - icons[garage.IconHeatControl]
+	heatCards := countCards(player.GetHand().GetCards(), models.Heat)
+
+	odds := ReactOdds{Cooled: a.cooled(icons[models.IconCooling], heatCards)}
+	odds.Move, odds.Boost = a.moveOdds(player, player.GetCar().GetSpeed(), icons[models.IconBoost], engineHeat, odds.Cooled)
+	return odds, nil
+}
+
+// moveOdds works out the odds of moving without and with a boost
+// Input: player - the player moving
+//
+//	speed - the speed of the revealed cards
+//	flips - the Stress cards and Boost icons still to flip, not counting a boost
+//	engineHeat - the heat left in the engine once the plan is paid for
+//	cooled - the Heat cards cooled back into the engine before moving
+//
+// Returns: the MoveOdds without boosting, and with boosting or nil if it cannot be paid for
+func (a *advisor) moveOdds(player models.Player, speed int, flips int, engineHeat int, cooled int) (MoveOdds, *MoveOdds) {
+	board := a.game.GetBoard()
+	from, err := board.FindCar(player.GetCar())
+	if err != nil {
+		return MoveOdds{}, nil
+	}
+	deck := player.GetDeck().GetCards()
+	discard := player.GetDiscardPile().GetCards()
+
+	move := moveOdds(board, from, FlipOdds(deck, discard, flips).shift(speed), engineHeat+cooled)
+
+	cost := engine.BoostCost(board, from)
+	if cost > engineHeat {
+		return move, nil
+	}
+	boost := moveOdds(board, from, FlipOdds(deck, discard, flips+1).shift(speed), engineHeat-cost+cooled)
+	return move, &boost
+}
+
+// cooled returns how many Heat cards Cooling icons move back into the engine, with the weather applied
+// Input: cooling - the Cooling icons before the weather
+//
+//	heatCards - the Heat cards in the hand
+//
+// Returns: the number of Heat cards cooled
+func (a *advisor) cooled(cooling int, heatCards int) int {
+	weather := a.game.GetBoard().GetWeather()
+	switch {
+	case weather.NoCooling:
+		cooling = 0
+	case cooling > 0:
+		cooling = max(cooling+weather.CoolingModifier, 0)
+	}
+	return min(cooling, heatCards)
+}
+
+// player returns the player on a seat
+// Input: seat - the seat index
+// Returns: the Player, an error if the seat does not exist or is a Legend
+func (a *advisor) player(seat int) (models.Player, error) {
+	players := a.game.GetPlayers()
+	if seat < 0 || seat >= len(players) {
+		return nil, fmt.Errorf("seat %d does not exist", seat)
+	}
+	return players[seat], nil
+}
+
+// countCards counts the cards with a name
+// Input: cards - the cards to look through
+//
+//	name - the card name
+//
+// Returns: the count
+func countCards(cards []models.Card, name string) int {
+	count := 0
+	for _, card := range cards {
+		if card.GetName() == name {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,229 @@
+package analysis
+
+import (
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// Helper function to create a solo race on a short track; the car starts on space 2 and the corner on space 6 has a limit of 2
+func createCornerGame(t *testing.T) engine.Game {
+	return createLimitGame(t, 2)
+}
+
+// Helper function to create a solo race on a short track with a corner on space 6 of a given speed limit
+func createLimitGame(t *testing.T, limit int) engine.Game {
+	game, err := engine.NewGame(engine.Config{
+		Seed: 1,
+		Track: tracks.Track{
+			Name:    "Test",
+			Length:  30,
+			Laps:    1,
+			Corners: []tracks.Corner{{Space: 6, SpeedLimit: limit}, {Space: 20, SpeedLimit: 5}},
+		},
+		Seats: []engine.Seat{{Name: "Solo", Color: models.Red}},
+	})
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+	return game
+}
+
+// Helper function to replace a player's hand, deck and discard pile
+func setCards(player models.Player, hand []models.Card, deck []models.Card, discard []models.Card) {
+	for len(player.GetHand().GetCards()) > 0 {
+		player.GetHand().RemoveCard(0)
+	}
+	player.GetHand().AddCards(hand)
+
+	for !player.GetDeck().IsEmpty() {
+		player.GetDeck().DrawCard()
+	}
+	player.GetDeck().AddCardsToTop(deck)
+
+	for player.GetDiscardPile().Size() > 0 {
+		player.GetDiscardPile().RemoveCard(0)
+	}
+	for _, card := range discard {
+		player.GetDiscardPile().AddCard(card)
+	}
+}
+
+func TestAdvisor_EvaluatePlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		hand      []models.Card
+		engine    int
+		cards     []int
+		move      MoveOdds
+		boost     *MoveOdds
+		wantErr   bool
+		wantHeat  int
+		wantCount int
+	}{
+		{
+			name:   "Over the limit with heat to spare",
+			hand:   speedCards(4, 1),
+			engine: 6,
+			cards:  []int{0},
+			move:   MoveOdds{Speed: Distribution{4: 1}, Distance: Distribution{4: 1}, Heat: Distribution{2: 1}},
+			boost:  &MoveOdds{Speed: Distribution{5: 0.5, 7: 0.5}, Distance: Distribution{5: 0.5, 7: 0.5}, Heat: Distribution{3: 0.5, 5: 0.5}},
+		},
+		{
+			name:   "Boosting spins out",
+			hand:   speedCards(4, 1),
+			engine: 3,
+			cards:  []int{0},
+			move:   MoveOdds{Speed: Distribution{4: 1}, Distance: Distribution{4: 1}, Heat: Distribution{2: 1}},
+			boost:  &MoveOdds{Speed: Distribution{5: 0.5, 7: 0.5}, Distance: Distribution{3: 1}, Heat: Distribution{0: 1}, SpinOut: 1},
+		},
+		{
+			name:      "Stress flips a card",
+			hand:      []models.Card{models.NewStressCard(), models.NewSpeedCard(1)},
+			engine:    6,
+			cards:     []int{0},
+			move:      MoveOdds{Speed: Distribution{1: 0.5, 3: 0.5}, Distance: Distribution{1: 0.5, 3: 0.5}, Heat: Distribution{0: 1}},
+			boost:     &MoveOdds{Speed: Distribution{4: 1}, Distance: Distribution{4: 1}, Heat: Distribution{2: 1}},
+			wantCount: 1,
+		},
+		{
+			name:   "Cooling pays for the corner",
+			hand:   []models.Card{models.NewSpeedCard(4), models.NewHeatCard(), models.NewHeatCard()},
+			engine: 1,
+			cards:  []int{0},
+			move:   MoveOdds{Speed: Distribution{4: 1}, Distance: Distribution{4: 1}, Heat: Distribution{2: 1}},
+			boost:  &MoveOdds{Speed: Distribution{5: 0.5, 7: 0.5}, Distance: Distribution{3: 1}, Heat: Distribution{0: 1}, SpinOut: 1},
+		},
+		{
+			name:   "No heat to boost",
+			hand:   speedCards(4, 1),
+			engine: 0,
+			cards:  []int{1},
+			move:   MoveOdds{Speed: Distribution{1: 1}, Distance: Distribution{1: 1}, Heat: Distribution{0: 1}},
+		},
+		{
+			name:    "Illegal plan",
+			hand:    speedCards(4, 1),
+			engine:  6,
+			cards:   []int{0, 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := createCornerGame(t)
+			player := game.GetPlayers()[0]
+			setCards(player, tt.hand, speedCards(1, 3), nil)
+			player.GetCar().SetEngine(tt.engine)
+
+			odds, err := NewAdvisor(game).EvaluatePlan(0, engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: tt.cards})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvaluatePlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if odds.Heat != tt.wantHeat || odds.Stress != tt.wantCount {
+				t.Errorf("EvaluatePlan() heat = %d, stress = %d, want %d and %d", odds.Heat, odds.Stress, tt.wantHeat, tt.wantCount)
+			}
+			checkMove(t, "move", odds.Move, tt.move)
+			if (odds.Boost == nil) != (tt.boost == nil) {
+				t.Fatalf("EvaluatePlan() boost = %v, want %v", odds.Boost, tt.boost)
+			}
+			if tt.boost != nil {
+				checkMove(t, "boost", *odds.Boost, *tt.boost)
+			}
+		})
+	}
+}
+
+// Helper function to compare move odds
+func checkMove(t *testing.T, name string, got MoveOdds, want MoveOdds) {
+	t.Helper()
+	if !equalOdds(got.Speed, want.Speed, 1e-9) || !equalOdds(got.Distance, want.Distance, 1e-9) || !equalOdds(got.Heat, want.Heat, 1e-9) {
+		t.Errorf("%s = speed %v, distance %v, heat %v, want %v, %v, %v", name, got.Speed, got.Distance, got.Heat, want.Speed, want.Distance, want.Heat)
+	}
+	if got.SpinOut != want.SpinOut {
+		t.Errorf("%s spin-out = %v, want %v", name, got.SpinOut, want.SpinOut)
+	}
+	if got.ExpectedDistance != want.Distance.GetMean() || got.ExpectedHeat != want.Heat.GetMean() {
+		t.Errorf("%s expected distance %v and heat %v do not match the distributions", name, got.ExpectedDistance, got.ExpectedHeat)
+	}
+}
+
+func TestAdvisor_GetPlanOdds(t *testing.T) {
+	game := createCornerGame(t)
+	advisor := NewAdvisor(game)
+
+	odds, err := advisor.GetPlanOdds(0)
+	if err != nil {
+		t.Fatalf("GetPlanOdds() error = %v", err)
+	}
+	actions := game.LegalActions(0)
+	if len(odds) != len(actions) {
+		t.Fatalf("GetPlanOdds() = %d plans, want %d", len(odds), len(actions))
+	}
+	for i, plan := range odds {
+		if plan.Gear != actions[i].Gear || len(plan.Cards) != len(actions[i].Cards) {
+			t.Errorf("plan %d is gear %d with %v, want gear %d with %v", i, plan.Gear, plan.Cards, actions[i].Gear, actions[i].Cards)
+		}
+	}
+
+	if _, err := advisor.GetPlanOdds(1); err == nil {
+		t.Error("GetPlanOdds() for a seat that does not exist should fail")
+	}
+	if err := game.Submit(0, actions[0]); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := advisor.GetPlanOdds(0); err == nil {
+		t.Error("GetPlanOdds() after planning should fail")
+	}
+}
+
+func TestAdvisor_GetReactOdds(t *testing.T) {
+	game := createCornerGame(t)
+	advisor := NewAdvisor(game)
+	setCards(game.GetPlayers()[0], speedCards(4, 1), speedCards(1, 3), nil)
+
+	if _, err := advisor.GetReactOdds(0); err == nil {
+		t.Error("GetReactOdds() while planning should fail")
+	}
+	if err := game.Submit(0, engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{0}}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	odds, err := advisor.GetReactOdds(0)
+	if err != nil {
+		t.Fatalf("GetReactOdds() error = %v", err)
+	}
+	checkMove(t, "move", odds.Move, MoveOdds{Speed: Distribution{4: 1}, Distance: Distribution{4: 1}, Heat: Distribution{2: 1}})
+	if odds.Boost == nil || odds.Boost.Speed.GetMean() != 6 {
+		t.Errorf("GetReactOdds() boost = %v, want a mean speed of 6", odds.Boost)
+	}
+}
+
+func TestAdvisor_GetStressOdds(t *testing.T) {
+	game := createCornerGame(t)
+	advisor := NewAdvisor(game)
+	setCards(game.GetPlayers()[0], nil, speedCards(1, 3), speedCards(2))
+
+	odds, err := advisor.GetStressOdds(0, 3)
+	if err != nil {
+		t.Fatalf("GetStressOdds() error = %v", err)
+	}
+	// The first two flips use up the deck, the third one comes from the 1, 2 and 3 shuffled back in
+	if !equalOdds(odds, Distribution{5: 1.0 / 3, 6: 1.0 / 3, 7: 1.0 / 3}, 1e-9) {
+		t.Errorf("GetStressOdds() = %v, want 5, 6 or 7", odds)
+	}
+
+	if _, err := advisor.GetStressOdds(0, -1); err == nil {
+		t.Error("GetStressOdds() with a negative count should fail")
+	}
+	if _, err := advisor.GetStressOdds(-1, 1); err == nil {
+		t.Error("GetStressOdds() for a seat that does not exist should fail")
+	}
+}
@@ -0,0 +1,274 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+const (
+	// DefaultSuggestions is the number of moves Suggest returns at most
+	DefaultSuggestions = 3
+
+	// heatPenalty is what paying one heat is worth, in spaces
+	heatPenalty = 1.0
+
+	// spinOutPenalty is what a spin-out costs on top of the distance lost, in spaces: the Stress cards and the drop to first gear
+	spinOutPenalty = 8.0
+
+	// clearBonus is what getting a Stress or Heat card out of the hand is worth, in spaces
+	clearBonus = 0.5
+
+	// unlimitedHeat is an engine that can pay for any corner, used to find the corners a move passes
+	unlimitedHeat = math.MaxInt32
+)
+
+// Suggestion is a recommended move with the reasons for it
+type Suggestion struct {
+	// Action is the move to submit
+	Action engine.Action `json:"action"`
+
+	// Cards are the names of the cards played, in hand order
+	Cards []string `json:"cards,omitempty"`
+
+	// Score ranks the suggestions: the expected distance less the heat paid and the cost of spinning out, in spaces
+	Score float64 `json:"score"`
+
+	// Rationale explains the move in plain language
+	Rationale string `json:"rationale"`
+
+	// Odds are the odds the rationale is based on
+	Odds MoveOdds `json:"odds"`
+}
+
+// Suggest recommends the best few moves for a player: plans while planning and whether to boost while reacting
+// Input: player - the player to advise
+// Returns: up to DefaultSuggestions suggestions, best first, an error if the player is not in the game or has no move to make
+func (a *advisor) Suggest(player models.Player) ([]Suggestion, error) {
+	seat := -1
+	for i, p := range a.game.GetPlayers() {
+		if p == player {
+			seat = i
+		}
+	}
+	if seat < 0 {
+		return nil, errors.New("player is not in the game")
+	}
+
+	var suggestions []Suggestion
+	var err error
+	switch a.game.GetPhase() {
+	case engine.PhasePlanning:
+		suggestions, err = a.suggestPlans(seat)
+	case engine.PhaseReact:
+		suggestions, err = a.suggestReactions(seat)
+	default:
+		return nil, fmt.Errorf("no hints for the %s phase", a.game.GetPhase())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	return suggestions[:min(len(suggestions), DefaultSuggestions)], nil
+}
+
+// suggestPlans rates every legal plan of a seat
+// Input: seat - the planning seat
+// Returns: a suggestion per plan, an error if the seat is not planning
+func (a *advisor) suggestPlans(seat int) ([]Suggestion, error) {
+	plans, err := a.GetPlanOdds(seat)
+	if err != nil {
+		return nil, err
+	}
+
+	player := a.game.GetPlayers()[seat]
+	hand := player.GetHand().GetCards()
+	from, err := a.game.GetBoard().FindCar(player.GetCar())
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]Suggestion, 0, len(plans))
+	for _, plan := range plans {
+		names := make([]string, 0, len(plan.Cards))
+		for _, index := range plan.Cards {
+			names = append(names, hand[index].GetName())
+		}
+
+		lead := fmt.Sprintf("Gear %d with no cards", plan.Gear)
+		if len(names) > 0 {
+			lead = fmt.Sprintf("Gear %d with %s", plan.Gear, joinParts(names))
+		}
+		parts := a.describeMove(from, plan.Move, plan.Heat)
+		if plan.Cooled > 0 {
+			parts = append(parts, fmt.Sprintf("cools %d Heat", plan.Cooled))
+		}
+		if plan.Stress > 0 {
+			parts = append(parts, fmt.Sprintf("plays off %s", plural(plan.Stress, "Stress card")))
+		}
+
+		suggestions = append(suggestions, Suggestion{
+			Action:    engine.Action{Type: engine.ActionPlan, Gear: plan.Gear, Cards: plan.Cards},
+			Cards:     names,
+			Score:     score(plan.Move, plan.Heat, plan.Stress+plan.Cooled),
+			Rationale: lead + " " + joinParts(parts),
+			Odds:      plan.Move,
+		})
+	}
+	return suggestions, nil
+}
+
+// suggestReactions rates moving with and without a boost
+// Direct Play cards are left to the player; every optional icon is accepted
+// Input: seat - the reacting seat
+// Returns: a suggestion per choice, an error if it is not the seat's turn to react
+func (a *advisor) suggestReactions(seat int) ([]Suggestion, error) {
+	odds, err := a.GetReactOdds(seat)
+	if err != nil {
+		return nil, err
+	}
+
+	board := a.game.GetBoard()
+	from, err := board.FindCar(a.game.GetPlayers()[seat].GetCar())
+	if err != nil {
+		return nil, err
+	}
+
+	// The reaction accepting the most icons without Direct Play cards, with and without a boost
+	reactions := make(map[bool]engine.Action)
+	for _, action := range a.game.LegalActions(seat) {
+		if len(action.DirectPlay) > 0 {
+			continue
+		}
+		if chosen, ok := reactions[action.Boost]; !ok || len(action.Icons) > len(chosen.Icons) {
+			reactions[action.Boost] = action
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, 2)
+	if action, ok := reactions[false]; ok {
+		suggestions = append(suggestions, Suggestion{
+			Action:    action,
+			Score:     score(odds.Move, 0, odds.Cooled),
+			Rationale: "Moving without a boost " + joinParts(a.describeMove(from, odds.Move, 0)),
+			Odds:      odds.Move,
+		})
+	}
+	if action, ok := reactions[true]; ok && odds.Boost != nil {
+		cost := engine.BoostCost(board, from)
+		gain := odds.Boost.Speed.GetMean() - odds.Move.Speed.GetMean()
+		parts := append([]string{fmt.Sprintf("adds %s speed on average", formatNumber(gain))}, a.describeMove(from, *odds.Boost, cost)...)
+		suggestions = append(suggestions, Suggestion{
+			Action:    action,
+			Score:     score(*odds.Boost, cost, odds.Cooled),
+			Rationale: "Boosting " + joinParts(parts),
+			Odds:      *odds.Boost,
+		})
+	}
+	return suggestions, nil
+}
+
+// describeMove explains how far a move goes, what the corners do and what it costs
+// Input: from - the index of the space the car starts on
+//
+//	odds - the odds of the move
+//	paid - the heat paid before moving
+//
+// Returns: the parts of the explanation
+func (a *advisor) describeMove(from int, odds MoveOdds, paid int) []string {
+	board := a.game.GetBoard()
+	parts := make([]string, 0, 3)
+
+	if distances := odds.Distance.GetOutcomes(); len(distances) == 1 {
+		parts = append(parts, "moves "+plural(distances[0], "space"))
+	} else {
+		parts = append(parts, fmt.Sprintf("moves %s spaces on average", formatNumber(odds.ExpectedDistance)))
+	}
+
+	// The nearest corner is the first one the fastest outcome passes
+	speeds := odds.Speed.GetOutcomes()
+	corner := -1
+	if len(speeds) > 0 {
+		if corners := ResolveMove(board, from, speeds[len(speeds)-1], unlimitedHeat).Corners; len(corners) > 0 {
+			corner = corners[0]
+		}
+	}
+	if corner >= 0 {
+		limit := board.GetCornerLimit(corner)
+		fastest, slowest := speeds[len(speeds)-1], speeds[0]
+		switch {
+		case odds.SpinOut > 0:
+			parts = append(parts, fmt.Sprintf("risks a %.0f%% chance of spinning out at the corner limit of %d", odds.SpinOut*100, limit))
+		case fastest == limit && slowest == limit:
+			parts = append(parts, fmt.Sprintf("keeps you at the corner limit of %d", limit))
+		case fastest <= limit:
+			parts = append(parts, fmt.Sprintf("keeps you within the corner limit of %d", limit))
+		default:
+			parts = append(parts, fmt.Sprintf("goes over the corner limit of %d", limit))
+		}
+	} else {
+		parts = append(parts, "stays clear of the corners")
+	}
+
+	if heat := float64(paid) + odds.ExpectedHeat; heat == 0 {
+		parts = append(parts, "costs no heat")
+	} else {
+		parts = append(parts, fmt.Sprintf("costs %s heat", formatNumber(heat)))
+	}
+	return parts
+}
+
+// score rates a move in spaces
+// Input: odds - the odds of the move
+//
+//	paid - the heat paid before moving
+//	cleared - the Stress and Heat cards taken out of the hand
+//
+// Returns: the score, higher is better
+func score(odds MoveOdds, paid int, cleared int) float64 {
+	return odds.ExpectedDistance -
+		heatPenalty*(float64(paid)+odds.ExpectedHeat) -
+		spinOutPenalty*odds.SpinOut +
+		clearBonus*float64(cleared)
+}
+
+// joinParts joins phrases into a list: "a", "a and b", "a, b and c"
+// Input: parts - the phrases
+// Returns: the list as a string
+func joinParts(parts []string) string {
+	if len(parts) <= 1 {
+		return strings.Join(parts, "")
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
+
+// plural formats a count with a noun, adding an s when the count is not 1
+// Input: count - the count
+//
+//	noun - the singular noun
+//
+// Returns: the phrase, like "1 space" or "3 spaces"
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// formatNumber formats a value without decimals when it is whole and with one otherwise
+// Input: value - the value
+// Returns: the value as a string
+func formatNumber(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.1f", value)
+}
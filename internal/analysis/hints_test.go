@@ -0,0 +1,175 @@
+package analysis
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+func TestAdvisor_Suggest_Planning(t *testing.T) {
+	game := createLimitGame(t, 4)
+	player := game.GetPlayers()[0]
+	setCards(player, speedCards(4, 1, 1, 2), speedCards(1, 3), nil)
+
+	suggestions, err := NewAdvisor(game).Suggest(player)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != DefaultSuggestions {
+		t.Fatalf("Suggest() = %d suggestions, want %d", len(suggestions), DefaultSuggestions)
+	}
+
+	for i, suggestion := range suggestions {
+		if i > 0 && suggestion.Score > suggestions[i-1].Score {
+			t.Errorf("suggestion %d scores %v, more than the one before", i, suggestion.Score)
+		}
+		if !isLegal(game, suggestion.Action) {
+			t.Errorf("suggestion %d = %+v is not a legal action", i, suggestion.Action)
+		}
+		if !strings.HasPrefix(suggestion.Rationale, "Gear ") || len(suggestion.Cards) != len(suggestion.Action.Cards) {
+			t.Errorf("suggestion %d = %+v", i, suggestion)
+		}
+	}
+}
+
+func TestAdvisor_Suggest_Rationale(t *testing.T) {
+	tests := []struct {
+		name     string
+		hand     []models.Card
+		gear     int
+		cards    []int
+		expected string
+	}{
+		{
+			name:     "At the corner limit",
+			hand:     speedCards(4, 1),
+			gear:     1,
+			cards:    []int{0},
+			expected: "Gear 1 with Speed 4 moves 4 spaces, keeps you at the corner limit of 4 and costs no heat",
+		},
+		{
+			name:     "Short of the corner",
+			hand:     speedCards(4, 1),
+			gear:     1,
+			cards:    []int{1},
+			expected: "Gear 1 with Speed 1 moves 1 space, stays clear of the corners and costs no heat",
+		},
+		{
+			name:     "Over the limit with cooling",
+			hand:     []models.Card{models.NewSpeedCard(4), models.NewSpeedCard(1), models.NewHeatCard()},
+			gear:     2,
+			cards:    []int{0, 1},
+			expected: "Gear 2 with Speed 4 and Speed 1 moves 5 spaces, goes over the corner limit of 4, costs 1 heat and cools 1 Heat",
+		},
+		{
+			name:     "Stress",
+			hand:     []models.Card{models.NewStressCard(), models.NewSpeedCard(4)},
+			gear:     2,
+			cards:    []int{0, 1},
+			expected: "Gear 2 with Stress and Speed 4 moves 6 spaces on average, goes over the corner limit of 4, costs 2 heat and plays off 1 Stress card",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := createLimitGame(t, 4)
+			setCards(game.GetPlayers()[0], tt.hand, speedCards(1, 3), nil)
+
+			suggestions, err := NewAdvisor(game).(*advisor).suggestPlans(0)
+			if err != nil {
+				t.Fatalf("suggestPlans() error = %v", err)
+			}
+			for _, suggestion := range suggestions {
+				if suggestion.Action.Gear == tt.gear && reflect.DeepEqual(suggestion.Action.Cards, tt.cards) {
+					if suggestion.Rationale != tt.expected {
+						t.Errorf("Rationale = %q, want %q", suggestion.Rationale, tt.expected)
+					}
+					return
+				}
+			}
+			t.Fatalf("no suggestion for gear %d with cards %v", tt.gear, tt.cards)
+		})
+	}
+}
+
+func TestAdvisor_Suggest_React(t *testing.T) {
+	game := createCornerGame(t)
+	player := game.GetPlayers()[0]
+	setCards(player, speedCards(4, 1), speedCards(1, 3), nil)
+	if err := game.Submit(0, engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{0}}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	suggestions, err := NewAdvisor(game).Suggest(player)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("Suggest() = %d suggestions, want moving and boosting", len(suggestions))
+	}
+
+	// Boosting adds 2 on average but the corner limit of 2 makes every extra space cost heat
+	if suggestions[0].Action.Boost || !suggestions[1].Action.Boost {
+		t.Errorf("Suggest() = %+v, want moving without a boost first", suggestions)
+	}
+	want := "Boosting adds 2 speed on average, moves 6 spaces on average, goes over the corner limit of 2 and costs 5 heat"
+	if suggestions[1].Rationale != want {
+		t.Errorf("Rationale = %q, want %q", suggestions[1].Rationale, want)
+	}
+	for _, suggestion := range suggestions {
+		if !isLegal(game, suggestion.Action) {
+			t.Errorf("suggestion %+v is not a legal action", suggestion.Action)
+		}
+	}
+}
+
+// Helper function to check an action is one of the seat's legal actions
+func isLegal(game engine.Game, action engine.Action) bool {
+	for _, legal := range game.LegalActions(0) {
+		if reflect.DeepEqual(legal, action) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAdvisor_Suggest_Errors(t *testing.T) {
+	game := createCornerGame(t)
+	other := createCornerGame(t)
+
+	if _, err := NewAdvisor(game).Suggest(other.GetPlayers()[0]); err == nil {
+		t.Error("Suggest() for a player from another game should fail")
+	}
+
+	player := game.GetPlayers()[0]
+	setCards(player, speedCards(1, 1), speedCards(1, 3), nil)
+	game.Submit(0, engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{0}})
+	game.Submit(0, engine.Action{Type: engine.ActionReact})
+	if game.GetPhase() != engine.PhaseDiscard {
+		t.Fatalf("GetPhase() = %s, want %s", game.GetPhase(), engine.PhaseDiscard)
+	}
+	if _, err := NewAdvisor(game).Suggest(player); err == nil {
+		t.Error("Suggest() while discarding should fail")
+	}
+}
+
+func TestJoinParts(t *testing.T) {
+	tests := []struct {
+		parts    []string
+		expected string
+	}{
+		{parts: nil, expected: ""},
+		{parts: []string{"a"}, expected: "a"},
+		{parts: []string{"a", "b"}, expected: "a and b"},
+		{parts: []string{"a", "b", "c"}, expected: "a, b and c"},
+	}
+
+	for _, tt := range tests {
+		if got := joinParts(tt.parts); got != tt.expected {
+			t.Errorf("joinParts(%v) = %q, want %q", tt.parts, got, tt.expected)
+		}
+	}
+}
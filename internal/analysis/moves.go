@@ -0,0 +1,112 @@
+package analysis
+
+import (
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+// MoveOdds describes what a move may lead to
+type MoveOdds struct {
+	// Speed is the distribution of the car's speed
+	Speed Distribution `json:"speed"`
+
+	// Distance is the distribution of the spaces moved, after blocked spaces and spin-outs
+	Distance Distribution `json:"distance"`
+
+	// Heat is the distribution of the heat paid in corners
+	Heat Distribution `json:"heat"`
+
+	// SpinOut is the probability of spinning out in a corner
+	SpinOut float64 `json:"spin_out"`
+
+	// ExpectedDistance and ExpectedHeat are the means of Distance and Heat
+	ExpectedDistance float64 `json:"expected_distance"`
+	ExpectedHeat     float64 `json:"expected_heat"`
+}
+
+// CornerOutcome is what happens to a car moving at one speed
+type CornerOutcome struct {
+	// Distance is the number of spaces the car ends up from where it started
+	Distance int `json:"distance"`
+
+	// Heat is the heat paid in the corners taken
+	Heat int `json:"heat"`
+
+	// SpunOut is whether the car could not pay for a corner
+	SpunOut bool `json:"spun_out"`
+
+	// Corners are the corner spaces passed, up to the one the car spun out in
+	Corners []int `json:"corners,omitempty"`
+}
+
+// ResolveMove works out where a car ends up and what the corners cost if it moves at a speed
+// The other cars are taken to stay where they are, and slipstreaming is left out
+// Input: board - the board the race is run on
+//
+//	from - the index of the space the car starts on
+//	speed - the car's speed
+//	engineHeat - the heat left in the engine when the corners are checked
+//
+// Returns: the CornerOutcome
+func ResolveMove(board models.Board, from int, speed int, engineHeat int) CornerOutcome {
+	spaces := board.GetSpaces()
+	length := len(spaces)
+
+	// The car stops on the furthest space that is not full, like Board.MoveCar
+	moved := 0
+	for d := max(speed, 0); d > 0; d-- {
+		if !spaces[(from+d)%length].IsFull() {
+			moved = d
+			break
+		}
+	}
+
+	outcome := CornerOutcome{Distance: moved}
+	for d := 1; d <= moved; d++ {
+		corner := (from + d) % length
+		if spaces[corner].GetCorner() <= 0 {
+			continue
+		}
+		outcome.Corners = append(outcome.Corners, corner)
+
+		heat := engine.CornerHeat(board, corner, speed)
+		if heat > engineHeat-outcome.Heat {
+			// The car goes back to the first space before the corner that is not full
+			back := d - 1
+			for back > 0 && spaces[(from+back)%length].IsFull() {
+				back--
+			}
+			outcome.Distance = back
+			outcome.SpunOut = true
+			return outcome
+		}
+		outcome.Heat += heat
+	}
+	return outcome
+}
+
+// moveOdds works out a MoveOdds from the distribution of a car's speed
+// Input: board - the board the race is run on
+//
+//	from - the index of the space the car starts on
+//	speed - the distribution of the car's speed
+//	engineHeat - the heat left in the engine when the corners are checked
+//
+// Returns: the MoveOdds
+func moveOdds(board models.Board, from int, speed Distribution, engineHeat int) MoveOdds {
+	odds := MoveOdds{Speed: speed, Distance: make(Distribution), Heat: make(Distribution)}
+	for _, value := range speed.GetOutcomes() {
+		probability := speed[value]
+		outcome := ResolveMove(board, from, value, engineHeat)
+
+		odds.Distance[outcome.Distance] += probability
+		odds.Heat[outcome.Heat] += probability
+		if outcome.SpunOut {
+			odds.SpinOut += probability
+		}
+	}
+
+	odds.ExpectedDistance = odds.Distance.GetMean()
+	odds.ExpectedHeat = odds.Heat.GetMean()
+	return odds
+}
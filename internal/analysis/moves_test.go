@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveMove(t *testing.T) {
+	tests := []struct {
+		name       string
+		speed      int
+		engineHeat int
+		expected   CornerOutcome
+	}{
+		{name: "Stops before the corner", speed: 3, engineHeat: 0, expected: CornerOutcome{Distance: 3}},
+		{name: "At the limit", speed: 2, engineHeat: 0, expected: CornerOutcome{Distance: 2}},
+		{name: "Pays for the corner", speed: 4, engineHeat: 2, expected: CornerOutcome{Distance: 4, Heat: 2, Corners: []int{6}}},
+		{name: "Spins out", speed: 6, engineHeat: 3, expected: CornerOutcome{Distance: 3, SpunOut: true, Corners: []int{6}}},
+		{name: "Standing still", speed: 0, engineHeat: 0, expected: CornerOutcome{}},
+	}
+
+	game := createCornerGame(t)
+	board := game.GetBoard()
+	from, err := board.FindCar(game.GetPlayers()[0].GetCar())
+	if err != nil {
+		t.Fatalf("FindCar() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveMove(board, from, tt.speed, tt.engineHeat); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ResolveMove() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package analysis
+
+import (
+	"math/rand"
+	"sort"
+
+	"race-cars/internal/models"
+)
+
+// Distribution maps every possible outcome to its probability
+type Distribution map[int]float64
+
+// GetOutcomes returns the possible outcomes
+// Input: none
+// Returns: the outcomes with a probability above 0, lowest first
+func (d Distribution) GetOutcomes() []int {
+	outcomes := make([]int, 0, len(d))
+	for outcome, probability := range d {
+		if probability > 0 {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	sort.Ints(outcomes)
+	return outcomes
+}
+
+// GetMean returns the expected outcome
+// Input: none
+// Returns: the mean, 0 for an empty distribution
+func (d Distribution) GetMean() float64 {
+	mean := 0.0
+	for _, outcome := range d.GetOutcomes() {
+		mean += float64(outcome) * d[outcome]
+	}
+	return mean
+}
+
+// GetAtLeast returns the probability of an outcome of at least a value
+// Input: value - the lowest outcome counted
+// Returns: the probability from 0 to 1
+func (d Distribution) GetAtLeast(value int) float64 {
+	probability := 0.0
+	for _, outcome := range d.GetOutcomes() {
+		if outcome >= value {
+			probability += d[outcome]
+		}
+	}
+	return probability
+}
+
+// shift returns the distribution with every outcome moved by an amount
+// Input: amount - the amount added to every outcome
+// Returns: a new Distribution
+func (d Distribution) shift(amount int) Distribution {
+	shifted := make(Distribution, len(d))
+	for outcome, probability := range d {
+		shifted[outcome+amount] = probability
+	}
+	return shifted
+}
+
+// flipState is the part of a deck and discard pile that decides what the next flip finds
+// Only basic cards stop a flip, and they are found in a uniformly random order, so counting them by speed is enough
+type flipState struct {
+	deck    string
+	discard string
+	speed   int
+}
+
+// FlipOdds works out the exact total speed found by flipping cards until a basic card is found, a number of times
+// This is how Stress cards and Boost icons resolve: every basic card in the deck is equally likely to turn up
+// first, and when the deck runs out the discard pile, with the cards just flipped, is shuffled back in
+// Only the contents of the deck and discard pile are used, never their order
+// Input: deck - the cards in the deck
+//
+//	discard - the cards in the discard pile
+//	flips - the number of flips, like the Stress cards played plus the boosts
+//
+// Returns: the Distribution of the total speed found
+func FlipOdds(deck []models.Card, discard []models.Card, flips int) Distribution {
+	speeds := basicSpeeds(deck, discard)
+	start := flipState{deck: countKey(deck, speeds), discard: countKey(discard, speeds)}
+
+	// Flips that end in the same state are merged, so the work grows with the distinct states rather than the paths
+	states := map[flipState]float64{start: 1}
+	for i := 0; i < flips; i++ {
+		next := make(map[flipState]float64, len(states))
+		for state, probability := range states {
+			flip(state, probability, speeds, next)
+		}
+		states = next
+	}
+
+	odds := make(Distribution)
+	for state, probability := range states {
+		odds[state.speed] += probability
+	}
+	return odds
+}
+
+// flip adds the states one more flip leads to
+// Input: state - the state before the flip
+//
+//	probability - the probability of the state
+//	speeds - the speed of every count slot
+//	next - the states after the flip, added to
+//
+// Returns: none
+func flip(state flipState, probability float64, speeds []int, next map[flipState]float64) {
+	deck := []byte(state.deck)
+	discard := []byte(state.discard)
+
+	if total(deck) == 0 {
+		// The deck is flipped through and the discard pile is shuffled in to form the new deck
+		for i := range deck {
+			deck[i] += discard[i]
+			discard[i] = 0
+		}
+		if total(deck) == 0 {
+			next[state] += probability
+			return
+		}
+	}
+
+	cards := float64(total(deck))
+	for i, count := range deck {
+		if count == 0 {
+			continue
+		}
+		deck[i]--
+		discard[i]++
+		after := flipState{deck: string(deck), discard: string(discard), speed: state.speed + speeds[i]}
+		next[after] += probability * float64(count) / cards
+		deck[i]++
+		discard[i]--
+	}
+}
+
+// SampleFlipOdds estimates FlipOdds by shuffling the deck and flipping cards like the game does
+// Input: deck - the cards in the deck
+//
+//	discard - the cards in the discard pile
+//	flips - the number of flips
+//	samples - the number of shuffles to try
+//	rng - the random source for the shuffles
+//
+// Returns: the sampled Distribution of the total speed found
+func SampleFlipOdds(deck []models.Card, discard []models.Card, flips int, samples int, rng *rand.Rand) Distribution {
+	odds := make(Distribution)
+	if samples <= 0 {
+		return odds
+	}
+
+	for s := 0; s < samples; s++ {
+		pile := append([]models.Card(nil), deck...)
+		flipped := append([]models.Card(nil), discard...)
+		rng.Shuffle(len(pile), func(i, j int) { pile[i], pile[j] = pile[j], pile[i] })
+
+		speed := 0
+		for f := 0; f < flips; f++ {
+			reshuffled := false
+			for {
+				if len(pile) == 0 {
+					if reshuffled || len(flipped) == 0 {
+						break
+					}
+					pile, flipped = flipped, nil
+					rng.Shuffle(len(pile), func(i, j int) { pile[i], pile[j] = pile[j], pile[i] })
+					reshuffled = true
+				}
+
+				card := pile[len(pile)-1]
+				pile = pile[:len(pile)-1]
+				flipped = append(flipped, card)
+				if card.IsBasic() {
+					speed += card.GetSpeed()
+					break
+				}
+			}
+		}
+		odds[speed] += 1 / float64(samples)
+	}
+	return odds
+}
+
+// basicSpeeds lists the distinct speeds of the basic cards
+// Input: piles - the cards to look through
+// Returns: the speeds, lowest first
+func basicSpeeds(piles ...[]models.Card) []int {
+	seen := make(map[int]bool)
+	speeds := make([]int, 0)
+	for _, pile := range piles {
+		for _, card := range pile {
+			if card.IsBasic() && !seen[card.GetSpeed()] {
+				seen[card.GetSpeed()] = true
+				speeds = append(speeds, card.GetSpeed())
+			}
+		}
+	}
+	sort.Ints(speeds)
+	return speeds
+}
+
+// countKey counts the basic cards of every speed, one byte per speed so the counts can key a map
+// Input: cards - the cards to count
+//
+//	speeds - the speed of every slot
+//
+// Returns: the counts as a string
+func countKey(cards []models.Card, speeds []int) string {
+	counts := make([]byte, len(speeds))
+	for _, card := range cards {
+		if !card.IsBasic() {
+			continue
+		}
+		counts[sort.SearchInts(speeds, card.GetSpeed())]++
+	}
+	return string(counts)
+}
+
+// total adds up counts
+// Input: counts - the counts
+// Returns: the sum
+func total(counts []byte) int {
+	sum := 0
+	for _, count := range counts {
+		sum += int(count)
+	}
+	return sum
+}
@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"race-cars/internal/models"
+)
+
+// Helper function to create speed cards
+func speedCards(speeds ...int) []models.Card {
+	cards := make([]models.Card, 0, len(speeds))
+	for _, speed := range speeds {
+		cards = append(cards, models.NewSpeedCard(speed))
+	}
+	return cards
+}
+
+// Helper function to compare distributions up to rounding
+func equalOdds(got Distribution, want Distribution, tolerance float64) bool {
+	for outcome := range want {
+		if math.Abs(got[outcome]-want[outcome]) > tolerance {
+			return false
+		}
+	}
+	for outcome, probability := range got {
+		if _, ok := want[outcome]; !ok && probability > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFlipOdds(t *testing.T) {
+	tests := []struct {
+		name     string
+		deck     []models.Card
+		discard  []models.Card
+		flips    int
+		expected Distribution
+	}{
+		{
+			name:     "No flips",
+			deck:     speedCards(1, 3),
+			expected: Distribution{0: 1},
+		},
+		{
+			name:     "Non-basic cards are flipped past",
+			deck:     append(speedCards(1, 3), models.NewStressCard(), models.NewHeatCard()),
+			flips:    1,
+			expected: Distribution{1: 0.5, 3: 0.5},
+		},
+		{
+			name:     "Flips without replacement",
+			deck:     speedCards(1, 3),
+			flips:    2,
+			expected: Distribution{4: 1},
+		},
+		{
+			name:     "Discard pile shuffled in when the deck runs out",
+			deck:     []models.Card{models.NewHeatCard()},
+			discard:  speedCards(2, 4, 4),
+			flips:    1,
+			expected: Distribution{2: 1.0 / 3, 4: 2.0 / 3},
+		},
+		{
+			name:     "Flipped cards are shuffled back in",
+			deck:     speedCards(1, 3),
+			flips:    3,
+			expected: Distribution{5: 0.5, 7: 0.5},
+		},
+		{
+			name:     "Nothing to flip",
+			deck:     []models.Card{models.NewStressCard()},
+			flips:    2,
+			expected: Distribution{0: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlipOdds(tt.deck, tt.discard, tt.flips)
+			if !equalOdds(got, tt.expected, 1e-9) {
+				t.Errorf("FlipOdds() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFlipOdds_MatchesSampling(t *testing.T) {
+	cards := models.NewStartingCards()
+	deck, discard := cards[:9], cards[9:]
+
+	for flips := 1; flips <= 4; flips++ {
+		exact := FlipOdds(deck, discard, flips)
+		sampled := SampleFlipOdds(deck, discard, flips, 20000, rand.New(rand.NewSource(int64(flips))))
+		if !equalOdds(sampled, exact, 0.02) {
+			t.Errorf("%d flips: sampled %v, exact %v", flips, sampled, exact)
+		}
+
+		sum := 0.0
+		for _, probability := range exact {
+			sum += probability
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("%d flips: probabilities add up to %v", flips, sum)
+		}
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	odds := Distribution{4: 0.25, 1: 0.5, 2: 0, 6: 0.25}
+
+	if got := odds.GetOutcomes(); !reflect.DeepEqual(got, []int{1, 4, 6}) {
+		t.Errorf("GetOutcomes() = %v, want [1 4 6]", got)
+	}
+	if got := odds.GetMean(); got != 3 {
+		t.Errorf("GetMean() = %v, want 3", got)
+	}
+	if got := odds.GetAtLeast(4); got != 0.5 {
+		t.Errorf("GetAtLeast(4) = %v, want 0.5", got)
+	}
+	if got := odds.shift(2); !reflect.DeepEqual(got, Distribution{6: 0.25, 3: 0.5, 4: 0, 8: 0.25}) {
+		t.Errorf("shift(2) = %v", got)
+	}
+}
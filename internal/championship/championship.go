@@ -0,0 +1,334 @@
+package championship
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/tracks"
+)
+
+// Race is one race on the championship calendar
+type Race struct {
+	Track string `json:"track"`
+
+	// Laps overrides the track's number of laps when set
+	Laps int `json:"laps,omitempty"`
+
+	// Event is the name of the championship event drawn for the race, empty for none
+	Event string `json:"event,omitempty"`
+}
+
+// Settings describe a championship
+type Settings struct {
+	Name    string        `json:"name"`
+	Drivers []engine.Seat `json:"drivers"`
+	Races   []Race        `json:"races"`
+	Seed    int64         `json:"seed"`
+
+	// Modules are enabled in every race
+	Modules []string `json:"modules,omitempty"`
+
+	// Points are awarded for first place, second place and so on, nil uses DefaultPoints
+	Points []int `json:"points,omitempty"`
+}
+
+// State is everything needed to save a championship and resume it later
+type State struct {
+	Settings Settings     `json:"settings"`
+	Results  []RaceResult `json:"results"`
+
+	// Upgrades are the Garage upgrades each driver keeps, in seat order
+	Upgrades [][]string `json:"upgrades"`
+}
+
+// Championship strings races on different tracks together and keeps the points table
+// Events and upgrades carry over from one race to the next
+type Championship interface {
+	// GetSettings returns the settings the championship was created with
+	// Returns: the championship's Settings
+	GetSettings() Settings
+
+	// GetNextRace returns the index of the next race on the calendar
+	// Returns: the race index, the number of races once the championship is complete
+	GetNextRace() int
+
+	// IsComplete returns whether every race has been run
+	// Returns: a boolean
+	IsComplete() bool
+
+	// GetRaceConfig returns the configuration of the next race with its events and upgrades applied
+	// Returns: the race Config, an error if the championship is complete or the race is invalid
+	GetRaceConfig() (engine.Config, error)
+
+	// StartRace creates the game for the next race
+	// Returns: a new Game, an error if the race cannot be set up
+	StartRace() (engine.Game, error)
+
+	// RecordRace adds the results of a finished race to the standings and keeps drafted upgrades
+	// Input: game - the finished game for the next race
+	// Returns: an error if the game is not finished, is not the next race or the race's events cannot be found
+	RecordRace(game engine.Game) error
+
+	// GetResults returns the results of every race run so far
+	// Returns: a copy of the race results in calendar order
+	GetResults() []RaceResult
+
+	// GetStandings returns the points table
+	// Returns: the standings, leader first
+	GetStandings() []Standing
+
+	// GetUpgrades returns the Garage upgrades a driver keeps
+	// Input: seat - the driver's seat index
+	// Returns: a copy of the upgrade names
+	GetUpgrades(seat int) []string
+
+	// GetState returns the championship's state for saving
+	// Returns: a copy of the State
+	GetState() State
+}
+
+type championship struct {
+	state State
+}
+
+// NewChampionship creates a championship that has not run any races yet
+// Input: settings - the championship settings
+// Returns: a new Championship, an error if the settings are invalid
+func NewChampionship(settings Settings) (Championship, error) {
+	return Resume(State{Settings: settings})
+}
+
+// Resume restores a championship from a saved state
+// Input: state - the saved state
+// Returns: the Championship, an error if the state is invalid
+func Resume(state State) (Championship, error) {
+	settings := state.Settings
+	if len(settings.Drivers) == 0 || len(settings.Drivers) > engine.MaxSeats {
+		return nil, fmt.Errorf("championship needs between 1 and %d drivers", engine.MaxSeats)
+	}
+	if len(settings.Races) == 0 {
+		return nil, errors.New("championship needs at least one race")
+	}
+	for i, race := range settings.Races {
+		if _, err := tracks.GetTrack(race.Track); err != nil {
+			return nil, fmt.Errorf("race %d: %w", i+1, err)
+		}
+		if race.Event != "" {
+			if _, err := GetRaceEvent(race.Event); err != nil {
+				return nil, fmt.Errorf("race %d: %w", i+1, err)
+			}
+		}
+	}
+	if len(state.Results) > len(settings.Races) {
+		return nil, errors.New("more results than races")
+	}
+
+	if settings.Points == nil {
+		settings.Points = DefaultPoints
+	}
+	upgrades := make([][]string, len(settings.Drivers))
+	for i := range upgrades {
+		if i < len(state.Upgrades) {
+			upgrades[i] = append([]string(nil), state.Upgrades[i]...)
+		}
+	}
+
+	c := &championship{state: State{
+		Settings: settings,
+		Results:  append([]RaceResult(nil), state.Results...),
+		Upgrades: upgrades,
+	}}
+	return c, nil
+}
+
+// Load reads a saved championship
+// Input: r - the reader holding the JSON state
+// Returns: the Championship, an error if the state cannot be read or is invalid
+func Load(r io.Reader) (Championship, error) {
+	var state State
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, err
+	}
+	return Resume(state)
+}
+
+// Save writes a championship's state as JSON
+// Input: w - the writer to save to
+//
+//	c - the championship to save
+//
+// Returns: an error if the state cannot be written
+func Save(w io.Writer, c Championship) error {
+	return json.NewEncoder(w).Encode(c.GetState())
+}
+
+// GetSettings returns the settings the championship was created with
+// Input: none
+// Returns: the championship's Settings
+func (c *championship) GetSettings() Settings {
+	return c.state.Settings
+}
+
+// GetNextRace returns the index of the next race on the calendar
+// Input: none
+// Returns: the race index, the number of races once the championship is complete
+func (c *championship) GetNextRace() int {
+	return len(c.state.Results)
+}
+
+// IsComplete returns whether every race has been run
+// Input: none
+// Returns: a boolean
+func (c *championship) IsComplete() bool {
+	return c.GetNextRace() >= len(c.state.Settings.Races)
+}
+
+// GetRaceConfig returns the configuration of the next race with its events and upgrades applied
+// The event drawn for the race applies, along with every persistent event drawn before it
+// Input: none
+// Returns: the race Config, an error if the championship is complete or the race is invalid
+func (c *championship) GetRaceConfig() (engine.Config, error) {
+	if c.IsComplete() {
+		return engine.Config{}, errors.New("championship is complete")
+	}
+
+	settings := c.state.Settings
+	index := c.GetNextRace()
+	race := settings.Races[index]
+
+	track, err := tracks.GetTrack(race.Track)
+	if err != nil {
+		return engine.Config{}, err
+	}
+
+	config := engine.Config{
+		Track:   track,
+		Laps:    race.Laps,
+		Seed:    settings.Seed + int64(index),
+		Modules: append([]string(nil), settings.Modules...),
+	}
+	for i, driver := range settings.Drivers {
+		config.Seats = append(config.Seats, engine.Seat{
+			Name:     driver.Name,
+			Color:    driver.Color,
+			Upgrades: c.GetUpgrades(i),
+		})
+	}
+
+	events, err := c.activeEvents(index)
+	if err != nil {
+		return engine.Config{}, err
+	}
+	for _, event := range events {
+		event.Apply(&config)
+	}
+
+	return config, nil
+}
+
+// StartRace creates the game for the next race
+// Input: none
+// Returns: a new Game, an error if the race cannot be set up
+func (c *championship) StartRace() (engine.Game, error) {
+	config, err := c.GetRaceConfig()
+	if err != nil {
+		return nil, err
+	}
+	return engine.NewGame(config)
+}
+
+// RecordRace adds the results of a finished race to the standings and keeps drafted upgrades
+// Input: game - the finished game for the next race
+// Returns: an error if the game is not finished, is not the next race or the race's events cannot be found
+func (c *championship) RecordRace(game engine.Game) error {
+	expected, err := c.GetRaceConfig()
+	if err != nil {
+		return err
+	}
+	if !game.IsFinished() {
+		return errors.New("race is not finished")
+	}
+
+	config := game.GetConfig()
+	if config.Track.Name != expected.Track.Name || config.Seed != expected.Seed || len(config.Seats) != len(expected.Seats) {
+		return fmt.Errorf("game is not race %d of the championship", c.GetNextRace()+1)
+	}
+
+	index := c.GetNextRace()
+	events, err := c.activeEvents(index)
+	if err != nil {
+		return err
+	}
+	result := RaceResult{Race: index, Track: config.Track.Name, Results: game.GetResults()}
+	for _, event := range events {
+		result.Events = append(result.Events, event.Name)
+	}
+
+	for _, event := range game.GetEvents() {
+		if event.Type == engine.EventDrafted && len(event.Cards) == 1 {
+			c.state.Upgrades[event.Seat] = append(c.state.Upgrades[event.Seat], event.Cards[0])
+		}
+	}
+	c.state.Results = append(c.state.Results, result)
+	return nil
+}
+
+// GetResults returns the results of every race run so far
+// Input: none
+// Returns: a copy of the race results in calendar order
+func (c *championship) GetResults() []RaceResult {
+	result := make([]RaceResult, len(c.state.Results))
+	copy(result, c.state.Results)
+	return result
+}
+
+// GetStandings returns the points table
+// Input: none
+// Returns: the standings, leader first
+func (c *championship) GetStandings() []Standing {
+	return computeStandings(c.state.Settings.Drivers, c.state.Settings.Points, c.state.Results)
+}
+
+// GetUpgrades returns the Garage upgrades a driver keeps
+// Input: seat - the driver's seat index
+// Returns: a copy of the upgrade names, nil for a missing seat
+func (c *championship) GetUpgrades(seat int) []string {
+	if seat < 0 || seat >= len(c.state.Upgrades) {
+		return nil
+	}
+	return append([]string(nil), c.state.Upgrades[seat]...)
+}
+
+// GetState returns the championship's state for saving
+// Input: none
+// Returns: a copy of the State
+func (c *championship) GetState() State {
+	upgrades := make([][]string, len(c.state.Upgrades))
+	for i := range upgrades {
+		upgrades[i] = c.GetUpgrades(i)
+	}
+	return State{Settings: c.state.Settings, Results: c.GetResults(), Upgrades: upgrades}
+}
+
+// activeEvents returns the events that apply to a race
+// Input: index - the race index
+// Returns: the persistent events of earlier races followed by the race's own event
+func (c *championship) activeEvents(index int) ([]RaceEvent, error) {
+	events := make([]RaceEvent, 0)
+	for i, race := range c.state.Settings.Races[:index+1] {
+		if race.Event == "" {
+			continue
+		}
+		event, err := GetRaceEvent(race.Event)
+		if err != nil {
+			return nil, err
+		}
+		if i == index || event.Persistent {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
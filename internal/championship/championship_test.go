@@ -0,0 +1,262 @@
+package championship
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/garage"
+	"race-cars/internal/models"
+)
+
+// Helper function to create championship settings
+func createTestSettings(races ...Race) Settings {
+	return Settings{Name: "League Night", Drivers: createTestDrivers(3), Races: races, Seed: 7}
+}
+
+// autoAction returns a simple legal action: shift up when possible, play cards without heat costs first, always cool
+func autoAction(game engine.Game, seat int) engine.Action {
+	player := game.GetPlayers()[seat]
+
+	switch game.GetPhase() {
+	case engine.PhaseDraft:
+		return engine.Action{Type: engine.ActionDraft, Cards: []int{0}}
+	case engine.PhasePlanning:
+		current := player.GetCar().GetGear()
+		for _, gear := range []int{min(current+1, 5), current, max(current-1, 1)} {
+			cards := make([]int, 0)
+			for _, free := range []bool{true, false} {
+				for i, card := range player.GetHand().GetCards() {
+					if card.IsPlayable() && (garage.HeatCost(card) == 0) == free && len(cards) < gear {
+						cards = append(cards, i)
+					}
+				}
+			}
+			if engine.ValidatePlan(player, gear, cards) == nil {
+				return engine.Action{Type: engine.ActionPlan, Gear: gear, Cards: cards}
+			}
+		}
+		return engine.Action{Type: engine.ActionPlan, Gear: current}
+	case engine.PhaseReact:
+		return engine.Action{Type: engine.ActionReact, Icons: []models.Icon{models.IconCooling}}
+	case engine.PhaseSlipstream:
+		return engine.Action{Type: engine.ActionSlipstream, Slipstream: true}
+	default:
+		return engine.Action{Type: engine.ActionDiscard}
+	}
+}
+
+// playRace submits automatic actions until the race is over
+func playRace(t *testing.T, game engine.Game) {
+	for steps := 0; !game.IsFinished(); steps++ {
+		if steps > 10000 {
+			t.Fatal("race did not finish")
+		}
+		for seat := range game.GetPlayers() {
+			if game.IsWaitingFor(seat) {
+				if err := game.Submit(seat, autoAction(game, seat)); err != nil {
+					t.Fatalf("Submit(%d) error = %v", seat, err)
+				}
+			}
+		}
+	}
+}
+
+// runNextRace starts, plays and records the next championship race
+func runNextRace(t *testing.T, c Championship) engine.Game {
+	game, err := c.StartRace()
+	if err != nil {
+		t.Fatalf("StartRace() error = %v", err)
+	}
+	playRace(t, game)
+	if err := c.RecordRace(game); err != nil {
+		t.Fatalf("RecordRace() error = %v", err)
+	}
+	return game
+}
+
+func TestNewChampionship_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+	}{
+		{name: "No drivers", settings: Settings{Races: []Race{{Track: "USA"}}}},
+		{name: "Too many drivers", settings: Settings{Drivers: make([]engine.Seat, engine.MaxSeats+1), Races: []Race{{Track: "USA"}}}},
+		{name: "No races", settings: createTestSettings()},
+		{name: "Unknown track", settings: createTestSettings(Race{Track: "Atlantis"})},
+		{name: "Unknown event", settings: createTestSettings(Race{Track: "USA", Event: "Meteor Shower"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewChampionship(tt.settings); err == nil {
+				t.Error("NewChampionship() should fail")
+			}
+		})
+	}
+}
+
+func TestChampionship_GetRaceConfig(t *testing.T) {
+	c, err := NewChampionship(createTestSettings(
+		Race{Track: "USA", Laps: 1, Event: "Sponsorship Deal"},
+		Race{Track: "Italy", Laps: 1, Event: "Monsoon"},
+		Race{Track: "France", Laps: 1},
+	))
+	if err != nil {
+		t.Fatalf("NewChampionship() error = %v", err)
+	}
+
+	expected := []struct {
+		track   string
+		weather string
+	}{
+		{track: "USA"},
+		{track: "Italy", weather: "Rain"},
+		{track: "France"},
+	}
+
+	for i, want := range expected {
+		config, err := c.GetRaceConfig()
+		if err != nil {
+			t.Fatalf("race %d: GetRaceConfig() error = %v", i, err)
+		}
+		if config.Track.Name != want.track {
+			t.Errorf("race %d: track = %s, want %s", i, config.Track.Name, want.track)
+		}
+		if config.Seed != 7+int64(i) {
+			t.Errorf("race %d: seed = %d, want %d", i, config.Seed, 7+i)
+		}
+		if config.Weather.Name != want.weather {
+			t.Errorf("race %d: weather = %q, want %q: one-off events do not carry over", i, config.Weather.Name, want.weather)
+		}
+		if !hasModule(config.Modules, engine.ModuleSponsors) {
+			t.Errorf("race %d: persistent events should carry over to later races", i)
+		}
+		runNextRace(t, c)
+	}
+
+	if !c.IsComplete() {
+		t.Error("IsComplete() = false after every race")
+	}
+	if _, err := c.GetRaceConfig(); err == nil {
+		t.Error("GetRaceConfig() should fail once the championship is complete")
+	}
+}
+
+func TestChampionship_Standings(t *testing.T) {
+	c, _ := NewChampionship(createTestSettings(Race{Track: "USA", Laps: 1}, Race{Track: "Great Britain", Laps: 1}))
+
+	for !c.IsComplete() {
+		runNextRace(t, c)
+	}
+
+	results := c.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("GetResults() = %d races, want 2", len(results))
+	}
+
+	total := 0
+	for _, standing := range c.GetStandings() {
+		total += standing.Points
+		if len(standing.Finishes) != 2 {
+			t.Errorf("%s has %d finishes, want 2", standing.Name, len(standing.Finishes))
+		}
+	}
+	if total != 2*(9+6+4) {
+		t.Errorf("Total points = %d, want %d", total, 2*(9+6+4))
+	}
+}
+
+func TestChampionship_RecordRace(t *testing.T) {
+	c, _ := NewChampionship(createTestSettings(Race{Track: "USA", Laps: 1}, Race{Track: "Italy", Laps: 1}))
+
+	game, _ := c.StartRace()
+	if err := c.RecordRace(game); err == nil {
+		t.Error("RecordRace() with an unfinished race should fail")
+	}
+
+	config, _ := c.GetRaceConfig()
+	config.Seed++
+	other, _ := engine.NewGame(config)
+	playRace(t, other)
+	if err := c.RecordRace(other); err == nil {
+		t.Error("RecordRace() with a different race should fail")
+	}
+
+	if c.GetNextRace() != 0 {
+		t.Errorf("GetNextRace() = %d after rejected races, want 0", c.GetNextRace())
+	}
+}
+
+func TestChampionship_PersistentUpgrades(t *testing.T) {
+	c, _ := NewChampionship(createTestSettings(
+		Race{Track: "USA", Laps: 1, Event: "Garage Workshop"},
+		Race{Track: "Italy", Laps: 1},
+	))
+
+	game := runNextRace(t, c)
+	drafted := make(map[int][]string)
+	for _, event := range game.GetEvents() {
+		if event.Type == engine.EventDrafted {
+			drafted[event.Seat] = append(drafted[event.Seat], event.Cards[0])
+		}
+	}
+
+	config, err := c.GetRaceConfig()
+	if err != nil {
+		t.Fatalf("GetRaceConfig() error = %v", err)
+	}
+	for seat, upgrades := range drafted {
+		if !reflect.DeepEqual(config.Seats[seat].Upgrades, upgrades) {
+			t.Errorf("seat %d upgrades = %v, want %v", seat, config.Seats[seat].Upgrades, upgrades)
+		}
+	}
+	if len(drafted) != 3 {
+		t.Errorf("%d seats drafted, want 3", len(drafted))
+	}
+}
+
+func TestChampionship_SaveAndLoad(t *testing.T) {
+	settings := createTestSettings(
+		Race{Track: "USA", Laps: 1, Event: "Garage Workshop"},
+		Race{Track: "Italy", Laps: 1},
+		Race{Track: "France", Laps: 1},
+	)
+	c, _ := NewChampionship(settings)
+	runNextRace(t, c)
+
+	var buffer bytes.Buffer
+	if err := Save(&buffer, c); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	resumed, err := Load(&buffer)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if resumed.GetNextRace() != 1 {
+		t.Errorf("GetNextRace() = %d, want 1", resumed.GetNextRace())
+	}
+	if !reflect.DeepEqual(resumed.GetStandings(), c.GetStandings()) {
+		t.Error("Resumed standings differ from the saved ones")
+	}
+	for seat := range settings.Drivers {
+		if !reflect.DeepEqual(resumed.GetUpgrades(seat), c.GetUpgrades(seat)) {
+			t.Errorf("seat %d upgrades were not restored", seat)
+		}
+	}
+
+	// Both copies run the rest of the championship the same way
+	for !c.IsComplete() {
+		runNextRace(t, c)
+		runNextRace(t, resumed)
+	}
+	if !reflect.DeepEqual(resumed.GetStandings(), c.GetStandings()) {
+		t.Error("Resumed championship finished with different standings")
+	}
+
+	if _, err := Load(bytes.NewBufferString("{")); err == nil {
+		t.Error("Load() with invalid JSON should fail")
+	}
+}
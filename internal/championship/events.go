@@ -0,0 +1,152 @@
+package championship
+
+import (
+	"fmt"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+// RaceEvent is a championship event card: a rule change for the race it is drawn for
+// Persistent events stay in effect for every race that follows
+type RaceEvent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Persistent  bool   `json:"persistent,omitempty"`
+
+	// Weather replaces the weather of the race when set
+	Weather *models.Weather `json:"weather,omitempty"`
+
+	// Modules are enabled on top of the championship's modules
+	Modules []string `json:"modules,omitempty"`
+
+	// LapsModifier changes the number of laps, a race always has at least one lap
+	LapsModifier int `json:"laps_modifier,omitempty"`
+}
+
+// raceEvents is the catalog of championship events
+var raceEvents = []RaceEvent{
+	{
+		Name:         "Sprint Race",
+		Description:  "The race is one lap shorter.",
+		LapsModifier: -1,
+	},
+	{
+		Name:         "Endurance Race",
+		Description:  "The race is one lap longer.",
+		LapsModifier: 1,
+	},
+	{
+		Name:        "Heat Wave",
+		Description: "The race is run in a heat wave.",
+		Weather:     findWeather("Heat Wave"),
+	},
+	{
+		Name:        "Monsoon",
+		Description: "The race is run in the rain.",
+		Weather:     findWeather("Rain"),
+	},
+	{
+		Name:        "Press Day",
+		Description: "Sponsor cards are awarded in this race.",
+		Modules:     []string{engine.ModuleSponsors},
+	},
+	{
+		Name:        "Sponsorship Deal",
+		Description: "Sponsor cards are awarded for the rest of the championship.",
+		Persistent:  true,
+		Modules:     []string{engine.ModuleSponsors},
+	},
+	{
+		Name:        "Garage Workshop",
+		Description: "Upgrades are drafted before every race for the rest of the championship.",
+		Persistent:  true,
+		Modules:     []string{engine.ModuleGarage},
+	},
+}
+
+// findWeather looks up a weather tile by name
+// Input: name - the name of the weather tile
+// Returns: a copy of the tile, nil if it does not exist
+func findWeather(name string) *models.Weather {
+	for _, weather := range models.GetWeathers() {
+		if weather.Name == name {
+			return &weather
+		}
+	}
+	return nil
+}
+
+// GetRaceEvents returns the catalog of championship events
+// Input: none
+// Returns: a copy of every event in the catalog
+func GetRaceEvents() []RaceEvent {
+	result := make([]RaceEvent, len(raceEvents))
+	for i, event := range raceEvents {
+		result[i] = event.copy()
+	}
+	return result
+}
+
+// GetRaceEvent returns a championship event by name
+// Input: name - the name of the event
+// Returns: a copy of the event, an error if it does not exist
+func GetRaceEvent(name string) (RaceEvent, error) {
+	for _, event := range raceEvents {
+		if event.Name == name {
+			return event.copy(), nil
+		}
+	}
+	return RaceEvent{}, fmt.Errorf("unknown championship event %q", name)
+}
+
+// Apply changes a race configuration by the event's rules
+// Input: config - the race configuration to change
+// Returns: none
+func (e RaceEvent) Apply(config *engine.Config) {
+	if e.Weather != nil {
+		config.Weather = *e.Weather
+	}
+
+	for _, module := range e.Modules {
+		if !hasModule(config.Modules, module) {
+			config.Modules = append(config.Modules, module)
+		}
+	}
+
+	if e.LapsModifier != 0 {
+		laps := config.Laps
+		if laps == 0 {
+			laps = config.Track.Laps
+		}
+		config.Laps = max(laps+e.LapsModifier, 1)
+	}
+}
+
+// copy returns a copy of the event that shares no slices or pointers with it
+// Input: none
+// Returns: a new RaceEvent
+func (e RaceEvent) copy() RaceEvent {
+	result := e
+	if e.Weather != nil {
+		weather := *e.Weather
+		result.Weather = &weather
+	}
+	result.Modules = append([]string(nil), e.Modules...)
+	return result
+}
+
+// hasModule returns whether a module is in a list
+// Input: modules - the enabled modules
+//
+//	module - the module to look for
+//
+// Returns: a boolean
+func hasModule(modules []string, module string) bool {
+	for _, enabled := range modules {
+		if enabled == module {
+			return true
+		}
+	}
+	return false
+}
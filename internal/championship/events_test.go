@@ -0,0 +1,70 @@
+package championship
+
+import (
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/tracks"
+)
+
+func TestGetRaceEvents(t *testing.T) {
+	for _, event := range GetRaceEvents() {
+		if event.Name == "" || event.Description == "" {
+			t.Errorf("Event %q needs a name and a description", event.Name)
+		}
+		if event.Weather != nil && event.Weather.Name == "" {
+			t.Errorf("Event %q has an unknown weather tile", event.Name)
+		}
+	}
+
+	// Changing a returned event must not change the catalog
+	events := GetRaceEvents()
+	events[2].Weather.Name = "Changed"
+	if again, _ := GetRaceEvent(events[2].Name); again.Weather.Name == "Changed" {
+		t.Error("GetRaceEvents() should return copies of the events")
+	}
+
+	if _, err := GetRaceEvent("Meteor Shower"); err == nil {
+		t.Error("GetRaceEvent() with an unknown name should fail")
+	}
+}
+
+func TestRaceEvent_Apply(t *testing.T) {
+	tests := []struct {
+		name            string
+		event           string
+		laps            int
+		expectedLaps    int
+		expectedModules []string
+		expectedWeather string
+	}{
+		{name: "Sprint race", event: "Sprint Race", laps: 2, expectedLaps: 1},
+		{name: "Sprint race keeps one lap", event: "Sprint Race", laps: 1, expectedLaps: 1},
+		{name: "Endurance race", event: "Endurance Race", laps: 2, expectedLaps: 3},
+		{name: "Track laps", event: "Endurance Race", laps: 0, expectedLaps: 4},
+		{name: "Weather", event: "Monsoon", laps: 2, expectedLaps: 2, expectedWeather: "Rain"},
+		{name: "Modules", event: "Press Day", laps: 2, expectedLaps: 2, expectedModules: []string{engine.ModuleSponsors}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := GetRaceEvent(tt.event)
+			if err != nil {
+				t.Fatalf("GetRaceEvent() error = %v", err)
+			}
+
+			config := engine.Config{Track: tracks.Track{Laps: 3}, Laps: tt.laps}
+			event.Apply(&config)
+
+			if config.Laps != tt.expectedLaps {
+				t.Errorf("Laps = %d, want %d", config.Laps, tt.expectedLaps)
+			}
+			if config.Weather.Name != tt.expectedWeather {
+				t.Errorf("Weather = %q, want %q", config.Weather.Name, tt.expectedWeather)
+			}
+			if len(config.Modules) != len(tt.expectedModules) {
+				t.Errorf("Modules = %v, want %v", config.Modules, tt.expectedModules)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package championship
+
+import (
+	"sort"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+// DefaultPoints are the championship points for first to sixth place
+var DefaultPoints = []int{9, 6, 4, 3, 2, 1}
+
+// Standing is a driver's place in the championship
+type Standing struct {
+	Seat     int          `json:"seat"`
+	Name     string       `json:"name"`
+	Color    models.Color `json:"color"`
+	Position int          `json:"position"`
+	Points   int          `json:"points"`
+	Wins     int          `json:"wins"`
+
+	// Finishes are the driver's finishing positions, one per race run so far
+	Finishes []int `json:"finishes"`
+}
+
+// RaceResult is the outcome of one championship race
+type RaceResult struct {
+	Race    int             `json:"race"`
+	Track   string          `json:"track"`
+	Events  []string        `json:"events,omitempty"`
+	Results []engine.Result `json:"results"`
+}
+
+// pointsFor returns the points for a finishing position
+// Input: points - the points table, first place first
+//
+//	position - the finishing position, starting at 1
+//
+// Returns: the points, 0 for positions outside the table
+func pointsFor(points []int, position int) int {
+	if position < 1 || position > len(points) {
+		return 0
+	}
+	return points[position-1]
+}
+
+// computeStandings totals the points of every driver and ranks them
+// Ties are broken by countback: most wins, then most second places and so on,
+// then the better finish in the most recent race, then seat order
+// Input: drivers - the championship drivers in seat order
+//
+//	points - the points table
+//	races - the results of every race run so far
+//
+// Returns: the standings, leader first
+func computeStandings(drivers []engine.Seat, points []int, races []RaceResult) []Standing {
+	standings := make([]Standing, len(drivers))
+	for i, driver := range drivers {
+		standings[i] = Standing{Seat: i, Name: driver.Name, Color: driver.Color, Finishes: make([]int, 0, len(races))}
+	}
+
+	for _, race := range races {
+		for _, result := range race.Results {
+			if result.Seat < 0 || result.Seat >= len(standings) {
+				continue
+			}
+			standing := &standings[result.Seat]
+			standing.Points += pointsFor(points, result.Position)
+			standing.Finishes = append(standing.Finishes, result.Position)
+			if result.Position == 1 {
+				standing.Wins++
+			}
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return ahead(standings[i], standings[j], len(drivers))
+	})
+	for i := range standings {
+		standings[i].Position = i + 1
+	}
+	return standings
+}
+
+// ahead returns whether a driver is ranked ahead of another
+// Input: a - the first driver's standing
+//
+//	b - the second driver's standing
+//	places - the number of finishing positions in a race
+//
+// Returns: a boolean, false when the drivers cannot be separated
+func ahead(a Standing, b Standing, places int) bool {
+	if a.Points != b.Points {
+		return a.Points > b.Points
+	}
+
+	for position := 1; position <= places; position++ {
+		countA, countB := countFinishes(a.Finishes, position), countFinishes(b.Finishes, position)
+		if countA != countB {
+			return countA > countB
+		}
+	}
+
+	for race := min(len(a.Finishes), len(b.Finishes)) - 1; race >= 0; race-- {
+		if a.Finishes[race] != b.Finishes[race] {
+			return a.Finishes[race] < b.Finishes[race]
+		}
+	}
+
+	return a.Seat < b.Seat
+}
+
+// countFinishes returns how often a driver finished in a position
+// Input: finishes - the driver's finishing positions
+//
+//	position - the position to count
+//
+// Returns: the number of races finished in that position
+func countFinishes(finishes []int, position int) int {
+	count := 0
+	for _, finish := range finishes {
+		if finish == position {
+			count++
+		}
+	}
+	return count
+}
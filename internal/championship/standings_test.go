@@ -0,0 +1,109 @@
+package championship
+
+import (
+	"reflect"
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+// Helper function to create championship drivers
+func createTestDrivers(count int) []engine.Seat {
+	colors := []models.Color{models.Red, models.Blue, models.Green, models.Yellow, models.Orange, models.Black}
+	drivers := make([]engine.Seat, count)
+	for i := range drivers {
+		drivers[i] = engine.Seat{Name: string(colors[i]), Color: colors[i]}
+	}
+	return drivers
+}
+
+// Helper function to create a race result from seats in finishing order
+func createRaceResult(race int, order ...int) RaceResult {
+	result := RaceResult{Race: race, Track: "USA"}
+	for i, seat := range order {
+		result.Results = append(result.Results, engine.Result{Seat: seat, Position: i + 1})
+	}
+	return result
+}
+
+func TestPointsFor(t *testing.T) {
+	tests := []struct {
+		position int
+		expected int
+	}{
+		{position: 1, expected: 9},
+		{position: 6, expected: 1},
+		{position: 7, expected: 0},
+		{position: 0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		if got := pointsFor(DefaultPoints, tt.position); got != tt.expected {
+			t.Errorf("pointsFor(%d) = %d, want %d", tt.position, got, tt.expected)
+		}
+	}
+}
+
+func TestComputeStandings(t *testing.T) {
+	tests := []struct {
+		name          string
+		races         []RaceResult
+		expectedOrder []int
+		expectedTop   int
+	}{
+		{
+			name:          "No races",
+			expectedOrder: []int{0, 1, 2},
+		},
+		{
+			name:          "Points decide",
+			races:         []RaceResult{createRaceResult(0, 2, 0, 1), createRaceResult(1, 2, 0, 1)},
+			expectedOrder: []int{2, 0, 1},
+			expectedTop:   18,
+		},
+		{
+			name: "Countback",
+			// Seats 0, 1 and 2 all score 10: seat 0 has a win, seats 1 and 2 are split by the last race
+			races: []RaceResult{
+				createRaceResult(0, 0, 1, 2, 3, 4, 5),
+				createRaceResult(1, 3, 2, 1, 4, 5, 0),
+			},
+			expectedOrder: []int{3, 0, 2, 1, 4, 5},
+			expectedTop:   12,
+		},
+		{
+			name: "Most recent race breaks a full tie",
+			races: []RaceResult{
+				createRaceResult(0, 0, 1, 2),
+				createRaceResult(1, 1, 0, 2),
+			},
+			expectedOrder: []int{1, 0, 2},
+			expectedTop:   15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drivers := 3
+			for _, race := range tt.races {
+				drivers = max(drivers, len(race.Results))
+			}
+			standings := computeStandings(createTestDrivers(drivers), DefaultPoints, tt.races)
+
+			order := make([]int, len(standings))
+			for i, standing := range standings {
+				order[i] = standing.Seat
+				if standing.Position != i+1 {
+					t.Errorf("standing %d position = %d", i, standing.Position)
+				}
+			}
+			if !reflect.DeepEqual(order, tt.expectedOrder) {
+				t.Errorf("Standings order = %v, want %v", order, tt.expectedOrder)
+			}
+			if standings[0].Points != tt.expectedTop {
+				t.Errorf("Leader points = %d, want %d", standings[0].Points, tt.expectedTop)
+			}
+		})
+	}
+}
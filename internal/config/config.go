@@ -5,9 +5,21 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
+
+	"race-cars/migrations"
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Database drivers DB_DRIVER selects
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
 )
 
 var DB *sql.DB
@@ -18,7 +30,50 @@ func LoadEnv() error {
 }
 
 // InitDB initializes the database connection
+// Migrations that have not been applied yet run first unless DB_AUTO_MIGRATE is false
+// The memory driver has no database and leaves DB nil
 func InitDB() error {
+	driver := GetDBDriver()
+	if driver == DriverMemory {
+		log.Println("Keeping data in memory, it is lost when the server stops")
+		return nil
+	}
+
+	var err error
+	DB, err = OpenDB()
+	if err != nil {
+		return err
+	}
+	log.Println("Database connected successfully")
+
+	if GetEnvBool("DB_AUTO_MIGRATE", true) {
+		if err := migrations.Up(driver, DB); err != nil {
+			return err
+		}
+		log.Println("Database migrations applied")
+	}
+	return nil
+}
+
+// GetDBDriver returns the database driver DB_DRIVER selects, postgres by default
+// Input: none
+// Returns: DriverPostgres, DriverSQLite or DriverMemory
+func GetDBDriver() string {
+	return getEnv("DB_DRIVER", DriverPostgres)
+}
+
+// OpenDB connects to the database described by the environment without touching its schema
+// Input: none
+// Returns: the database, an error if the driver has no database or it cannot be reached
+func OpenDB() (*sql.DB, error) {
+	switch driver := GetDBDriver(); driver {
+	case DriverPostgres:
+	case DriverSQLite:
+		return openSQLite(getEnv("SQLITE_PATH", "race_cars.db"))
+	default:
+		return nil, fmt.Errorf("database driver %q has no database to open", driver)
+	}
+
 	// Get database connection string from environment
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
@@ -35,19 +90,65 @@ func InitDB() error {
 	}
 
 	// Open database connection
-	var err error
-	DB, err = sql.Open("postgres", dbURL)
+	db, err := sql.Open("postgres", dbURL)
 	if err != nil {
-		return fmt.Errorf("error opening database: %w", err)
+		return nil, fmt.Errorf("error opening database: %w", err)
 	}
 
 	// Test the connection
-	if err := DB.Ping(); err != nil {
-		return fmt.Errorf("error connecting to database: %w", err)
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error connecting to database: %w", err)
 	}
+	return db, nil
+}
 
-	log.Println("Database connected successfully")
-	return nil
+// openSQLite opens a SQLite file, creating it if it does not exist
+// Input: path - the file
+// Returns: the database, an error if it cannot be opened
+func openSQLite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_foreign_keys=on&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %w", err)
+	}
+	// SQLite allows one writer at a time, sharing a single connection keeps writes from failing as busy
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error opening database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// GetEnvBool reads a boolean flag from the environment, like HINTS_ENABLED=true
+// Input: key - the variable name
+//
+//	defaultValue - the value when the variable is unset or not a boolean
+//
+// Returns: the flag
+func GetEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, strconv.FormatBool(defaultValue)))
+	if err != nil {
+		log.Printf("Ignoring %s: %v", key, err)
+		return defaultValue
+	}
+	return value
+}
+
+// GetEnvDuration reads a duration from the environment, like SPECTATOR_DELAY=30s
+// Input: key - the variable name
+//
+//	defaultValue - the value when the variable is unset or not a duration
+//
+// Returns: the duration
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, defaultValue.String()))
+	if err != nil {
+		log.Printf("Ignoring %s: %v", key, err)
+		return defaultValue
+	}
+	return value
 }
 
 // getEnv gets an environment variable or returns a default value
@@ -0,0 +1,433 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"race-cars/internal/garage"
+	"race-cars/internal/models"
+)
+
+// ActionType identifies the decision an Action makes
+type ActionType string
+
+const (
+	// ActionDraft picks the upgrade at Cards[0] from the draft market
+	ActionDraft ActionType = "draft"
+
+	// ActionPlan shifts to Gear and plays the hand cards at Cards
+	ActionPlan ActionType = "plan"
+
+	// ActionReact plays Direct Play cards, optionally boosts and accepts the optional Icons
+	ActionReact ActionType = "react"
+
+	// ActionSlipstream takes or declines the slipstream
+	ActionSlipstream ActionType = "slipstream"
+
+	// ActionDiscard discards the hand cards at Cards
+	ActionDiscard ActionType = "discard"
+)
+
+// Action is a decision submitted by a seat
+type Action struct {
+	Type       ActionType    `json:"type"`
+	Gear       int           `json:"gear,omitempty"`
+	Cards      []int         `json:"cards,omitempty"`
+	DirectPlay []int         `json:"direct_play,omitempty"`
+	Boost      bool          `json:"boost,omitempty"`
+	Icons      []models.Icon `json:"icons,omitempty"`
+	Slipstream bool          `json:"slipstream,omitempty"`
+}
+
+// expectedAction maps each phase to the action it waits for
+var expectedAction = map[Phase]ActionType{
+	PhaseDraft:      ActionDraft,
+	PhasePlanning:   ActionPlan,
+	PhaseReact:      ActionReact,
+	PhaseSlipstream: ActionSlipstream,
+	PhaseDiscard:    ActionDiscard,
+}
+
+// Submit applies a seat's action to the game
+// Actions are validated before anything changes, so a rejected action leaves the game untouched
+// Input: seat - the seat index
+//
+//	action - the decision for the current phase
+//
+// Returns: an error if the action is not allowed
+func (g *game) Submit(seat int, action Action) error {
+	if seat < 0 || seat >= len(g.seats) {
+		return fmt.Errorf("seat %d does not exist", seat)
+	}
+	if g.phase == PhaseFinished {
+		return errors.New("race is finished")
+	}
+	if action.Type != expectedAction[g.phase] {
+		return fmt.Errorf("expected a %s action during the %s phase", expectedAction[g.phase], g.phase)
+	}
+	if !g.IsWaitingFor(seat) {
+		return fmt.Errorf("not waiting for seat %d", seat)
+	}
+
+	switch g.phase {
+	case PhaseDraft:
+		return g.submitDraft(seat, action)
+	case PhasePlanning:
+		return g.submitPlan(seat, action)
+	case PhaseReact:
+		return g.submitReact(seat, action)
+	case PhaseSlipstream:
+		return g.submitSlipstream(seat, action)
+	default:
+		return g.submitDiscard(seat, action)
+	}
+}
+
+// submitDraft picks an upgrade from the draft market
+// Input: seat - the drafting seat
+//
+//	action - the draft action, Cards[0] is the market index
+//
+// Returns: an error if the pick is invalid
+func (g *game) submitDraft(seat int, action Action) error {
+	if len(action.Cards) != 1 {
+		return errors.New("draft action picks exactly one card")
+	}
+
+	market := g.draft.GetMarket()
+	if err := g.draft.Pick(action.Cards[0]); err != nil {
+		return err
+	}
+	g.emit(EventDrafted, seat, 0, []string{market[action.Cards[0]].GetName()})
+
+	if g.draft.IsComplete() {
+		g.startRace()
+	}
+	return nil
+}
+
+// submitPlan keeps a seat's gear shift and cards until every seat has planned
+// Seats plan at the same time, so nothing of a plan shows, not even the number of cards, before the last one is in
+// A seat plays as many cards as its gear, or every playable card if it holds fewer
+// Input: seat - the planning seat
+//
+//	action - the plan action
+//
+// Returns: an error if the gear or cards are not allowed
+func (g *game) submitPlan(seat int, action Action) error {
+	state := g.seats[seat]
+	if err := ValidatePlan(state.player, action.Gear, action.Cards); err != nil {
+		return err
+	}
+	state.gear = action.Gear
+	state.cards = append([]int(nil), action.Cards...)
+	state.planned = true
+
+	if g.allPlanned() {
+		if err := g.revealPlans(); err != nil {
+			return err
+		}
+		g.turn = 0
+		g.beginTurn()
+	}
+	return nil
+}
+
+// GetPlan returns the plan a seat submitted this round while it is still hidden
+// Input: seat - the seat index
+// Returns: the plan action, false once plans are revealed or when the seat has not planned
+func (g *game) GetPlan(seat int) (Action, bool) {
+	if g.phase != PhasePlanning || seat < 0 || seat >= len(g.seats) {
+		return Action{}, false
+	}
+	state := g.seats[seat]
+	if state.legend != nil || !state.planned {
+		return Action{}, false
+	}
+	return Action{Type: ActionPlan, Gear: state.gear, Cards: append([]int(nil), state.cards...)}, true
+}
+
+// ForgetPlan drops a plan that is not revealed yet, so the seat plans again
+// Input: seat - the seat index
+// Returns: none
+func (g *game) ForgetPlan(seat int) {
+	if g.phase != PhasePlanning || seat < 0 || seat >= len(g.seats) {
+		return
+	}
+	state := g.seats[seat]
+	if state.legend != nil {
+		return
+	}
+	state.planned = false
+	state.gear = 0
+	state.cards = nil
+}
+
+// revealPlans shifts the gears and plays the cards every seat planned this round, in turn order
+// Input: none
+// Returns: an error if a plan can no longer be carried out
+func (g *game) revealPlans() error {
+	for _, seat := range g.turnOrder {
+		state := g.seats[seat]
+		if state.legend != nil {
+			continue
+		}
+
+		player := state.player
+		previous := player.GetCar().GetGear()
+		icons, err := player.GetCar().SetGear(state.gear, player.GetDiscardPile())
+		if err != nil {
+			return err
+		}
+		player.AddIcons(icons)
+		if state.gear != previous {
+			g.emit(EventGearShifted, seat, state.gear, nil)
+		}
+
+		for _, index := range descending(state.cards) {
+			if err := player.PlayCard(index); err != nil {
+				return err
+			}
+		}
+		state.cards = nil
+	}
+	return nil
+}
+
+// ValidatePlan checks a gear shift and card selection without changing anything
+// A seat plays as many cards as its gear, or as many as it can pay the heat for if that is fewer
+// Input: player - the player planning
+//
+//	gear - the gear to shift to
+//	cards - the indexes of the hand cards to play
+//
+// Returns: an error describing why the plan is not allowed
+func ValidatePlan(player models.Player, gear int, cards []int) error {
+	car := player.GetCar()
+	if gear < 1 || gear > 5 {
+		return errors.New("gear must be between 1 and 5")
+	}
+
+	shift := gear - car.GetGear()
+	if shift < -2 || shift > 2 {
+		return errors.New("cannot shift more than 2 gears at once")
+	}
+	heat := 0
+	if shift == 2 || shift == -2 {
+		heat++
+	}
+
+	if heat > car.GetEngine() {
+		return fmt.Errorf("shift needs %d heat, engine has %d", heat, car.GetEngine())
+	}
+
+	hand := player.GetHand()
+	if err := validateIndexes(cards, hand.Size()); err != nil {
+		return err
+	}
+
+	// Cards whose heat cannot be paid do not count, so a plan with the cheapest cards is always possible
+	costs := make([]int, 0, hand.Size())
+	for i := 0; i < hand.Size(); i++ {
+		if card := hand.GetCard(i); card.IsPlayable() {
+			costs = append(costs, garage.HeatCost(card))
+		}
+	}
+	sort.Ints(costs)
+	playable := 0
+	budget := car.GetEngine() - heat
+	for _, cost := range costs {
+		if cost > budget {
+			break
+		}
+		budget -= cost
+		playable++
+	}
+	if len(cards) != min(gear, playable) {
+		return fmt.Errorf("must play %d cards in gear %d", min(gear, playable), gear)
+	}
+
+	for _, index := range cards {
+		card := hand.GetCard(index)
+		if !card.IsPlayable() {
+			return fmt.Errorf("%s cannot be played", card.GetName())
+		}
+		heat += garage.HeatCost(card)
+	}
+
+	if heat > car.GetEngine() {
+		return fmt.Errorf("plan needs %d heat, engine has %d", heat, car.GetEngine())
+	}
+	return nil
+}
+
+// submitReact plays Direct Play cards, boosts and resolves the active seat's icons, then moves the car
+// Input: seat - the active seat
+//
+//	action - the react action
+//
+// Returns: an error if a card cannot be played directly or the boost cannot be paid for
+func (g *game) submitReact(seat int, action Action) error {
+	if err := g.validateReact(seat, action); err != nil {
+		return err
+	}
+
+	player := g.seats[seat].player
+	car := player.GetCar()
+	for _, index := range descending(action.DirectPlay) {
+		if err := garage.DirectPlay(player, index); err != nil {
+			return err
+		}
+	}
+
+	accepted := make(map[models.Icon]bool)
+	for _, icon := range action.Icons {
+		accepted[icon] = true
+	}
+
+	if action.Boost {
+		g.payHeat(player, g.boostCost(seat))
+		player.AddIcons(map[models.Icon]int{models.IconBoost: 1})
+		accepted[models.IconBoost] = true
+	}
+
+	g.applyWeatherToCooling(player)
+
+	speed := car.GetSpeed()
+	engine := car.GetEngine()
+	err := g.registry.ResolveIcons(player, func(effect models.IconEffect, count int) bool {
+		return accepted[effect.Icon]
+	})
+	if err != nil {
+		return err
+	}
+
+	if action.Boost {
+		g.emit(EventBoosted, seat, car.GetSpeed()-speed, nil)
+	}
+	if car.GetEngine() > engine {
+		g.emit(EventCooled, seat, car.GetEngine()-engine, nil)
+	}
+
+	return g.move(seat)
+}
+
+// validateReact checks the Direct Play cards and boost of a react action without changing anything
+// Input: seat - the active seat
+//
+//	action - the react action
+//
+// Returns: an error if a card cannot be played directly or the heat cannot be paid for
+func (g *game) validateReact(seat int, action Action) error {
+	player := g.seats[seat].player
+
+	hand := player.GetHand()
+	if err := validateIndexes(action.DirectPlay, hand.Size()); err != nil {
+		return err
+	}
+	heat := 0
+	for _, index := range action.DirectPlay {
+		card := hand.GetCard(index)
+		if !g.garage || card.GetIcons()[garage.IconDirectPlay] == 0 {
+			return fmt.Errorf("%s cannot be played directly", card.GetName())
+		}
+		heat += garage.HeatCost(card)
+	}
+
+	if action.Boost {
+		heat += g.boostCost(seat)
+	}
+	heat += player.GetIcons()[garage.IconHeatControl]
+	if heat > player.GetCar().GetEngine() {
+		return fmt.Errorf("react needs %d heat, engine has %d", heat, player.GetCar().GetEngine())
+	}
+	return nil
+}
+
+// submitSlipstream moves the active seat's car on by the slipstream distance if it accepts
+// Input: seat - the active seat
+//
+//	action - the slipstream action
+//
+// Returns: an error if the car cannot be moved
+func (g *game) submitSlipstream(seat int, action Action) error {
+	if action.Slipstream {
+		car := g.seats[seat].player.GetCar()
+		from, err := g.board.FindCar(car)
+		if err != nil {
+			return err
+		}
+
+		passed := len(car.GetPassedCorners())
+		to, err := g.board.MoveCar(car, g.slipstreamDistance(from))
+		if err != nil {
+			return err
+		}
+		g.seats[seat].slipstreamCorners = car.GetPassedCorners()[passed:]
+		g.emit(EventSlipstreamed, seat, to, nil)
+	}
+
+	g.checkCorners(seat)
+	return nil
+}
+
+// submitDiscard discards cards from the active seat's hand and ends its turn
+// Input: seat - the active seat
+//
+//	action - the discard action
+//
+// Returns: an error if a card cannot be discarded
+func (g *game) submitDiscard(seat int, action Action) error {
+	player := g.seats[seat].player
+
+	hand := player.GetHand()
+	if err := validateIndexes(action.Cards, hand.Size()); err != nil {
+		return err
+	}
+	for _, index := range action.Cards {
+		if card := hand.GetCard(index); !card.IsDiscardable() {
+			return fmt.Errorf("%s cannot be discarded", card.GetName())
+		}
+	}
+
+	for _, index := range descending(action.Cards) {
+		if err := player.DiscardCard(index); err != nil {
+			return err
+		}
+	}
+
+	g.endTurn(seat)
+	return nil
+}
+
+// validateIndexes checks that card indexes are in range and distinct
+// Input: indexes - the indexes to check
+//
+//	size - the number of cards they index into
+//
+// Returns: an error for the first invalid or repeated index
+func validateIndexes(indexes []int, size int) error {
+	seen := make(map[int]bool, len(indexes))
+	for _, index := range indexes {
+		if index < 0 || index >= size {
+			return fmt.Errorf("card index %d is out of range", index)
+		}
+		if seen[index] {
+			return fmt.Errorf("card index %d is used twice", index)
+		}
+		seen[index] = true
+	}
+	return nil
+}
+
+// descending returns a sorted copy of indexes, highest first
+// Removing cards from the highest index down keeps the remaining indexes valid
+// Input: indexes - the indexes to sort
+// Returns: a new slice of indexes
+func descending(indexes []int) []int {
+	result := make([]int, len(indexes))
+	copy(result, indexes)
+	sort.Sort(sort.Reverse(sort.IntSlice(result)))
+	return result
+}
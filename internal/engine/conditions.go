@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"fmt"
+
+	"race-cars/internal/models"
+)
+
+// applyConditions puts the weather tile and road-condition tokens of a race on its board
+// Input: board - the board built for the race
+//
+//	config - the race configuration
+//
+// Returns: an error if a token is placed on a space that is not a corner or on a missing sector
+func applyConditions(board models.Board, config Config) error {
+	board.SetWeather(config.Weather)
+
+	spaces := board.GetSpaces()
+	for index, condition := range config.CornerConditions {
+		if index < 0 || index >= len(spaces) || spaces[index].GetCorner() <= 0 {
+			return fmt.Errorf("space %d is not a corner", index)
+		}
+		spaces[index].SetRoadCondition(condition)
+	}
+
+	for index, condition := range config.SectorConditions {
+		if err := board.SetSectorCondition(index, condition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sectorCondition returns the road condition of the sector a space is in
+// Input: board - the board the race is run on
+//
+//	space - the index of the space
+//
+// Returns: the sector's road condition, a dry road if the space is not on the board
+func sectorCondition(board models.Board, space int) models.RoadCondition {
+	index := board.GetSectorIndex(space)
+	if index < 0 {
+		return models.RoadCondition{}
+	}
+	return board.GetSectors()[index].Condition
+}
+
+// applyWeatherToCooling adjusts a player's Cooling icons for the weather before the react phase
+// Input: player - the player about to react
+// Returns: none
+func (g *game) applyWeatherToCooling(player models.Player) {
+	weather := g.board.GetWeather()
+	if weather.NoCooling {
+		player.ClearIcon(models.IconCooling)
+		return
+	}
+
+	if weather.CoolingModifier == 0 || player.GetIcons()[models.IconCooling] == 0 {
+		return
+	}
+
+	cooling := player.ClearIcon(models.IconCooling) + weather.CoolingModifier
+	if cooling > 0 {
+		player.AddIcons(map[models.Icon]int{models.IconCooling: cooling})
+	}
+}
+
+// SlipstreamDistance returns how far a car slipstreams from a space, with the weather and sector modifiers applied
+// Input: board - the board the race is run on
+//
+//	space - the index of the space the car is on
+//
+// Returns: the number of spaces, 0 if slipstreaming is not possible
+func SlipstreamDistance(board models.Board, space int) int {
+	weather := board.GetWeather()
+	if weather.NoSlipstream {
+		return 0
+	}
+
+	distance := models.BaseSlipstream + weather.SlipstreamModifier + sectorCondition(board, space).SlipstreamModifier
+	return max(distance, 0)
+}
+
+// slipstreamDistance returns how far a car slipstreams from a space on the game's board
+// Input: space - the index of the space the car is on
+// Returns: the number of spaces, 0 if slipstreaming is not possible
+func (g *game) slipstreamDistance(space int) int {
+	return SlipstreamDistance(g.board, space)
+}
+
+// BoostCost returns the heat a car pays to boost
+// Input: board - the board the race is run on
+//
+//	space - the index of the space the car started its turn on
+//
+// Returns: 0 in a Free Boost sector, 1 otherwise
+func BoostCost(board models.Board, space int) int {
+	if sectorCondition(board, space).FreeBoost {
+		return 0
+	}
+	return 1
+}
+
+// boostCost returns the heat a seat pays to boost this turn
+// Input: seat - the seat boosting
+// Returns: 0 in a Free Boost sector, 1 otherwise
+func (g *game) boostCost(seat int) int {
+	return BoostCost(g.board, g.seats[seat].startSpace)
+}
+
+// CornerHeat returns the heat a car owes for passing a corner
+// Input: board - the board the race is run on
+//
+//	corner - the index of the corner space
+//	speed - the car's speed this turn
+//
+// Returns: the heat owed for going over the speed limit plus any heat from the corner's road condition
+func CornerHeat(board models.Board, corner int, speed int) int {
+	over := speed - board.GetCornerLimit(corner)
+	heat := board.GetSpaces()[corner].GetRoadCondition().ExtraHeat
+	if over > 0 {
+		heat += over
+	}
+	return heat
+}
+
+// cornerHeat returns the heat a car owes for passing a corner on the game's board
+// Input: corner - the index of the corner space
+//
+//	speed - the car's speed this turn
+//
+// Returns: the heat owed
+func (g *game) cornerHeat(corner int, speed int) int {
+	return CornerHeat(g.board, corner, speed)
+}
@@ -0,0 +1,52 @@
+package engine
+
+// EventType identifies what happened in an Event
+type EventType string
+
+const (
+	EventRoundStarted  EventType = "round_started"
+	EventLegendCard    EventType = "legend_card"
+	EventDrafted       EventType = "drafted"
+	EventGearShifted   EventType = "gear_shifted"
+	EventCardsRevealed EventType = "cards_revealed"
+	EventAdrenaline    EventType = "adrenaline"
+	EventBoosted       EventType = "boosted"
+	EventCooled        EventType = "cooled"
+	EventMoved         EventType = "moved"
+	EventSlipstreamed  EventType = "slipstreamed"
+	EventHeatPaid      EventType = "heat_paid"
+	EventSpunOut       EventType = "spun_out"
+	EventSponsored     EventType = "sponsored"
+	EventFinished      EventType = "finished"
+	EventRaceFinished  EventType = "race_finished"
+)
+
+// Event is an entry in a game's event log
+// Every event is public information: cards only appear once they are revealed
+type Event struct {
+	Sequence int       `json:"sequence"`
+	Round    int       `json:"round"`
+	Type     EventType `json:"type"`
+	Seat     int       `json:"seat"`
+	Value    int       `json:"value,omitempty"`
+	Cards    []string  `json:"cards,omitempty"`
+}
+
+// emit appends an event to the game's event log
+// Input: eventType - what happened
+//
+//	seat - the seat the event belongs to, -1 for the whole race
+//	value - a number describing the event, like the spaces moved or heat paid
+//	cards - the names of any cards revealed by the event
+//
+// Returns: none
+func (g *game) emit(eventType EventType, seat int, value int, cards []string) {
+	g.events = append(g.events, Event{
+		Sequence: len(g.events) + 1,
+		Round:    g.round,
+		Type:     eventType,
+		Seat:     seat,
+		Value:    value,
+		Cards:    cards,
+	})
+}
@@ -0,0 +1,620 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"race-cars/internal/garage"
+	"race-cars/internal/legends"
+	"race-cars/internal/models"
+	"race-cars/internal/sponsors"
+	"race-cars/internal/tracks"
+)
+
+const (
+	// MaxSeats is the largest grid a race supports
+	MaxSeats = 6
+
+	// DefaultMaxRounds ends a race that has not finished after this many rounds
+	DefaultMaxRounds = 100
+
+	// ModuleGarage enables the Garage upgrade cards and the pre-race draft
+	ModuleGarage = "garage"
+
+	// ModuleSponsors enables sponsor cards earned in corners and by slipstreaming across press corners
+	ModuleSponsors = "sponsors"
+)
+
+// Phase is the step of the round a game is waiting on
+type Phase string
+
+const (
+	// PhaseDraft waits for the current drafter to pick an upgrade
+	PhaseDraft Phase = "draft"
+
+	// PhasePlanning waits for every racing seat to shift gears and play cards
+	PhasePlanning Phase = "planning"
+
+	// PhaseReact waits for the active seat to boost and resolve its icons
+	PhaseReact Phase = "react"
+
+	// PhaseSlipstream waits for the active seat to decide whether to slipstream
+	PhaseSlipstream Phase = "slipstream"
+
+	// PhaseDiscard waits for the active seat to discard cards from its hand
+	PhaseDiscard Phase = "discard"
+
+	// PhaseFinished means the race is over
+	PhaseFinished Phase = "finished"
+)
+
+// Seat is a player taking part in a race
+type Seat struct {
+	Name  string       `json:"name"`
+	Color models.Color `json:"color"`
+
+	// Upgrades are Garage upgrades added to the starting deck, like those kept between championship races
+	Upgrades []string `json:"upgrades,omitempty"`
+}
+
+// Config describes a race
+type Config struct {
+	Track   tracks.Track `json:"track"`
+	Laps    int          `json:"laps"`
+	Seats   []Seat       `json:"seats"`
+	Seed    int64        `json:"seed"`
+	Modules []string     `json:"modules,omitempty"`
+
+	// Weather is the weather tile for the race, the zero value for clear weather
+	Weather models.Weather `json:"weather"`
+
+	// CornerConditions maps corner spaces to the road-condition token on them
+	CornerConditions map[int]models.RoadCondition `json:"corner_conditions,omitempty"`
+
+	// SectorConditions maps sector indexes to the road-condition token on them
+	SectorConditions map[int]models.RoadCondition `json:"sector_conditions,omitempty"`
+
+	// MaxRounds ends the race after this many rounds, 0 uses DefaultMaxRounds
+	MaxRounds int `json:"max_rounds,omitempty"`
+
+	// Legends are automated drivers that take the grid positions after the seats
+	Legends []legends.Driver `json:"legends,omitempty"`
+}
+
+// Result is the final standing of a seat
+type Result struct {
+	Seat     int    `json:"seat"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+
+	// Round is the round the car crossed the finish line, 0 if it did not finish
+	Round int `json:"round"`
+}
+
+// Game runs a race round by round
+// Every decision is submitted as an Action for a seat; everything else is resolved by the engine
+type Game interface {
+	// GetConfig returns the configuration the game was created with
+	// Returns: the game's Config
+	GetConfig() Config
+
+	// GetBoard returns the board the race is run on
+	// Returns: the game's Board
+	GetBoard() models.Board
+
+	// GetPlayers returns the players in seat order
+	// Returns: a copy of the players, without the Legends
+	GetPlayers() []models.Player
+
+	// GetRacers returns every racer in seat order: the players followed by the Legends
+	// Returns: a copy of the racers
+	GetRacers() []models.Racer
+
+	// GetIconRegistry returns the icon effects used in the react phase
+	// Returns: the game's IconRegistry
+	GetIconRegistry() models.IconRegistry
+
+	// GetDraft returns the Garage draft
+	// Returns: the draft, nil when the Garage module is not used
+	GetDraft() garage.Draft
+
+	// GetRound returns the current round, starting at 1
+	// Returns: the round number, 0 before the race starts
+	GetRound() int
+
+	// GetPhase returns the phase the game is waiting on
+	// Returns: the current Phase
+	GetPhase() Phase
+
+	// GetActiveSeat returns the seat whose turn it is
+	// Returns: the seat index, -1 while every seat plans at once or the race is over
+	GetActiveSeat() int
+
+	// GetTurnOrder returns the seats taking a turn this round, leader first
+	// Returns: a copy of the seat indexes
+	GetTurnOrder() []int
+
+	// HasAdrenaline returns whether a seat gets adrenaline this round: one more speed and one Cooling icon
+	// Input: seat - the seat index
+	// Returns: a boolean, false for Legends and seats that do not exist
+	HasAdrenaline(seat int) bool
+
+	// IsWaitingFor returns whether the game needs an action from a seat
+	// Input: seat - the seat index
+	// Returns: a boolean
+	IsWaitingFor(seat int) bool
+
+	// LegalActions returns every action a seat may submit right now
+	// Equivalent actions, like playing either of two identical cards, are listed once
+	// Input: seat - the seat index
+	// Returns: the legal actions, empty when the game is not waiting for the seat
+	LegalActions(seat int) []Action
+
+	// Submit applies a seat's action to the game
+	// Input: seat - the seat index
+	//	action - the decision for the current phase
+	// Returns: an error if the action is not allowed, in which case the game is unchanged
+	Submit(seat int, action Action) error
+
+	// GetPlan returns the plan a seat submitted this round while it is still hidden
+	// Input: seat - the seat index
+	// Returns: the plan action, false once plans are revealed or when the seat has not planned
+	GetPlan(seat int) (Action, bool)
+
+	// ForgetPlan drops a plan that is not revealed yet, so the seat plans again
+	// Used by search bots, which cannot know the plans of other seats, on a copy of the game
+	// Input: seat - the seat index
+	// Returns: none
+	ForgetPlan(seat int)
+
+	// IsFinished returns whether the race is over
+	// Returns: a boolean
+	IsFinished() bool
+
+	// GetResults returns the final standings
+	// Returns: the results in finishing order, empty until the race is over
+	GetResults() []Result
+
+	// GetEvents returns the event log
+	// Returns: a copy of every event so far
+	GetEvents() []Event
+
+	// Clone copies the game so it can be played on without changing the original
+	// Used by search bots to try out actions
+	// Input: seed - seeds the copy's random source, used for every shuffle from now on
+	// Returns: a new Game, an error during the draft
+	Clone(seed int64) (Game, error)
+}
+
+type seatState struct {
+	racer      models.Racer
+	player     models.Player
+	legend     legends.Legend
+	planned    bool
+	adrenaline bool
+
+	// gear and cards are the plan submitted this round, kept hidden until every seat has planned
+	gear  int
+	cards []int
+
+	finished    bool
+	finishRound int
+	startSpace  int
+
+	// slipstreamCorners are the corners the car crossed while slipstreaming this turn
+	slipstreamCorners []int
+}
+
+type game struct {
+	config    Config
+	board     models.Board
+	registry  models.IconRegistry
+	rng       *rand.Rand
+	draft     garage.Draft
+	garage    bool
+	sponsors  models.Deck
+	legends   legends.Deck
+	lines     map[int]int
+	card      legends.Card
+	seats     []*seatState
+	round     int
+	phase     Phase
+	turnOrder []int
+	turn      int
+	finishers []int
+	results   []Result
+	events    []Event
+}
+
+// NewGame creates a race and deals the starting decks
+// With the Garage module the game starts in the draft, otherwise hands are drawn and the first round begins
+// Input: config - the race configuration
+// Returns: a new Game, an error if the configuration is invalid
+func NewGame(config Config) (Game, error) {
+	if len(config.Seats) == 0 || len(config.Seats)+len(config.Legends) > MaxSeats {
+		return nil, fmt.Errorf("race needs at least 1 seat and at most %d cars", MaxSeats)
+	}
+	if config.MaxRounds == 0 {
+		config.MaxRounds = DefaultMaxRounds
+	}
+
+	colors := make(map[models.Color]bool)
+	for _, seat := range config.Seats {
+		if seat.Name == "" {
+			return nil, errors.New("every seat needs a name")
+		}
+		if colors[seat.Color] {
+			return nil, fmt.Errorf("color %s is taken", seat.Color)
+		}
+		colors[seat.Color] = true
+	}
+	for _, driver := range config.Legends {
+		if colors[driver.Color] {
+			return nil, fmt.Errorf("color %s is taken", driver.Color)
+		}
+		colors[driver.Color] = true
+	}
+
+	board, err := config.Track.NewBoard(config.Laps)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyConditions(board, config); err != nil {
+		return nil, err
+	}
+
+	draftEnabled := false
+	sponsorsEnabled := false
+	for _, module := range config.Modules {
+		switch module {
+		case ModuleGarage:
+			draftEnabled = true
+		case ModuleSponsors:
+			sponsorsEnabled = true
+		default:
+			return nil, fmt.Errorf("unknown module %q", module)
+		}
+	}
+
+	// Upgrade cards need the Garage icon effects even when there is no draft
+	garageEnabled := draftEnabled
+	for _, seat := range config.Seats {
+		garageEnabled = garageEnabled || len(seat.Upgrades) > 0
+	}
+	registry := models.NewIconRegistry()
+	if garageEnabled {
+		if err := garage.RegisterEffects(registry); err != nil {
+			return nil, err
+		}
+	}
+
+	g := &game{
+		config:   config,
+		board:    board,
+		registry: registry,
+		rng:      rand.New(rand.NewSource(config.Seed)),
+		garage:   garageEnabled,
+		events:   make([]Event, 0),
+	}
+
+	if sponsorsEnabled {
+		g.sponsors = sponsors.NewSponsorDeck(g.rng)
+	}
+
+	for i, seat := range config.Seats {
+		player, err := newPlayer(seat, board.GetWeather(), g.rng)
+		if err != nil {
+			return nil, err
+		}
+		if err := placeOnGrid(board, player.GetCar(), i); err != nil {
+			return nil, err
+		}
+		g.seats = append(g.seats, &seatState{racer: player, player: player})
+	}
+
+	for i, driver := range config.Legends {
+		legend, err := legends.NewLegend(driver)
+		if err != nil {
+			return nil, err
+		}
+		if err := placeOnGrid(board, legend.GetCar(), len(config.Seats)+i); err != nil {
+			return nil, err
+		}
+		g.seats = append(g.seats, &seatState{racer: legend, legend: legend})
+	}
+	if len(config.Legends) > 0 {
+		g.legends = legends.NewDeck(g.rng)
+		g.lines = legends.GetLines(config.Track)
+	}
+
+	if draftEnabled {
+		draft, err := garage.NewDraft(g.GetPlayers(), garage.DraftRounds, g.rng)
+		if err != nil {
+			return nil, err
+		}
+		g.draft = draft
+		g.phase = PhaseDraft
+		return g, nil
+	}
+
+	g.startRace()
+	return g, nil
+}
+
+// GetConfig returns the configuration the game was created with
+// Input: none
+// Returns: the game's Config
+func (g *game) GetConfig() Config {
+	return g.config
+}
+
+// GetBoard returns the board the race is run on
+// Input: none
+// Returns: the game's Board
+func (g *game) GetBoard() models.Board {
+	return g.board
+}
+
+// GetPlayers returns the players in seat order
+// Input: none
+// Returns: a copy of the players, without the Legends
+func (g *game) GetPlayers() []models.Player {
+	players := make([]models.Player, 0, len(g.seats))
+	for _, seat := range g.seats {
+		if seat.player != nil {
+			players = append(players, seat.player)
+		}
+	}
+	return players
+}
+
+// GetRacers returns every racer in seat order: the players followed by the Legends
+// Input: none
+// Returns: a copy of the racers
+func (g *game) GetRacers() []models.Racer {
+	racers := make([]models.Racer, len(g.seats))
+	for i, seat := range g.seats {
+		racers[i] = seat.racer
+	}
+	return racers
+}
+
+// GetIconRegistry returns the icon effects used in the react phase
+// Input: none
+// Returns: the game's IconRegistry
+func (g *game) GetIconRegistry() models.IconRegistry {
+	return g.registry
+}
+
+// GetDraft returns the Garage draft
+// Input: none
+// Returns: the draft, nil when the Garage module is not used
+func (g *game) GetDraft() garage.Draft {
+	return g.draft
+}
+
+// GetRound returns the current round, starting at 1
+// Input: none
+// Returns: the round number, 0 before the race starts
+func (g *game) GetRound() int {
+	return g.round
+}
+
+// GetPhase returns the phase the game is waiting on
+// Input: none
+// Returns: the current Phase
+func (g *game) GetPhase() Phase {
+	return g.phase
+}
+
+// GetActiveSeat returns the seat whose turn it is
+// Input: none
+// Returns: the seat index, -1 while every seat plans at once or the race is over
+func (g *game) GetActiveSeat() int {
+	switch g.phase {
+	case PhaseDraft:
+		return g.seatOf(g.draft.GetCurrentPlayer())
+	case PhaseReact, PhaseSlipstream, PhaseDiscard:
+		return g.turnOrder[g.turn]
+	default:
+		return -1
+	}
+}
+
+// GetTurnOrder returns the seats taking a turn this round, leader first
+// Input: none
+// Returns: a copy of the seat indexes
+func (g *game) GetTurnOrder() []int {
+	result := make([]int, len(g.turnOrder))
+	copy(result, g.turnOrder)
+	return result
+}
+
+// HasAdrenaline returns whether a seat gets adrenaline this round: one more speed and one Cooling icon
+// Input: seat - the seat index
+// Returns: a boolean, false for Legends and seats that do not exist
+func (g *game) HasAdrenaline(seat int) bool {
+	if seat < 0 || seat >= len(g.seats) {
+		return false
+	}
+	return g.seats[seat].adrenaline
+}
+
+// IsWaitingFor returns whether the game needs an action from a seat
+// Input: seat - the seat index
+// Returns: a boolean
+func (g *game) IsWaitingFor(seat int) bool {
+	if seat < 0 || seat >= len(g.seats) {
+		return false
+	}
+
+	switch g.phase {
+	case PhasePlanning:
+		return !g.seats[seat].finished && !g.seats[seat].planned
+	case PhaseFinished:
+		return false
+	default:
+		return g.GetActiveSeat() == seat
+	}
+}
+
+// IsFinished returns whether the race is over
+// Input: none
+// Returns: a boolean
+func (g *game) IsFinished() bool {
+	return g.phase == PhaseFinished
+}
+
+// GetResults returns the final standings
+// Input: none
+// Returns: the results in finishing order, empty until the race is over
+func (g *game) GetResults() []Result {
+	result := make([]Result, len(g.results))
+	copy(result, g.results)
+	return result
+}
+
+// GetEvents returns the event log
+// Input: none
+// Returns: a copy of every event so far
+func (g *game) GetEvents() []Event {
+	result := make([]Event, len(g.events))
+	copy(result, g.events)
+	return result
+}
+
+// Clone copies the game so it can be played on without changing the original
+// Cards, the track and the icon effects are immutable and shared with the copy
+// Input: seed - seeds the copy's random source, used for every shuffle from now on
+// Returns: a new Game, an error during the draft
+func (g *game) Clone(seed int64) (Game, error) {
+	if g.phase == PhaseDraft {
+		return nil, errors.New("cannot clone a game during the draft")
+	}
+
+	clone := *g
+	clone.rng = rand.New(rand.NewSource(seed))
+	if g.sponsors != nil {
+		clone.sponsors = g.sponsors.Clone(clone.rng)
+	}
+	if g.legends != nil {
+		clone.legends = g.legends.Clone(clone.rng)
+	}
+
+	cars := make(map[models.Car]models.Car, len(g.seats))
+	clone.seats = make([]*seatState, len(g.seats))
+	for i, seat := range g.seats {
+		copied := *seat
+		copied.slipstreamCorners = append([]int(nil), seat.slipstreamCorners...)
+		copied.cards = append([]int(nil), seat.cards...)
+		if seat.player != nil {
+			copied.player = seat.player.Clone(clone.rng)
+			copied.racer = copied.player
+			cars[seat.player.GetCar()] = copied.player.GetCar()
+		} else {
+			car := seat.legend.GetCar().Clone()
+			copied.legend = seat.legend.Clone(car)
+			copied.racer = copied.legend
+			cars[seat.legend.GetCar()] = car
+		}
+		clone.seats[i] = &copied
+	}
+	clone.board = g.board.Clone(cars)
+
+	clone.turnOrder = append([]int(nil), g.turnOrder...)
+	clone.finishers = append([]int(nil), g.finishers...)
+	clone.results = append([]Result(nil), g.results...)
+
+	// The copy appends to its own log once it outgrows the shared events
+	clone.events = g.events[:len(g.events):len(g.events)]
+	return &clone, nil
+}
+
+// newPlayer creates a player with a starting deck adjusted for the weather
+// Input: seat - the seat the player takes
+//
+//	weather - the weather tile for the race
+//	rng - the game's random source, used by the player's deck
+//
+// Returns: a new Player, an error if one of the seat's upgrades does not exist
+func newPlayer(seat Seat, weather models.Weather, rng *rand.Rand) (models.Player, error) {
+	cards := models.NewStartingCards()
+	for _, name := range seat.Upgrades {
+		card, err := garage.NewUpgradeCard(name)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	for i := 0; i < weather.StressCards; i++ {
+		cards = append(cards, models.NewStressCard())
+	}
+
+	engine := models.StartingEngine
+	heatInDeck := min(weather.HeatInDeck, engine)
+	engine -= heatInDeck
+	for i := 0; i < heatInDeck; i++ {
+		cards = append(cards, models.NewHeatCard())
+	}
+
+	discardPile := models.NewDiscardPile()
+	heatInDiscard := min(weather.HeatInDiscard, engine)
+	engine -= heatInDiscard
+	for i := 0; i < heatInDiscard; i++ {
+		discardPile.AddCard(models.NewHeatCard())
+	}
+
+	car := models.NewCar(string(seat.Color), engine)
+	return models.NewPlayer(seat.Name, car, discardPile, models.NewSeededDeck(cards, rng), models.NewHand()), nil
+}
+
+// placeOnGrid puts a car on its starting space
+// Two cars share each grid row, with the pole position on the row furthest from the finish line
+// Input: board - the board to place the car on
+//
+//	car - the car to place
+//	seat - the seat index, which is also the grid position
+//
+// Returns: an error if the space cannot take the car
+func placeOnGrid(board models.Board, car models.Car, seat int) error {
+	rows := (MaxSeats + 1) / 2
+	return board.PlaceCar(car, rows-1-seat/2)
+}
+
+// startRace shuffles the decks, draws the starting hands and begins the first round
+// Input: none
+// Returns: none
+func (g *game) startRace() {
+	for _, seat := range g.seats {
+		if seat.player != nil {
+			seat.player.GetDeck().Shuffle()
+			g.replenish(seat.player)
+		}
+	}
+	g.startRound()
+}
+
+// seatOf returns the seat index of a player
+// Input: player - the player to look for
+// Returns: the seat index, -1 if the player is not in the game
+func (g *game) seatOf(player models.Player) int {
+	for i, seat := range g.seats {
+		if seat.player == player {
+			return i
+		}
+	}
+	return -1
+}
+
+// seatOfCar returns the seat index of the player driving a car
+// Input: car - the car to look for
+// Returns: the seat index, -1 if the car is not in the game
+func (g *game) seatOfCar(car models.Car) int {
+	for i, seat := range g.seats {
+		if seat.racer.GetCar() == car {
+			return i
+		}
+	}
+	return -1
+}
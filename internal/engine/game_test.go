@@ -0,0 +1,1015 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"race-cars/internal/garage"
+	"race-cars/internal/legends"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// Helper function to create a race config on a built-in track
+func createTestConfig(t *testing.T, seats int) Config {
+	track, err := tracks.GetTrack("USA")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+
+	colors := []models.Color{models.Red, models.Blue, models.Green, models.Yellow, models.Orange, models.Black}
+	config := Config{Track: track, Laps: 1, Seed: 42}
+	for i := 0; i < seats; i++ {
+		config.Seats = append(config.Seats, Seat{Name: string(colors[i]), Color: colors[i]})
+	}
+	return config
+}
+
+// Helper function to create a single-seat race on a short track with one corner
+func createCornerGame(t *testing.T, config Config) Game {
+	config.Track = tracks.Track{
+		Name:    "Test",
+		Length:  30,
+		Laps:    1,
+		Corners: []tracks.Corner{{Space: 6, SpeedLimit: 2}, {Space: 20, SpeedLimit: 5}},
+	}
+	config.Seats = []Seat{{Name: "Solo", Color: models.Red}}
+
+	g, err := NewGame(config)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+	return g
+}
+
+// Helper function to replace a player's hand
+func setHand(player models.Player, cards []models.Card) {
+	for len(player.GetHand().GetCards()) > 0 {
+		player.GetHand().RemoveCard(0)
+	}
+	player.GetHand().AddCards(cards)
+}
+
+// autoAction returns a simple legal action for a seat: shift up when possible, play cards without heat costs first, always cool
+func autoAction(g Game, seat int) Action {
+	player := g.GetPlayers()[seat]
+
+	switch g.GetPhase() {
+	case PhaseDraft:
+		return Action{Type: ActionDraft, Cards: []int{0}}
+	case PhasePlanning:
+		current := player.GetCar().GetGear()
+		for _, gear := range []int{min(current+1, 5), current, max(current-1, 1)} {
+			cards := make([]int, 0)
+			for _, free := range []bool{true, false} {
+				for i, card := range player.GetHand().GetCards() {
+					if card.IsPlayable() && (garage.HeatCost(card) == 0) == free && len(cards) < gear {
+						cards = append(cards, i)
+					}
+				}
+			}
+			if ValidatePlan(player, gear, cards) == nil {
+				return Action{Type: ActionPlan, Gear: gear, Cards: cards}
+			}
+		}
+		return Action{Type: ActionPlan, Gear: current}
+	case PhaseReact:
+		return Action{Type: ActionReact, Icons: []models.Icon{models.IconCooling}}
+	case PhaseSlipstream:
+		return Action{Type: ActionSlipstream, Slipstream: true}
+	default:
+		return Action{Type: ActionDiscard}
+	}
+}
+
+// playToEnd submits automatic actions until the race is over
+func playToEnd(t *testing.T, g Game) {
+	for steps := 0; !g.IsFinished(); steps++ {
+		if steps > 10000 {
+			t.Fatal("race did not finish")
+		}
+		for seat := range g.GetPlayers() {
+			if g.IsWaitingFor(seat) {
+				if err := g.Submit(seat, autoAction(g, seat)); err != nil {
+					t.Fatalf("Submit(%d) in %s error = %v", seat, g.GetPhase(), err)
+				}
+			}
+		}
+	}
+}
+
+// findEvents returns the events of a type
+func findEvents(g Game, eventType EventType) []Event {
+	result := make([]Event, 0)
+	for _, event := range g.GetEvents() {
+		if event.Type == eventType {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func TestNewGame_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(config *Config)
+	}{
+		{
+			name:   "No seats",
+			modify: func(config *Config) { config.Seats = nil },
+		},
+		{
+			name: "Too many seats",
+			modify: func(config *Config) {
+				config.Seats = append(config.Seats, Seat{Name: "Extra", Color: models.Gray})
+			},
+		},
+		{
+			name:   "Duplicate color",
+			modify: func(config *Config) { config.Seats[1].Color = config.Seats[0].Color },
+		},
+		{
+			name:   "Seat without a name",
+			modify: func(config *Config) { config.Seats[0].Name = "" },
+		},
+		{
+			name:   "Unknown module",
+			modify: func(config *Config) { config.Modules = []string{"rocket"} },
+		},
+		{
+			name: "Corner condition off a corner",
+			modify: func(config *Config) {
+				config.CornerConditions = map[int]models.RoadCondition{1: models.GetCornerConditions()[0]}
+			},
+		},
+		{
+			name: "Missing sector",
+			modify: func(config *Config) {
+				config.SectorConditions = map[int]models.RoadCondition{9: models.GetSectorConditions()[0]}
+			},
+		},
+		{
+			name:   "Unknown upgrade",
+			modify: func(config *Config) { config.Seats[0].Upgrades = []string{"Rocket"} },
+		},
+		{
+			name: "Too many cars with Legends",
+			modify: func(config *Config) {
+				config.Legends = []legends.Driver{{Name: "Legend", Color: models.Gray}}
+			},
+		},
+		{
+			name: "Legend color taken",
+			modify: func(config *Config) {
+				config.Seats = config.Seats[:2]
+				config.Legends = []legends.Driver{{Name: "Legend", Color: config.Seats[0].Color}}
+			},
+		},
+		{
+			name: "Invalid Legend",
+			modify: func(config *Config) {
+				config.Seats = config.Seats[:2]
+				config.Legends = []legends.Driver{{Name: "Legend", Color: models.Gray, Level: legends.MaxLevel + 1}}
+			},
+		},
+		{
+			name:   "Invalid laps",
+			modify: func(config *Config) { config.Laps = -1 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t, MaxSeats)
+			tt.modify(&config)
+			if _, err := NewGame(config); err == nil {
+				t.Error("NewGame() should fail")
+			}
+		})
+	}
+}
+
+func TestNewGame_StartingState(t *testing.T) {
+	g, err := NewGame(createTestConfig(t, 4))
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+
+	if g.GetPhase() != PhasePlanning {
+		t.Errorf("GetPhase() = %s, want %s", g.GetPhase(), PhasePlanning)
+	}
+	if g.GetRound() != 1 {
+		t.Errorf("GetRound() = %d, want 1", g.GetRound())
+	}
+	if g.GetActiveSeat() != -1 {
+		t.Errorf("GetActiveSeat() = %d, want -1 while planning", g.GetActiveSeat())
+	}
+	if !reflect.DeepEqual(g.GetTurnOrder(), []int{0, 1, 2, 3}) {
+		t.Errorf("GetTurnOrder() = %v, want grid order", g.GetTurnOrder())
+	}
+
+	for seat, player := range g.GetPlayers() {
+		if len(player.GetHand().GetCards()) != models.HandSize {
+			t.Errorf("seat %d hand = %d cards, want %d", seat, len(player.GetHand().GetCards()), models.HandSize)
+		}
+		if player.GetCar().GetEngine() != models.StartingEngine {
+			t.Errorf("seat %d engine = %d, want %d", seat, player.GetCar().GetEngine(), models.StartingEngine)
+		}
+		if !g.IsWaitingFor(seat) {
+			t.Errorf("IsWaitingFor(%d) = false, want true", seat)
+		}
+	}
+
+	expectedSpaces := []int{2, 2, 1, 1}
+	for seat, player := range g.GetPlayers() {
+		space, _ := g.GetBoard().FindCar(player.GetCar())
+		if space != expectedSpaces[seat] {
+			t.Errorf("seat %d starts on space %d, want %d", seat, space, expectedSpaces[seat])
+		}
+	}
+}
+
+func TestGame_FullRace(t *testing.T) {
+	for seats := 1; seats <= MaxSeats; seats++ {
+		g, err := NewGame(createTestConfig(t, seats))
+		if err != nil {
+			t.Fatalf("NewGame() error = %v", err)
+		}
+
+		playToEnd(t, g)
+
+		results := g.GetResults()
+		if len(results) != seats {
+			t.Fatalf("%d seats: GetResults() = %d results", seats, len(results))
+		}
+		for i, result := range results {
+			if result.Position != i+1 {
+				t.Errorf("%d seats: result %d position = %d", seats, i, result.Position)
+			}
+		}
+		if g.IsWaitingFor(0) {
+			t.Errorf("%d seats: IsWaitingFor() should be false after the race", seats)
+		}
+		if err := g.Submit(0, Action{Type: ActionPlan}); err == nil {
+			t.Errorf("%d seats: Submit() after the race should fail", seats)
+		}
+	}
+}
+
+func TestGame_Deterministic(t *testing.T) {
+	play := func() []Event {
+		config := createTestConfig(t, 3)
+		config.Modules = []string{ModuleGarage, ModuleSponsors}
+		g, err := NewGame(config)
+		if err != nil {
+			t.Fatalf("NewGame() error = %v", err)
+		}
+		playToEnd(t, g)
+		return g.GetEvents()
+	}
+
+	if !reflect.DeepEqual(play(), play()) {
+		t.Error("Races with the same seed and actions should produce the same events")
+	}
+}
+
+func TestGame_GarageDraft(t *testing.T) {
+	config := createTestConfig(t, 2)
+	config.Modules = []string{ModuleGarage}
+	g, err := NewGame(config)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+
+	if g.GetPhase() != PhaseDraft || g.GetDraft() == nil {
+		t.Fatalf("GetPhase() = %s, want a draft", g.GetPhase())
+	}
+	if g.GetActiveSeat() != 0 {
+		t.Errorf("GetActiveSeat() = %d, want 0", g.GetActiveSeat())
+	}
+	if err := g.Submit(1, Action{Type: ActionDraft, Cards: []int{0}}); err == nil {
+		t.Error("Submit() out of draft order should fail")
+	}
+
+	for g.GetPhase() == PhaseDraft {
+		if err := g.Submit(g.GetActiveSeat(), Action{Type: ActionDraft, Cards: []int{0}}); err != nil {
+			t.Fatalf("Submit() draft error = %v", err)
+		}
+	}
+
+	if g.GetPhase() != PhasePlanning {
+		t.Errorf("GetPhase() = %s after the draft, want %s", g.GetPhase(), PhasePlanning)
+	}
+	if len(findEvents(g, EventDrafted)) != 2*3 {
+		t.Errorf("Drafted events = %d, want 6", len(findEvents(g, EventDrafted)))
+	}
+}
+
+func TestGame_SubmitValidation(t *testing.T) {
+	g, _ := NewGame(createTestConfig(t, 2))
+	player := g.GetPlayers()[0]
+	hand := player.GetHand().GetCards()
+
+	tests := []struct {
+		name   string
+		seat   int
+		action Action
+	}{
+		{name: "Missing seat", seat: 5, action: Action{Type: ActionPlan, Gear: 1, Cards: []int{0}}},
+		{name: "Wrong action for the phase", seat: 0, action: Action{Type: ActionDiscard}},
+		{name: "Too few cards", seat: 0, action: Action{Type: ActionPlan, Gear: 2, Cards: []int{0}}},
+		{name: "Repeated card", seat: 0, action: Action{Type: ActionPlan, Gear: 2, Cards: []int{0, 0}}},
+		{name: "Card out of range", seat: 0, action: Action{Type: ActionPlan, Gear: 1, Cards: []int{9}}},
+		{name: "Shift too far", seat: 0, action: Action{Type: ActionPlan, Gear: 4, Cards: []int{0, 1, 2, 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := g.Submit(tt.seat, tt.action); err == nil {
+				t.Error("Submit() should fail")
+			}
+			if !reflect.DeepEqual(player.GetHand().GetCards(), hand) {
+				t.Error("A rejected action should leave the hand unchanged")
+			}
+			if !g.IsWaitingFor(0) {
+				t.Error("A rejected action should not count as the seat's plan")
+			}
+		})
+	}
+
+	if err := g.Submit(0, autoAction(g, 0)); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if err := g.Submit(0, autoAction(g, 0)); err == nil {
+		t.Error("Submit() twice in the same planning phase should fail")
+	}
+}
+
+func TestGame_PlansHiddenUntilEverySeatPlanned(t *testing.T) {
+	g, _ := NewGame(createTestConfig(t, 2))
+	player := g.GetPlayers()[0]
+	gear := player.GetCar().GetGear()
+	handSize := player.GetHand().Size()
+
+	plan := autoAction(g, 0)
+	if err := g.Submit(0, plan); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if player.GetCar().GetGear() != gear || player.GetHand().Size() != handSize || len(findEvents(g, EventGearShifted)) != 0 {
+		t.Error("A plan should not change the car or the hand before every seat planned")
+	}
+	if got, ok := g.GetPlan(0); !ok || !reflect.DeepEqual(got, plan) {
+		t.Errorf("GetPlan() = %+v, %v, want %+v", got, ok, plan)
+	}
+	if _, ok := g.GetPlan(1); ok {
+		t.Error("GetPlan() for a seat that has not planned should return false")
+	}
+
+	g.ForgetPlan(0)
+	if _, ok := g.GetPlan(0); ok || !g.IsWaitingFor(0) {
+		t.Error("ForgetPlan() should let the seat plan again")
+	}
+	if err := g.Submit(0, plan); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	if err := g.Submit(1, autoAction(g, 1)); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if player.GetCar().GetGear() != plan.Gear || len(player.GetPlayedCards()) != len(plan.Cards) {
+		t.Errorf("gear %d with %d played cards, want the plan %+v once every seat planned", player.GetCar().GetGear(), len(player.GetPlayedCards()), plan)
+	}
+	if _, ok := g.GetPlan(0); ok {
+		t.Error("GetPlan() once every seat planned should return false")
+	}
+}
+
+func TestGame_WeatherStartingDecks(t *testing.T) {
+	tests := []struct {
+		name            string
+		weather         models.Weather
+		expectedEngine  int
+		expectedHeat    int
+		expectedStress  int
+		expectedDiscard int
+	}{
+		{
+			name:           "Clear weather",
+			expectedEngine: models.StartingEngine,
+			expectedStress: models.StartingStressCards,
+		},
+		{
+			name:           "Heat in the deck",
+			weather:        models.Weather{HeatInDeck: 3},
+			expectedEngine: models.StartingEngine - 3,
+			expectedHeat:   3,
+			expectedStress: models.StartingStressCards,
+		},
+		{
+			name:            "Heat in the discard pile",
+			weather:         models.Weather{HeatInDiscard: 3},
+			expectedEngine:  models.StartingEngine - 3,
+			expectedStress:  models.StartingStressCards,
+			expectedDiscard: 3,
+		},
+		{
+			name:           "Extra stress",
+			weather:        models.Weather{StressCards: 1},
+			expectedEngine: models.StartingEngine,
+			expectedStress: models.StartingStressCards + 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t, 1)
+			config.Weather = tt.weather
+			g, err := NewGame(config)
+			if err != nil {
+				t.Fatalf("NewGame() error = %v", err)
+			}
+
+			player := g.GetPlayers()[0]
+			cards := player.GetHand().GetCards()
+			for card := player.GetDeck().DrawCard(); card != nil; card = player.GetDeck().DrawCard() {
+				cards = append(cards, card)
+			}
+			counts := make(map[string]int)
+			for _, card := range cards {
+				counts[card.GetName()]++
+			}
+
+			if player.GetCar().GetEngine() != tt.expectedEngine {
+				t.Errorf("Engine = %d, want %d", player.GetCar().GetEngine(), tt.expectedEngine)
+			}
+			if counts[models.Heat] != tt.expectedHeat {
+				t.Errorf("Heat cards in deck = %d, want %d", counts[models.Heat], tt.expectedHeat)
+			}
+			if counts[models.Stress] != tt.expectedStress {
+				t.Errorf("Stress cards in deck = %d, want %d", counts[models.Stress], tt.expectedStress)
+			}
+			if len(player.GetDiscardPile().GetCards()) != tt.expectedDiscard {
+				t.Errorf("Discard pile = %d cards, want %d", len(player.GetDiscardPile().GetCards()), tt.expectedDiscard)
+			}
+		})
+	}
+}
+
+func TestGame_CornerChecks(t *testing.T) {
+	tests := []struct {
+		name           string
+		condition      *models.RoadCondition
+		engine         int
+		expectedEngine int
+		expectedSpace  int
+		spunOut        bool
+	}{
+		{
+			name:           "Pay heat for the speed over the limit",
+			engine:         6,
+			expectedEngine: 4,
+			expectedSpace:  6,
+		},
+		{
+			name:           "Tight apex lowers the limit",
+			condition:      &models.RoadCondition{Name: "Tight Apex", SpeedLimitModifier: -1},
+			engine:         6,
+			expectedEngine: 3,
+			expectedSpace:  6,
+		},
+		{
+			name:           "Wide apex raises the limit",
+			condition:      &models.RoadCondition{Name: "Wide Apex", SpeedLimitModifier: 1},
+			engine:         6,
+			expectedEngine: 5,
+			expectedSpace:  6,
+		},
+		{
+			name:           "Overheat adds heat",
+			condition:      &models.RoadCondition{Name: "Overheat", ExtraHeat: 1},
+			engine:         6,
+			expectedEngine: 3,
+			expectedSpace:  6,
+		},
+		{
+			name:           "Spin out without enough heat",
+			engine:         1,
+			expectedEngine: 1,
+			expectedSpace:  5,
+			spunOut:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Seed: 1}
+			if tt.condition != nil {
+				config.CornerConditions = map[int]models.RoadCondition{6: *tt.condition}
+			}
+			g := createCornerGame(t, config)
+			player := g.GetPlayers()[0]
+			player.GetCar().SetEngine(tt.engine)
+			setHand(player, []models.Card{models.NewSpeedCard(4)})
+
+			if err := g.Submit(0, Action{Type: ActionPlan, Gear: 1, Cards: []int{0}}); err != nil {
+				t.Fatalf("Submit() plan error = %v", err)
+			}
+			if err := g.Submit(0, Action{Type: ActionReact}); err != nil {
+				t.Fatalf("Submit() react error = %v", err)
+			}
+
+			if player.GetCar().GetEngine() != tt.expectedEngine {
+				t.Errorf("Engine = %d, want %d", player.GetCar().GetEngine(), tt.expectedEngine)
+			}
+			space, _ := g.GetBoard().FindCar(player.GetCar())
+			if space != tt.expectedSpace {
+				t.Errorf("Car on space %d, want %d", space, tt.expectedSpace)
+			}
+			if spun := len(findEvents(g, EventSpunOut)) > 0; spun != tt.spunOut {
+				t.Errorf("Spun out = %t, want %t", spun, tt.spunOut)
+			}
+			if tt.spunOut && len(player.GetHand().GetCards()) != 1 {
+				t.Errorf("Hand after spin out = %d cards, want 1 stress card", len(player.GetHand().GetCards()))
+			}
+			if g.GetPhase() != PhaseDiscard {
+				t.Errorf("GetPhase() = %s, want %s", g.GetPhase(), PhaseDiscard)
+			}
+		})
+	}
+}
+
+func TestGame_WeatherCooling(t *testing.T) {
+	tests := []struct {
+		name           string
+		weather        models.Weather
+		expectedEngine int
+	}{
+		{name: "Clear weather cools once per icon", expectedEngine: 3},
+		{name: "Rain prevents cooling", weather: models.Weather{NoCooling: true}, expectedEngine: 0},
+		{name: "Sunshine adds cooling", weather: models.Weather{CoolingModifier: 1}, expectedEngine: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := createCornerGame(t, Config{Seed: 1, Weather: tt.weather})
+			player := g.GetPlayers()[0]
+			player.GetCar().SetEngine(0)
+			heat := []models.Card{models.NewHeatCard(), models.NewHeatCard(), models.NewHeatCard(), models.NewHeatCard()}
+			setHand(player, append(heat, models.NewSpeedCard(1)))
+
+			// First gear gives three Cooling icons
+			if err := g.Submit(0, Action{Type: ActionPlan, Gear: 1, Cards: []int{4}}); err != nil {
+				t.Fatalf("Submit() plan error = %v", err)
+			}
+			if err := g.Submit(0, Action{Type: ActionReact, Icons: []models.Icon{models.IconCooling}}); err != nil {
+				t.Fatalf("Submit() react error = %v", err)
+			}
+
+			if player.GetCar().GetEngine() != tt.expectedEngine {
+				t.Errorf("Engine = %d, want %d", player.GetCar().GetEngine(), tt.expectedEngine)
+			}
+		})
+	}
+}
+
+func TestGame_Boost(t *testing.T) {
+	tests := []struct {
+		name           string
+		sector         *models.RoadCondition
+		engine         int
+		wantErr        bool
+		expectedEngine int
+	}{
+		{name: "Boost costs one heat", engine: 6, expectedEngine: 5},
+		{name: "Free boost sector", sector: &models.RoadCondition{Name: "Free Boost", FreeBoost: true}, engine: 6, expectedEngine: 6},
+		{name: "Cannot boost without heat", engine: 0, wantErr: true, expectedEngine: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Seed: 1}
+			if tt.sector != nil {
+				// The grid is in the last sector, which wraps around the finish line
+				config.SectorConditions = map[int]models.RoadCondition{1: *tt.sector}
+			}
+			g := createCornerGame(t, config)
+			player := g.GetPlayers()[0]
+			player.GetCar().SetEngine(tt.engine)
+			setHand(player, []models.Card{models.NewSpeedCard(1)})
+
+			if err := g.Submit(0, Action{Type: ActionPlan, Gear: 1, Cards: []int{0}}); err != nil {
+				t.Fatalf("Submit() plan error = %v", err)
+			}
+			err := g.Submit(0, Action{Type: ActionReact, Boost: true})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Submit() react error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if player.GetCar().GetEngine() != tt.expectedEngine {
+				t.Errorf("Engine = %d, want %d", player.GetCar().GetEngine(), tt.expectedEngine)
+			}
+			if !tt.wantErr && len(findEvents(g, EventBoosted)) != 1 {
+				t.Error("Boosting should add a boosted event")
+			}
+		})
+	}
+}
+
+func TestGame_SlipstreamDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		weather  models.Weather
+		sector   models.RoadCondition
+		expected int
+	}{
+		{name: "Base slipstream", expected: models.BaseSlipstream},
+		{name: "Tailwind", weather: models.Weather{SlipstreamModifier: 1}, expected: models.BaseSlipstream + 1},
+		{name: "Slipstream sector", sector: models.RoadCondition{SlipstreamModifier: 1}, expected: models.BaseSlipstream + 1},
+		{name: "Fog", weather: models.Weather{NoSlipstream: true}, sector: models.RoadCondition{SlipstreamModifier: 1}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Seed: 1, Weather: tt.weather, SectorConditions: map[int]models.RoadCondition{0: tt.sector}}
+			g := createCornerGame(t, config).(*game)
+			if got := g.slipstreamDistance(10); got != tt.expected {
+				t.Errorf("slipstreamDistance() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGame_Slipstream(t *testing.T) {
+	config := createTestConfig(t, 2)
+	g, _ := NewGame(config)
+	players := g.GetPlayers()
+	if g.HasAdrenaline(0) || !g.HasAdrenaline(1) || g.HasAdrenaline(2) {
+		t.Fatal("only the last car of two should have adrenaline")
+	}
+
+	// Both cars start on the front row; the leader moves 3 to space 5
+	setHand(players[0], []models.Card{models.NewSpeedCard(3)})
+	setHand(players[1], []models.Card{models.NewSpeedCard(1)})
+	g.Submit(0, Action{Type: ActionPlan, Gear: 1, Cards: []int{0}})
+	g.Submit(1, Action{Type: ActionPlan, Gear: 1, Cards: []int{0}})
+
+	g.Submit(0, Action{Type: ActionReact})
+	g.Submit(0, Action{Type: ActionDiscard})
+
+	// Seat 1 has adrenaline, so it moves 2 and ends right behind seat 0
+	if err := g.Submit(1, Action{Type: ActionReact}); err != nil {
+		t.Fatalf("Submit() react error = %v", err)
+	}
+	if g.GetPhase() != PhaseSlipstream {
+		t.Fatalf("GetPhase() = %s, want %s", g.GetPhase(), PhaseSlipstream)
+	}
+	if err := g.Submit(1, Action{Type: ActionSlipstream, Slipstream: true}); err != nil {
+		t.Fatalf("Submit() slipstream error = %v", err)
+	}
+
+	space, _ := g.GetBoard().FindCar(players[1].GetCar())
+	if space != 6 {
+		t.Errorf("Car on space %d after slipstreaming, want 6", space)
+	}
+}
+
+func TestGame_SponsorInCorner(t *testing.T) {
+	tests := []struct {
+		name     string
+		modules  []string
+		speed    int
+		engine   int
+		expected int
+	}{
+		{name: "Two over the limit", modules: []string{ModuleSponsors}, speed: 4, engine: 6, expected: 1},
+		{name: "One over the limit", modules: []string{ModuleSponsors}, speed: 3, engine: 6, expected: 0},
+		{name: "Spun out", modules: []string{ModuleSponsors}, speed: 4, engine: 1, expected: 0},
+		{name: "Module not used", speed: 4, engine: 6, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := createCornerGame(t, Config{Seed: 1, Modules: tt.modules})
+			player := g.GetPlayers()[0]
+			player.GetCar().SetEngine(tt.engine)
+			setHand(player, []models.Card{models.NewSpeedCard(tt.speed)})
+
+			if err := g.Submit(0, Action{Type: ActionPlan, Gear: 1, Cards: []int{0}}); err != nil {
+				t.Fatalf("Submit() plan error = %v", err)
+			}
+			if err := g.Submit(0, Action{Type: ActionReact}); err != nil {
+				t.Fatalf("Submit() react error = %v", err)
+			}
+
+			if got := len(findEvents(g, EventSponsored)); got != tt.expected {
+				t.Errorf("Sponsored events = %d, want %d", got, tt.expected)
+			}
+			sponsored := 0
+			for _, card := range player.GetHand().GetCards() {
+				if card.IsOneUse() {
+					sponsored++
+				}
+			}
+			if sponsored != tt.expected {
+				t.Errorf("Sponsor cards in hand = %d, want %d", sponsored, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGame_SponsorBySlipstream(t *testing.T) {
+	for _, press := range []bool{true, false} {
+		config := Config{
+			Track: tracks.Track{
+				Name:    "Press",
+				Length:  30,
+				Laps:    1,
+				Corners: []tracks.Corner{{Space: 6, SpeedLimit: 5, Press: press}},
+			},
+			Seats:   []Seat{{Name: "Leader", Color: models.Red}, {Name: "Chaser", Color: models.Blue}},
+			Seed:    1,
+			Modules: []string{ModuleSponsors},
+		}
+		g, err := NewGame(config)
+		if err != nil {
+			t.Fatalf("NewGame() error = %v", err)
+		}
+		players := g.GetPlayers()
+
+		// The leader stops on space 5, the chaser with adrenaline stops right behind it and slipstreams across the corner
+		setHand(players[0], []models.Card{models.NewSpeedCard(3)})
+		setHand(players[1], []models.Card{models.NewSpeedCard(1)})
+		g.Submit(0, Action{Type: ActionPlan, Gear: 1, Cards: []int{0}})
+		g.Submit(1, Action{Type: ActionPlan, Gear: 1, Cards: []int{0}})
+		g.Submit(0, Action{Type: ActionReact})
+		g.Submit(0, Action{Type: ActionDiscard})
+		g.Submit(1, Action{Type: ActionReact})
+		if err := g.Submit(1, Action{Type: ActionSlipstream, Slipstream: true}); err != nil {
+			t.Fatalf("Submit() slipstream error = %v", err)
+		}
+
+		expected := 0
+		if press {
+			expected = 1
+		}
+		if got := len(findEvents(g, EventSponsored)); got != expected {
+			t.Errorf("press %t: Sponsored events = %d, want %d", press, got, expected)
+		}
+	}
+}
+
+func TestGame_SeatUpgrades(t *testing.T) {
+	config := createTestConfig(t, 2)
+	config.Seats[0].Upgrades = []string{"Turbocharger", "Brakes"}
+	g, err := NewGame(config)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+
+	if g.GetPhase() != PhasePlanning || g.GetDraft() != nil {
+		t.Error("Upgrades without the Garage module should not start a draft")
+	}
+	if _, ok := g.GetIconRegistry().GetEffect(garage.IconHeatControl); !ok {
+		t.Error("Upgrades should register the Garage icon effects")
+	}
+
+	for seat, expected := range []int{2, 0} {
+		player := g.GetPlayers()[seat]
+		cards := player.GetHand().GetCards()
+		for card := player.GetDeck().DrawCard(); card != nil; card = player.GetDeck().DrawCard() {
+			cards = append(cards, card)
+		}
+
+		upgrades := 0
+		for _, card := range cards {
+			if card.GetName() == "Turbocharger" || card.GetName() == "Brakes" {
+				upgrades++
+			}
+		}
+		if upgrades != expected {
+			t.Errorf("seat %d has %d upgrades, want %d", seat, upgrades, expected)
+		}
+	}
+}
+
+func TestValidatePlan_HeatBudget(t *testing.T) {
+	turbo, _ := garage.NewUpgradeCard("Turbocharger")
+	player := models.NewPlayer("Test", models.NewCar("red", 1), models.NewDiscardPile(), models.NewDeck(nil), models.NewHand())
+	player.GetHand().AddCards([]models.Card{turbo, turbo, models.NewHeatCard()})
+	player.GetCar().SetGear(2, player.GetDiscardPile())
+
+	tests := []struct {
+		name    string
+		gear    int
+		cards   []int
+		wantErr bool
+	}{
+		{name: "Only one upgrade can be paid for", gear: 2, cards: []int{0}},
+		{name: "Both upgrades need two heat", gear: 2, cards: []int{0, 1}, wantErr: true},
+		{name: "Heat cards cannot be played", gear: 2, cards: []int{2}, wantErr: true},
+		{name: "Shift of two gears uses the only heat", gear: 4, cards: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePlan(player, tt.gear, tt.cards)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePlan() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Helper function to create a race with two players and four Legends
+func createLegendsConfig(t *testing.T) Config {
+	config := createTestConfig(t, 2)
+	config.Legends = []legends.Driver{
+		{Name: "Legend 1", Color: models.Green, Level: 0},
+		{Name: "Legend 2", Color: models.Yellow, Level: 1},
+		{Name: "Legend 3", Color: models.Orange, Level: 2},
+		{Name: "Legend 4", Color: models.Black, Level: legends.MaxLevel},
+	}
+	return config
+}
+
+func TestGame_LegendsOnTheGrid(t *testing.T) {
+	g, err := NewGame(createLegendsConfig(t))
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+
+	if len(g.GetPlayers()) != 2 {
+		t.Errorf("GetPlayers() = %d players, want 2", len(g.GetPlayers()))
+	}
+	racers := g.GetRacers()
+	if len(racers) != MaxSeats {
+		t.Fatalf("GetRacers() = %d racers, want %d", len(racers), MaxSeats)
+	}
+
+	expectedSpaces := []int{2, 2, 1, 1, 0, 0}
+	for seat, racer := range racers {
+		space, _ := g.GetBoard().FindCar(racer.GetCar())
+		if space != expectedSpaces[seat] {
+			t.Errorf("%s starts on space %d, want %d", racer.GetName(), space, expectedSpaces[seat])
+		}
+	}
+
+	if !reflect.DeepEqual(g.GetTurnOrder(), []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("GetTurnOrder() = %v, want Legends in the turn order", g.GetTurnOrder())
+	}
+	for seat := 2; seat < MaxSeats; seat++ {
+		if g.IsWaitingFor(seat) {
+			t.Errorf("IsWaitingFor(%d) = true, Legends never submit actions", seat)
+		}
+		if err := g.Submit(seat, Action{Type: ActionPlan, Gear: 1}); err == nil {
+			t.Errorf("Submit(%d) for a Legend should fail", seat)
+		}
+	}
+	if len(findEvents(g, EventLegendCard)) != 1 {
+		t.Error("A Legends card should be flipped at the start of the round")
+	}
+}
+
+func TestGame_RaceWithLegends(t *testing.T) {
+	g, _ := NewGame(createLegendsConfig(t))
+	playToEnd(t, g)
+
+	results := g.GetResults()
+	if len(results) != MaxSeats {
+		t.Fatalf("GetResults() = %d results, want %d", len(results), MaxSeats)
+	}
+
+	finished := 0
+	for _, result := range results {
+		if result.Seat >= 2 && result.Round > 0 {
+			finished++
+		}
+	}
+	if finished != 4 {
+		t.Errorf("%d Legends finished, want 4", finished)
+	}
+	if len(findEvents(g, EventLegendCard)) != g.GetRound() {
+		t.Errorf("Legends cards flipped = %d, want one per round (%d)", len(findEvents(g, EventLegendCard)), g.GetRound())
+	}
+}
+
+func TestGame_SoloWithLegends(t *testing.T) {
+	config := createTestConfig(t, 1)
+	config.Legends = []legends.Driver{{Name: "Legend", Color: models.Black, Level: legends.MaxLevel}}
+	g, _ := NewGame(config)
+	player := g.GetPlayers()[0]
+
+	// The player stalls in first gear, so the Legend finishes first and keeps the race going
+	for steps := 0; !g.IsFinished(); steps++ {
+		if steps > 10000 {
+			t.Fatal("race did not finish")
+		}
+		if !g.IsWaitingFor(0) {
+			continue
+		}
+		action := autoAction(g, 0)
+		if action.Type == ActionPlan {
+			action = Action{Type: ActionPlan, Gear: 1, Cards: []int{}}
+			for i, card := range player.GetHand().GetCards() {
+				if card.IsPlayable() && garage.HeatCost(card) == 0 {
+					action.Cards = []int{i}
+					break
+				}
+			}
+		}
+		if err := g.Submit(0, action); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	results := g.GetResults()
+	if results[0].Name != "Legend" {
+		t.Errorf("Winner = %s, want the Legend", results[0].Name)
+	}
+}
+
+func TestGame_Clone(t *testing.T) {
+	config := createLegendsConfig(t)
+	config.Modules = []string{ModuleSponsors}
+	g, err := NewGame(config)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+	for g.GetRound() < 3 {
+		for seat := range g.GetPlayers() {
+			if g.IsWaitingFor(seat) {
+				if err := g.Submit(seat, autoAction(g, seat)); err != nil {
+					t.Fatalf("Submit(%d) error = %v", seat, err)
+				}
+			}
+		}
+	}
+
+	events := g.GetEvents()
+	positions := make([]int, 0)
+	for _, racer := range g.GetRacers() {
+		space, _ := g.GetBoard().FindCar(racer.GetCar())
+		positions = append(positions, space)
+	}
+	hand := g.GetPlayers()[0].GetHand().GetCards()
+
+	play := func() []Event {
+		clone, err := g.Clone(7)
+		if err != nil {
+			t.Fatalf("Clone() error = %v", err)
+		}
+		if clone.GetPhase() != g.GetPhase() || clone.GetRound() != g.GetRound() {
+			t.Fatalf("Clone() is in round %d %s, want round %d %s", clone.GetRound(), clone.GetPhase(), g.GetRound(), g.GetPhase())
+		}
+		playToEnd(t, clone)
+		return clone.GetEvents()
+	}
+
+	first := play()
+	if !reflect.DeepEqual(first, play()) {
+		t.Error("Clones with the same seed and actions should produce the same events")
+	}
+	if len(first) <= len(events) {
+		t.Error("the clone should have played on")
+	}
+
+	if !reflect.DeepEqual(g.GetEvents(), events) {
+		t.Error("Playing the clone should not change the original's events")
+	}
+	for i, racer := range g.GetRacers() {
+		if space, _ := g.GetBoard().FindCar(racer.GetCar()); space != positions[i] {
+			t.Errorf("racer %d moved from %d to %d on the original board", i, positions[i], space)
+		}
+	}
+	if !reflect.DeepEqual(g.GetPlayers()[0].GetHand().GetCards(), hand) {
+		t.Error("Playing the clone should not change the original hands")
+	}
+
+	// The original can still be played to the end
+	playToEnd(t, g)
+}
+
+func TestGame_CloneDuringDraft(t *testing.T) {
+	config := createTestConfig(t, 2)
+	config.Modules = []string{ModuleGarage}
+	g, err := NewGame(config)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+
+	if _, err := g.Clone(1); err == nil {
+		t.Error("Clone() during the draft should fail")
+	}
+}
+
+func BenchmarkGame_Clone(b *testing.B) {
+	track, _ := tracks.GetTrack("USA")
+	config := Config{Track: track, Laps: 1, Seed: 42}
+	for _, color := range []models.Color{models.Red, models.Blue, models.Green, models.Yellow} {
+		config.Seats = append(config.Seats, Seat{Name: string(color), Color: color})
+	}
+	g, _ := NewGame(config)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Clone(int64(i))
+	}
+}
@@ -0,0 +1,224 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+
+	"race-cars/internal/garage"
+	"race-cars/internal/models"
+)
+
+// maxIconChoices caps the optional icons whose subsets are listed one by one
+// With more optional icons than this only accepting all or none of them is listed
+const maxIconChoices = 4
+
+// LegalActions returns every action a seat may submit right now
+// Equivalent actions, like playing either of two identical cards, are listed once
+// Input: seat - the seat index
+// Returns: the legal actions, empty when the game is not waiting for the seat
+func (g *game) LegalActions(seat int) []Action {
+	if !g.IsWaitingFor(seat) {
+		return []Action{}
+	}
+
+	switch g.phase {
+	case PhaseDraft:
+		return g.legalDrafts()
+	case PhasePlanning:
+		return legalPlans(g.seats[seat].player)
+	case PhaseReact:
+		return g.legalReactions(seat)
+	case PhaseSlipstream:
+		return []Action{
+			{Type: ActionSlipstream, Slipstream: true},
+			{Type: ActionSlipstream, Slipstream: false},
+		}
+	default:
+		return legalDiscards(g.seats[seat].player)
+	}
+}
+
+// legalDrafts lists a pick of every distinct upgrade in the draft market
+// Input: none
+// Returns: the draft actions
+func (g *game) legalDrafts() []Action {
+	actions := make([]Action, 0)
+	seen := make(map[string]bool)
+	for i, card := range g.draft.GetMarket() {
+		if !seen[card.GetName()] {
+			seen[card.GetName()] = true
+			actions = append(actions, Action{Type: ActionDraft, Cards: []int{i}})
+		}
+	}
+	return actions
+}
+
+// legalPlans lists every gear a player can shift to with every distinct selection of cards it allows
+// Input: player - the player planning
+// Returns: the plan actions, lowest gear first
+func legalPlans(player models.Player) []Action {
+	hand := player.GetHand()
+	playable := make([]int, 0, hand.Size())
+	for i := 0; i < hand.Size(); i++ {
+		if hand.GetCard(i).IsPlayable() {
+			playable = append(playable, i)
+		}
+	}
+
+	actions := make([]Action, 0)
+	current := player.GetCar().GetGear()
+	for gear := max(current-2, 1); gear <= min(current+2, 5); gear++ {
+		seen := make(map[string]bool)
+		for size := 0; size <= min(gear, len(playable)); size++ {
+			for _, cards := range combinations(playable, size) {
+				key := cardsKey(hand, cards)
+				if seen[key] || ValidatePlan(player, gear, cards) != nil {
+					continue
+				}
+				seen[key] = true
+				actions = append(actions, Action{Type: ActionPlan, Gear: gear, Cards: cards})
+			}
+		}
+	}
+	return actions
+}
+
+// legalReactions lists every combination of Direct Play cards, boost and optional icons the active seat can afford
+// Input: seat - the active seat
+// Returns: the react actions
+func (g *game) legalReactions(seat int) []Action {
+	player := g.seats[seat].player
+	hand := player.GetHand()
+
+	directPlay := make([]int, 0)
+	icons := make(map[models.Icon]bool)
+	for icon, count := range player.GetIcons() {
+		icons[icon] = count > 0
+	}
+	if g.garage {
+		for i := 0; i < hand.Size(); i++ {
+			if card := hand.GetCard(i); card.GetIcons()[garage.IconDirectPlay] > 0 {
+				directPlay = append(directPlay, i)
+				for icon := range card.GetIcons() {
+					icons[icon] = true
+				}
+			}
+		}
+	}
+
+	optional := make([]models.Icon, 0)
+	for icon, present := range icons {
+		effect, ok := g.registry.GetEffect(icon)
+		if present && ok && !effect.Mandatory {
+			optional = append(optional, icon)
+		}
+	}
+	sort.Slice(optional, func(i, j int) bool { return optional[i] < optional[j] })
+	iconChoices := [][]models.Icon{optional, {}}
+	if len(optional) <= maxIconChoices {
+		iconChoices = subsets(optional)
+	}
+
+	actions := make([]Action, 0)
+	seen := make(map[string]bool)
+	for size := 0; size <= len(directPlay); size++ {
+		for _, cards := range combinations(directPlay, size) {
+			key := cardsKey(hand, cards)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			for _, boost := range []bool{false, true} {
+				for _, accepted := range iconChoices {
+					action := Action{Type: ActionReact, DirectPlay: cards, Boost: boost, Icons: accepted}
+					if g.validateReact(seat, action) == nil {
+						actions = append(actions, action)
+					}
+				}
+			}
+		}
+	}
+	return actions
+}
+
+// legalDiscards lists every distinct selection of discardable cards, starting with discarding nothing
+// Input: player - the player discarding
+// Returns: the discard actions
+func legalDiscards(player models.Player) []Action {
+	hand := player.GetHand()
+	discardable := make([]int, 0, hand.Size())
+	for i := 0; i < hand.Size(); i++ {
+		if hand.GetCard(i).IsDiscardable() {
+			discardable = append(discardable, i)
+		}
+	}
+
+	actions := make([]Action, 0)
+	seen := make(map[string]bool)
+	for size := 0; size <= len(discardable); size++ {
+		for _, cards := range combinations(discardable, size) {
+			key := cardsKey(hand, cards)
+			if !seen[key] {
+				seen[key] = true
+				actions = append(actions, Action{Type: ActionDiscard, Cards: cards})
+			}
+		}
+	}
+	return actions
+}
+
+// combinations returns every selection of size elements from items, keeping their order
+// Input: items - the elements to choose from
+//
+//	size - the number of elements in each selection
+//
+// Returns: the selections, each a new slice
+func combinations(items []int, size int) [][]int {
+	if size == 0 {
+		return [][]int{{}}
+	}
+	if size > len(items) {
+		return [][]int{}
+	}
+
+	result := make([][]int, 0)
+	for i := 0; i <= len(items)-size; i++ {
+		for _, rest := range combinations(items[i+1:], size-1) {
+			result = append(result, append([]int{items[i]}, rest...))
+		}
+	}
+	return result
+}
+
+// subsets returns every subset of icons, the full set first
+// Input: icons - the icons to choose from
+// Returns: the subsets, each a new slice
+func subsets(icons []models.Icon) [][]models.Icon {
+	result := make([][]models.Icon, 0, 1<<len(icons))
+	for mask := (1 << len(icons)) - 1; mask >= 0; mask-- {
+		subset := make([]models.Icon, 0)
+		for i, icon := range icons {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, icon)
+			}
+		}
+		result = append(result, subset)
+	}
+	return result
+}
+
+// cardsKey identifies a selection of cards by their names, so identical cards give the same key
+// Input: hand - the cards in hand
+//
+//	indexes - the selected indexes
+//
+// Returns: the key
+func cardsKey(hand models.Hand, indexes []int) string {
+	names := make([]string, len(indexes))
+	for i, index := range indexes {
+		names[i] = hand.GetCard(index).GetName()
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"race-cars/internal/models"
+)
+
+func TestGame_LegalActions_Planning(t *testing.T) {
+	g, _ := NewGame(createTestConfig(t, 2))
+	player := g.GetPlayers()[0]
+	setHand(player, []models.Card{
+		models.NewSpeedCard(1), models.NewSpeedCard(1), models.NewSpeedCard(2),
+		models.NewStressCard(), models.NewHeatCard(),
+	})
+
+	actions := g.LegalActions(0)
+	gears := make(map[int]int)
+	for _, action := range actions {
+		if action.Type != ActionPlan {
+			t.Fatalf("LegalActions() returned a %s action while planning", action.Type)
+		}
+		if err := ValidatePlan(player, action.Gear, action.Cards); err != nil {
+			t.Errorf("LegalActions() returned an invalid plan %v: %v", action, err)
+		}
+		gears[action.Gear]++
+	}
+
+	// Gear 1: three distinct single cards; gear 2: 1+1, 1+2, 1+S, 2+S; gear 3: 1+1+2, 1+1+S, 1+2+S
+	expected := map[int]int{1: 3, 2: 4, 3: 3}
+	if !reflect.DeepEqual(gears, expected) {
+		t.Errorf("Plans per gear = %v, want %v", gears, expected)
+	}
+}
+
+func TestGame_LegalActions_NotWaiting(t *testing.T) {
+	g, _ := NewGame(createTestConfig(t, 2))
+	g.Submit(0, autoAction(g, 0))
+
+	if len(g.LegalActions(0)) != 0 {
+		t.Error("LegalActions() should be empty for a seat that already planned")
+	}
+	if len(g.LegalActions(9)) != 0 {
+		t.Error("LegalActions() should be empty for a missing seat")
+	}
+}
+
+func TestGame_LegalActions_Discard(t *testing.T) {
+	g := createCornerGame(t, Config{Seed: 1})
+	player := g.GetPlayers()[0]
+	setHand(player, []models.Card{models.NewSpeedCard(1), models.NewSpeedCard(3), models.NewSpeedCard(3), models.NewHeatCard()})
+	g.Submit(0, Action{Type: ActionPlan, Gear: 1, Cards: []int{0}})
+	g.Submit(0, Action{Type: ActionReact})
+
+	// Nothing, one Speed 3, or both Speed 3 cards: the Heat card cannot be discarded
+	actions := g.LegalActions(0)
+	if len(actions) != 3 {
+		t.Errorf("LegalActions() = %d discards, want 3", len(actions))
+	}
+	if len(actions[0].Cards) != 0 {
+		t.Error("Discarding nothing should be listed first")
+	}
+}
+
+// Every listed action must be accepted, whichever one is picked
+func TestGame_LegalActions_AlwaysAccepted(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		config := createTestConfig(t, 3)
+		config.Seed = seed
+		config.Modules = []string{ModuleGarage, ModuleSponsors}
+		g, _ := NewGame(config)
+		rng := rand.New(rand.NewSource(seed))
+
+		for steps := 0; !g.IsFinished(); steps++ {
+			if steps > 10000 {
+				t.Fatal("race did not finish")
+			}
+			for seat := range g.GetPlayers() {
+				actions := g.LegalActions(seat)
+				if !g.IsWaitingFor(seat) {
+					continue
+				}
+				if len(actions) == 0 {
+					t.Fatalf("seed %d: no legal actions for seat %d in %s", seed, seat, g.GetPhase())
+				}
+				action := actions[rng.Intn(len(actions))]
+				if err := g.Submit(seat, action); err != nil {
+					t.Fatalf("seed %d: legal action %+v rejected in %s: %v", seed, action, g.GetPhase(), err)
+				}
+			}
+		}
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		items    []int
+		size     int
+		expected int
+	}{
+		{items: []int{1, 2, 3, 4}, size: 2, expected: 6},
+		{items: []int{1, 2, 3, 4}, size: 0, expected: 1},
+		{items: []int{1, 2}, size: 3, expected: 0},
+	}
+
+	for _, tt := range tests {
+		if got := len(combinations(tt.items, tt.size)); got != tt.expected {
+			t.Errorf("combinations(%v, %d) = %d selections, want %d", tt.items, tt.size, got, tt.expected)
+		}
+	}
+}
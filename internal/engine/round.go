@@ -0,0 +1,328 @@
+package engine
+
+import (
+	"race-cars/internal/models"
+	"race-cars/internal/sponsors"
+)
+
+// startRound begins a new round: seats are ordered leader first and the last cars get adrenaline
+// The race ends instead once every car has finished or the round limit is reached
+// Input: none
+// Returns: none
+func (g *game) startRound() {
+	if len(g.finishers) == len(g.seats) || g.round >= g.config.MaxRounds {
+		g.finishRace()
+		return
+	}
+
+	g.round++
+	g.turnOrder = make([]int, 0, len(g.seats))
+	for _, car := range g.board.GetRanking() {
+		seat := g.seatOfCar(car)
+		if seat >= 0 && !g.seats[seat].finished {
+			g.turnOrder = append(g.turnOrder, seat)
+		}
+	}
+
+	adrenaline := 1
+	if len(g.seats) >= 5 {
+		adrenaline = 2
+	}
+	for i, seat := range g.turnOrder {
+		state := g.seats[seat]
+		state.planned = state.legend != nil
+		state.adrenaline = state.legend == nil && len(g.seats) > 1 && i >= len(g.turnOrder)-adrenaline
+	}
+
+	g.phase = PhasePlanning
+	g.emit(EventRoundStarted, -1, g.round, nil)
+
+	if g.legends != nil {
+		g.card = g.legends.Draw()
+		g.emit(EventLegendCard, -1, g.card.Speed, nil)
+	}
+
+	// Once every player has finished the Legends race on by themselves
+	if g.allPlanned() {
+		g.turn = 0
+		g.beginTurn()
+	}
+}
+
+// allPlanned returns whether every racing seat has submitted its plan
+// Input: none
+// Returns: a boolean
+func (g *game) allPlanned() bool {
+	for _, seat := range g.turnOrder {
+		if !g.seats[seat].planned {
+			return false
+		}
+	}
+	return true
+}
+
+// beginTurn reveals the played cards of the next seat in turn order and waits for its reaction
+// Legends move on their own until a player's turn comes up; once every seat has had its turn the next round starts
+// Input: none
+// Returns: none
+func (g *game) beginTurn() {
+	for g.turn < len(g.turnOrder) && g.seats[g.turnOrder[g.turn]].legend != nil {
+		g.moveLegend(g.turnOrder[g.turn])
+		g.turn++
+	}
+	if g.turn >= len(g.turnOrder) {
+		g.startRound()
+		return
+	}
+
+	seat := g.turnOrder[g.turn]
+	state := g.seats[seat]
+	player := state.player
+
+	names := make([]string, 0)
+	for _, card := range player.GetPlayedCards() {
+		names = append(names, card.GetName())
+	}
+	player.ResolvePlayedCards()
+	g.emit(EventCardsRevealed, seat, player.GetCar().GetSpeed(), names)
+
+	if state.adrenaline {
+		car := player.GetCar()
+		car.SetSpeed(car.GetSpeed() + 1)
+		player.AddIcons(map[models.Icon]int{models.IconCooling: 1})
+		g.emit(EventAdrenaline, seat, 1, nil)
+	}
+
+	state.startSpace, _ = g.board.FindCar(player.GetCar())
+	g.phase = PhaseReact
+}
+
+// move drives the active seat's car its full speed and offers the slipstream when it is allowed
+// Input: seat - the active seat
+// Returns: an error if the car is not on the board
+func (g *game) move(seat int) error {
+	car := g.seats[seat].player.GetCar()
+	to, err := g.board.MoveCar(car, car.GetSpeed())
+	if err != nil {
+		return err
+	}
+	g.emit(EventMoved, seat, to, nil)
+
+	if g.canSlipstream(car, to) {
+		g.phase = PhaseSlipstream
+		return nil
+	}
+
+	g.checkCorners(seat)
+	return nil
+}
+
+// canSlipstream returns whether a car may slipstream from a space
+// A car slipstreams when it shares a space with another car or is directly behind one
+// Input: car - the car that moved
+//
+//	space - the index of the space the car ended on
+//
+// Returns: a boolean
+func (g *game) canSlipstream(car models.Car, space int) bool {
+	if car.GetSpeed() <= 0 || g.slipstreamDistance(space) == 0 {
+		return false
+	}
+
+	spaces := g.board.GetSpaces()
+	if len(spaces[space].GetCars()) > 1 {
+		return true
+	}
+	return spaces[(space+1)%len(spaces)].IsOccupied()
+}
+
+// checkCorners makes the active seat pay heat for every corner it passed this turn
+// A car that cannot pay spins out before the corner, takes stress cards and drops to first gear
+// Every corner taken without spinning out may earn a sponsor card
+// Input: seat - the active seat
+// Returns: none
+func (g *game) checkCorners(seat int) {
+	player := g.seats[seat].player
+	car := player.GetCar()
+
+	for _, corner := range car.GetPassedCorners() {
+		heat := g.cornerHeat(corner, car.GetSpeed())
+		if heat > car.GetEngine() {
+			g.spinOut(seat, corner)
+			break
+		}
+
+		if heat > 0 {
+			g.payHeat(player, heat)
+			g.emit(EventHeatPaid, seat, heat, nil)
+		}
+		g.awardSponsor(seat, corner)
+	}
+	car.ResetPassedCorners()
+	g.seats[seat].slipstreamCorners = nil
+
+	if g.finishIfOver(seat) {
+		player.DiscardPlayedCards()
+		g.turn++
+		g.beginTurn()
+		return
+	}
+
+	g.phase = PhaseDiscard
+}
+
+// moveLegend drives a Legend's car by the Legends card flipped for the round
+// Input: seat - the Legend's seat
+// Returns: none
+func (g *game) moveLegend(seat int) {
+	to, err := g.seats[seat].legend.Move(g.board, g.lines, g.card)
+	if err != nil {
+		return
+	}
+	g.emit(EventMoved, seat, to, nil)
+	g.finishIfOver(seat)
+}
+
+// finishIfOver records a seat as finished once its car has completed the race
+// Input: seat - the seat that just moved
+// Returns: whether the seat finished
+func (g *game) finishIfOver(seat int) bool {
+	if g.seats[seat].racer.GetCar().GetLap() < g.board.GetNumberOfLaps() {
+		return false
+	}
+
+	g.seats[seat].finished = true
+	g.seats[seat].finishRound = g.round
+	g.finishers = append(g.finishers, seat)
+	g.emit(EventFinished, seat, len(g.finishers), nil)
+	return true
+}
+
+// awardSponsor gives the seat a sponsor card if it earned one in a corner
+// Sponsor cards go straight to the hand; none are awarded once the sponsor deck runs out
+// Input: seat - the seat that took the corner
+//
+//	corner - the index of the corner space
+//
+// Returns: none
+func (g *game) awardSponsor(seat int, corner int) {
+	if g.sponsors == nil {
+		return
+	}
+
+	state := g.seats[seat]
+	over := state.player.GetCar().GetSpeed() - g.board.GetCornerLimit(corner)
+	pressSlipstream := false
+	if g.config.Track.IsPressCorner(corner) {
+		for _, crossed := range state.slipstreamCorners {
+			pressSlipstream = pressSlipstream || crossed == corner
+		}
+	}
+	if !sponsors.IsEarned(over, pressSlipstream) {
+		return
+	}
+
+	card := g.sponsors.DrawCard()
+	if card == nil {
+		return
+	}
+	state.player.GetHand().AddCards([]models.Card{card})
+	g.emit(EventSponsored, seat, corner, nil)
+}
+
+// spinOut sends a car back before the corner it could not take
+// The player takes one stress card in gears 1 and 2, two in higher gears, and shifts down to first gear
+// Input: seat - the seat that spun out
+//
+//	corner - the index of the corner space
+//
+// Returns: none
+func (g *game) spinOut(seat int, corner int) {
+	player := g.seats[seat].player
+	car := player.GetCar()
+
+	to, err := g.board.SpinOut(car, corner)
+	if err != nil {
+		return
+	}
+
+	stress := 1
+	if car.GetGear() > 2 {
+		stress = 2
+	}
+	for i := 0; i < stress; i++ {
+		player.GetHand().AddCards([]models.Card{models.NewStressCard()})
+	}
+	car.ResetGear()
+	g.emit(EventSpunOut, seat, to, nil)
+}
+
+// endTurn discards the played cards, refills the hand and moves on to the next seat
+// Input: seat - the seat ending its turn
+// Returns: none
+func (g *game) endTurn(seat int) {
+	player := g.seats[seat].player
+	player.DiscardPlayedCards()
+	g.replenish(player)
+
+	g.turn++
+	g.beginTurn()
+}
+
+// finishRace records the final standings and ends the game
+// Cars that crossed the finish line are ranked in the order they finished, the rest by race position
+// Input: none
+// Returns: none
+func (g *game) finishRace() {
+	order := make([]int, len(g.finishers))
+	copy(order, g.finishers)
+	for _, car := range g.board.GetRanking() {
+		seat := g.seatOfCar(car)
+		if seat >= 0 && !g.seats[seat].finished {
+			order = append(order, seat)
+		}
+	}
+
+	g.results = make([]Result, len(order))
+	for i, seat := range order {
+		g.results[i] = Result{
+			Seat:     seat,
+			Name:     g.seats[seat].racer.GetName(),
+			Position: i + 1,
+			Round:    g.seats[seat].finishRound,
+		}
+	}
+
+	g.phase = PhaseFinished
+	g.emit(EventRaceFinished, -1, g.round, nil)
+}
+
+// payHeat moves heat from a car's engine into the player's discard pile
+// Input: player - the player paying
+//
+//	heat - the number of heat cards to pay
+//
+// Returns: none
+func (g *game) payHeat(player models.Player, heat int) {
+	car := player.GetCar()
+	for i := 0; i < heat && car.GetEngine() > 0; i++ {
+		car.SetEngine(car.GetEngine() - 1)
+		player.GetDiscardPile().AddCard(models.NewHeatCard())
+	}
+}
+
+// replenish draws cards until the player holds a full hand
+// The discard pile is shuffled into the deck when the deck runs out
+// Input: player - the player drawing
+// Returns: none
+func (g *game) replenish(player models.Player) {
+	for player.GetHand().Size() < models.HandSize {
+		if player.GetDeck().IsEmpty() {
+			player.GetDiscardPile().ResetDeck(player.GetDeck())
+			if player.GetDeck().IsEmpty() {
+				return
+			}
+		}
+		player.DrawCard(player.GetDeck())
+	}
+}
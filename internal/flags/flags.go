@@ -0,0 +1,17 @@
+// Package flags holds the command-line helpers shared by the commands in cmd
+package flags
+
+import "strings"
+
+// SplitList splits a comma-separated flag, ignoring empty entries
+// Input: value - the flag value, like "hard, normal,,easy"
+// Returns: the trimmed entries, empty when there are none
+func SplitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
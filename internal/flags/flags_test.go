@@ -0,0 +1,27 @@
+package flags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{"Empty", "", []string{}},
+		{"One entry", "hard", []string{"hard"}},
+		{"Several entries", "hard,normal,easy", []string{"hard", "normal", "easy"}},
+		{"Spaces and empty entries", " hard, ,normal,, ", []string{"hard", "normal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitList(tt.value); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SplitList(%q) = %q, want %q", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
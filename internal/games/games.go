@@ -0,0 +1,189 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// MaxLaps is the longest race a game can be set up for
+const MaxLaps = 10
+
+// Status is where a game is in its life
+type Status string
+
+const (
+	// StatusWaiting means the game is waiting for players to join and start it
+	StatusWaiting Status = "waiting"
+
+	// StatusRacing means the race is being played
+	StatusRacing Status = "racing"
+
+	// StatusFinished means the race is over
+	StatusFinished Status = "finished"
+)
+
+var (
+	// ErrGameNotFound is returned when there is no game with an ID
+	ErrGameNotFound = errors.New("game not found")
+
+	// ErrInvalidSettings is returned when a game cannot be set up or started as asked
+	ErrInvalidSettings = errors.New("invalid game settings")
+
+	// ErrInvalidSeat is returned when a player cannot join with the name or color asked for
+	ErrInvalidSeat = errors.New("invalid seat")
+
+	// ErrInvalidToken is returned when a seat token does not belong to the game
+	ErrInvalidToken = errors.New("invalid seat token")
+
+	// ErrGameStarted is returned when joining or starting a game that has started
+	ErrGameStarted = errors.New("game has already started")
+
+	// ErrGameNotStarted is returned when racing in a game that has not started
+	ErrGameNotStarted = errors.New("game has not started")
+
+	// ErrGameFull is returned when joining a game with every seat taken
+	ErrGameFull = errors.New("every seat is taken")
+
+	// ErrSeatsOpen is returned when starting a game before every seat is taken
+	ErrSeatsOpen = errors.New("not every seat is taken")
+
+	// ErrColorTaken is returned when joining with a color another seat has
+	ErrColorTaken = errors.New("color is taken")
+
+	// ErrRaceFinished is returned when acting in a race that is over
+	ErrRaceFinished = errors.New("race is finished")
+
+	// ErrNotYourTurn is returned when a seat acts while the race is waiting for other seats
+	ErrNotYourTurn = errors.New("not waiting for this seat")
+
+	// ErrIllegalAction is returned when the engine rejects an action
+	ErrIllegalAction = errors.New("illegal action")
+)
+
+// playerColors are the colors given to players who join without asking for one, in order
+var playerColors = []models.Color{models.Red, models.Blue, models.Green, models.Yellow, models.Orange, models.Black}
+
+// Settings describe the race a game is set up for
+type Settings struct {
+	// Track is the name of a built-in track
+	Track string `json:"track"`
+
+	// Laps is the number of laps to race, 0 uses the track's default
+	Laps int `json:"laps,omitempty"`
+
+	// Seats is the number of players the game waits for
+	Seats int `json:"seats"`
+
+	// Modules are the engine modules used, like "garage" and "sponsors"
+	Modules []string `json:"modules,omitempty"`
+
+	// Seed seeds every shuffle of the race, picked at random when the game is created without one
+	Seed int64 `json:"seed,omitempty"`
+
+	// Hints makes the game a teaching game, where every seat can ask for move suggestions
+	Hints bool `json:"hints,omitempty"`
+}
+
+// Seat is a player who has joined a game
+type Seat struct {
+	Name  string       `json:"name"`
+	Color models.Color `json:"color"`
+}
+
+// Table is what everyone can see of a game outside the race itself: its settings, who sits where and its status
+type Table struct {
+	ID        string    `json:"id"`
+	Settings  Settings  `json:"settings"`
+	Seats     []Seat    `json:"seats"`
+	Status    Status    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate checks that a race can be set up with the settings
+// Input: none
+// Returns: an error wrapping ErrInvalidSettings that describes the first problem found
+func (s Settings) Validate() error {
+	if _, err := tracks.GetTrack(s.Track); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSettings, err)
+	}
+	if s.Laps < 0 || s.Laps > MaxLaps {
+		return fmt.Errorf("%w: laps must be between 1 and %d, or 0 for the track's default", ErrInvalidSettings, MaxLaps)
+	}
+	if s.Seats < 1 || s.Seats > engine.MaxSeats {
+		return fmt.Errorf("%w: seats must be between 1 and %d", ErrInvalidSettings, engine.MaxSeats)
+	}
+
+	seen := make(map[string]bool, len(s.Modules))
+	for _, module := range s.Modules {
+		if module != engine.ModuleGarage && module != engine.ModuleSponsors {
+			return fmt.Errorf("%w: unknown module %q", ErrInvalidSettings, module)
+		}
+		if seen[module] {
+			return fmt.Errorf("%w: module %q is listed twice", ErrInvalidSettings, module)
+		}
+		seen[module] = true
+	}
+	return nil
+}
+
+// newSeat checks a player joining a table and picks their color
+// Input: table - the table being joined
+//
+//	seat - the player's name and the color asked for, empty for the first free player color
+//
+// Returns: the Seat, an error if the name is missing or the color is unknown or taken
+func newSeat(table Table, seat Seat) (Seat, error) {
+	seat.Name = strings.TrimSpace(seat.Name)
+	if seat.Name == "" {
+		return Seat{}, fmt.Errorf("%w: name is required", ErrInvalidSeat)
+	}
+
+	taken := make(map[models.Color]bool, len(table.Seats))
+	for _, other := range table.Seats {
+		taken[other.Color] = true
+	}
+
+	if seat.Color == "" {
+		for _, color := range playerColors {
+			if !taken[color] {
+				seat.Color = color
+				break
+			}
+		}
+		return seat, nil
+	}
+
+	color, err := models.ParseColor(string(seat.Color))
+	if err != nil {
+		return Seat{}, fmt.Errorf("%w: %v", ErrInvalidSeat, err)
+	}
+	if taken[color] {
+		return Seat{}, fmt.Errorf("%w: %s", ErrColorTaken, color)
+	}
+	seat.Color = color
+	return seat, nil
+}
+
+// newConfig builds the engine configuration for a table's race
+// Input: table - the table with every seat taken
+// Returns: the engine Config
+func newConfig(table Table) engine.Config {
+	track, _ := tracks.GetTrack(table.Settings.Track)
+	seats := make([]engine.Seat, len(table.Seats))
+	for i, seat := range table.Seats {
+		seats[i] = engine.Seat{Name: seat.Name, Color: seat.Color}
+	}
+	return engine.Config{
+		Track:   track,
+		Laps:    table.Settings.Laps,
+		Seats:   seats,
+		Seed:    table.Settings.Seed,
+		Modules: append([]string(nil), table.Settings.Modules...),
+	}
+}
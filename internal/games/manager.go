@@ -0,0 +1,444 @@
+package games
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"race-cars/internal/engine"
+)
+
+const (
+	// idBytes is the number of random bytes in a game ID
+	idBytes = 8
+
+	// tokenBytes is the number of random bytes in a seat token
+	tokenBytes = 16
+)
+
+// Manager keeps the games being set up and played
+// Seat tokens, handed out when a player joins, are the only proof of which seat a request acts for
+type Manager interface {
+	// Create sets up a game waiting for players
+	// Input: settings - the race to set up; a zero Seed is replaced by a random one
+	// Returns: the new game's Table, an error wrapping ErrInvalidSettings if the settings are invalid
+	Create(settings Settings) (Table, error)
+
+	// GetTable returns a game's settings, seats and status
+	// Input: id - the game ID
+	// Returns: the Table, ErrGameNotFound if there is no game with the ID
+	GetTable(id string) (Table, error)
+
+	// Join takes the next free seat of a game that has not started
+	// Input: id - the game ID
+	//	seat - the player's name and the color asked for, empty for the first free player color
+	// Returns: the seat index and its secret token, an error if the game is full, started or the seat is invalid
+	Join(id string, seat Seat) (int, string, error)
+
+	// Authorize finds the seat a token belongs to
+	// Input: id - the game ID
+	//	token - the seat token
+	// Returns: the seat index, ErrInvalidToken if the token is not one of the game's
+	Authorize(id string, token string) (int, error)
+
+	// Start begins the race once every seat is taken
+	// Input: id - the game ID
+	//	token - the token of any seat in the game
+	// Returns: an error if the token is invalid, the game has started or seats are open
+	Start(id string, token string) error
+
+	// Submit plays an action for the seat a token belongs to
+	// Input: id - the game ID
+	//	token - the seat token
+	//	action - the decision for the current phase
+	// Returns: an error if the token is invalid, the game is not waiting for the seat or the engine rejects the action
+	Submit(id string, token string, action engine.Action) error
+
+	// View reads a game's race while no action can change it
+	// Input: id - the game ID
+	//	read - called with the race, which must not be kept or changed after read returns
+	// Returns: ErrGameNotStarted before the race starts, otherwise the error read returns
+	View(id string, read func(game engine.Game) error) error
+
+	// GetGame returns a copy of a game's race that is safe to read at any time
+	// Input: id - the game ID
+	// Returns: the copy, an error before the race starts or during the Garage draft
+	GetGame(id string) (engine.Game, error)
+
+	// Subscribe asks to be told whenever a player joins, the race starts or an action is played
+	// Changes made while the subscriber is busy are merged into one signal, so a slow subscriber never holds up the game
+	// Input: id - the game ID
+	// Returns: the channel signalled after changes, a function ending the subscription, ErrGameNotFound if there is no game
+	Subscribe(id string) (<-chan struct{}, func(), error)
+}
+
+// entry is a game with everything only the manager sees
+type entry struct {
+	mu    sync.RWMutex
+	table Table
+
+	// tokens are the hashes of the seat tokens, by seat
+	tokens []string
+
+	game  engine.Game
+	moves []Move
+
+	// subscribers are signalled after every change; they have their own lock so signalling never waits on readers
+	subscribersMu sync.Mutex
+	subscribers   map[chan struct{}]bool
+}
+
+type manager struct {
+	mu    sync.RWMutex
+	games map[string]*entry
+
+	// store saves every change, nil to keep games in memory only
+	// Tables, seats and starts are saved before they are made; a move is applied to the race first, saved with the events
+	// it caused, and undone by replaying the saved moves if it cannot be saved
+	store Store
+}
+
+// NewManager creates a manager keeping games in memory
+// Input: none
+// Returns: a new Manager without games
+func NewManager() Manager {
+	return &manager{
+		games: make(map[string]*entry),
+	}
+}
+
+// LoadManager creates a manager saving games to a store, starting with every game saved in it
+// Races under way are rebuilt by replaying their moves, so they carry on where they were when the server stopped
+// A game that cannot be read or rebuilt is logged and left out, so one bad race does not take down every other game
+// Input: store - where games are saved
+// Returns: the Manager, an error if the saved games cannot be listed
+func LoadManager(store Store) (Manager, error) {
+	tables, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &manager{
+		games: make(map[string]*entry, len(tables)),
+		store: store,
+	}
+	for _, table := range tables {
+		record, err := store.Get(table.ID)
+		if err != nil {
+			log.Printf("Skipping game %s: error reading it: %v", table.ID, err)
+			continue
+		}
+		game, err := restore(record)
+		if err != nil {
+			log.Printf("Skipping game %s: error restoring it: %v", table.ID, err)
+			continue
+		}
+		m.games[table.ID] = game
+	}
+	return m, nil
+}
+
+// Create sets up a game waiting for players
+func (m *manager) Create(settings Settings) (Table, error) {
+	if err := settings.Validate(); err != nil {
+		return Table{}, err
+	}
+	settings.Modules = append([]string(nil), settings.Modules...)
+	if settings.Seed == 0 {
+		seed, err := randomSeed()
+		if err != nil {
+			return Table{}, err
+		}
+		settings.Seed = seed
+	}
+
+	id, err := randomHex(idBytes)
+	if err != nil {
+		return Table{}, err
+	}
+	table := Table{
+		ID:        id,
+		Settings:  settings,
+		Seats:     make([]Seat, 0, settings.Seats),
+		Status:    StatusWaiting,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if m.store != nil {
+		if err := m.store.Create(table); err != nil {
+			return Table{}, err
+		}
+	}
+
+	m.mu.Lock()
+	m.games[id] = &entry{table: table, subscribers: make(map[chan struct{}]bool)}
+	m.mu.Unlock()
+	return copyTable(table), nil
+}
+
+// GetTable returns a game's settings, seats and status
+func (m *manager) GetTable(id string) (Table, error) {
+	game, err := m.find(id)
+	if err != nil {
+		return Table{}, err
+	}
+	game.mu.RLock()
+	defer game.mu.RUnlock()
+	return copyTable(game.table), nil
+}
+
+// Join takes the next free seat of a game that has not started
+func (m *manager) Join(id string, seat Seat) (int, string, error) {
+	game, err := m.find(id)
+	if err != nil {
+		return 0, "", err
+	}
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.table.Status != StatusWaiting {
+		return 0, "", ErrGameStarted
+	}
+	if len(game.table.Seats) >= game.table.Settings.Seats {
+		return 0, "", ErrGameFull
+	}
+	seat, err = newSeat(game.table, seat)
+	if err != nil {
+		return 0, "", err
+	}
+	token, err := randomHex(tokenBytes)
+	if err != nil {
+		return 0, "", err
+	}
+	index := len(game.table.Seats)
+	if m.store != nil {
+		if err := m.store.AddSeat(id, index, seat, hashToken(token)); err != nil {
+			return 0, "", err
+		}
+	}
+
+	game.table.Seats = append(game.table.Seats, seat)
+	game.tokens = append(game.tokens, hashToken(token))
+	game.notify()
+	return index, token, nil
+}
+
+// Authorize finds the seat a token belongs to
+func (m *manager) Authorize(id string, token string) (int, error) {
+	game, err := m.find(id)
+	if err != nil {
+		return 0, err
+	}
+	game.mu.RLock()
+	defer game.mu.RUnlock()
+	return game.authorize(token)
+}
+
+// Start begins the race once every seat is taken
+func (m *manager) Start(id string, token string) error {
+	game, err := m.find(id)
+	if err != nil {
+		return err
+	}
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if _, err := game.authorize(token); err != nil {
+		return err
+	}
+	if game.table.Status != StatusWaiting {
+		return ErrGameStarted
+	}
+	if len(game.table.Seats) < game.table.Settings.Seats {
+		return fmt.Errorf("%w: waiting for %d more", ErrSeatsOpen, game.table.Settings.Seats-len(game.table.Seats))
+	}
+
+	race, err := replay(game.table, nil)
+	if err != nil {
+		return err
+	}
+	if m.store != nil {
+		if err := m.store.Start(id, race.GetEvents()); err != nil {
+			return err
+		}
+	}
+	game.game = race
+	game.table.Status = StatusRacing
+	game.notify()
+	return nil
+}
+
+// Submit plays an action for the seat a token belongs to
+func (m *manager) Submit(id string, token string, action engine.Action) error {
+	game, err := m.find(id)
+	if err != nil {
+		return err
+	}
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	seat, err := game.authorize(token)
+	if err != nil {
+		return err
+	}
+	switch {
+	case game.game == nil:
+		return ErrGameNotStarted
+	case game.game.IsFinished():
+		return ErrRaceFinished
+	case !game.game.IsWaitingFor(seat):
+		return ErrNotYourTurn
+	}
+
+	played := len(game.game.GetEvents())
+	if err := game.game.Submit(seat, action); err != nil {
+		return fmt.Errorf("%w: %v", ErrIllegalAction, err)
+	}
+	move := Move{Sequence: len(game.moves) + 1, Seat: seat, Action: action}
+	status := StatusRacing
+	if game.game.IsFinished() {
+		status = StatusFinished
+	}
+
+	if m.store != nil {
+		if err := m.store.AddMove(id, move, game.game.GetEvents()[played:], status); err != nil {
+			// The race has already moved on, so it is rebuilt from the moves that were saved
+			race, replayErr := replay(game.table, game.moves)
+			if replayErr != nil {
+				return fmt.Errorf("%v; %w", err, replayErr)
+			}
+			game.game = race
+			return err
+		}
+	}
+	game.moves = append(game.moves, move)
+	game.table.Status = status
+	game.notify()
+	return nil
+}
+
+// View reads a game's race while no action can change it
+func (m *manager) View(id string, read func(game engine.Game) error) error {
+	game, err := m.find(id)
+	if err != nil {
+		return err
+	}
+	game.mu.RLock()
+	defer game.mu.RUnlock()
+
+	if game.game == nil {
+		return ErrGameNotStarted
+	}
+	return read(game.game)
+}
+
+// GetGame returns a copy of a game's race that is safe to read at any time
+// The copy is seeded with the game's seed, so the shuffles played on it are not the race's
+func (m *manager) GetGame(id string) (engine.Game, error) {
+	var clone engine.Game
+	err := m.View(id, func(game engine.Game) error {
+		var err error
+		clone, err = game.Clone(game.GetConfig().Seed)
+		return err
+	})
+	return clone, err
+}
+
+// Subscribe asks to be told whenever a game changes
+func (m *manager) Subscribe(id string) (<-chan struct{}, func(), error) {
+	game, err := m.find(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := make(chan struct{}, 1)
+	game.subscribersMu.Lock()
+	game.subscribers[changes] = true
+	game.subscribersMu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			game.subscribersMu.Lock()
+			delete(game.subscribers, changes)
+			game.subscribersMu.Unlock()
+		})
+	}
+	return changes, unsubscribe, nil
+}
+
+// find looks up a game
+// Input: id - the game ID
+// Returns: the game, ErrGameNotFound if there is no game with the ID
+func (m *manager) find(id string) (*entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	game, ok := m.games[id]
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+	return game, nil
+}
+
+// authorize finds the seat a token belongs to; the caller holds the game's lock
+// Input: token - the seat token
+// Returns: the seat index, ErrInvalidToken if the token is not one of the game's
+func (e *entry) authorize(token string) (int, error) {
+	hash := hashToken(token)
+	for seat, seatHash := range e.tokens {
+		if token != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(seatHash)) == 1 {
+			return seat, nil
+		}
+	}
+	return 0, ErrInvalidToken
+}
+
+// notify signals every subscriber that the game changed, skipping those with a signal still waiting
+// Input: none
+// Returns: none
+func (e *entry) notify() {
+	e.subscribersMu.Lock()
+	defer e.subscribersMu.Unlock()
+	for changes := range e.subscribers {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// copyTable copies a table so the caller cannot change the manager's
+// Input: table - the table
+// Returns: the copy
+func copyTable(table Table) Table {
+	table.Seats = append(make([]Seat, 0, len(table.Seats)), table.Seats...)
+	table.Settings.Modules = append([]string(nil), table.Settings.Modules...)
+	return table
+}
+
+// randomHex returns random bytes as hex, for IDs and tokens nobody can guess
+// Input: size - the number of random bytes
+// Returns: the hex string, an error if the system has no randomness
+func randomHex(size int) (string, error) {
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("error generating random ID: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// randomSeed returns a positive random seed for a race
+// Input: none
+// Returns: the seed, an error if the system has no randomness
+func randomSeed() (int64, error) {
+	var bytes [8]byte
+	if _, err := rand.Read(bytes[:]); err != nil {
+		return 0, fmt.Errorf("error generating seed: %w", err)
+	}
+	return max(int64(binary.BigEndian.Uint64(bytes[:])>>1), 1), nil
+}
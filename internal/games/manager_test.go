@@ -0,0 +1,277 @@
+package games
+
+import (
+	"errors"
+	"testing"
+
+	"race-cars/internal/ai"
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+// Helper function to create a game and fill its seats, returning the game ID and seat tokens
+func createFullGame(t *testing.T, manager Manager, settings Settings) (string, []string) {
+	table, err := manager.Create(settings)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tokens := make([]string, settings.Seats)
+	for i := range tokens {
+		seat, token, err := manager.Join(table.ID, Seat{Name: "Player"})
+		if err != nil {
+			t.Fatalf("Join() error = %v", err)
+		}
+		if seat != i {
+			t.Fatalf("Join() seat = %d, want %d", seat, i)
+		}
+		tokens[i] = token
+	}
+	return table.ID, tokens
+}
+
+func TestSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		wantErr  bool
+	}{
+		{"Valid", Settings{Track: "USA", Laps: 1, Seats: 2}, false},
+		{"Track default laps", Settings{Track: "Italy", Seats: 6, Modules: []string{"garage", "sponsors"}}, false},
+		{"Unknown track", Settings{Track: "Monaco", Seats: 2}, true},
+		{"Negative laps", Settings{Track: "USA", Laps: -1, Seats: 2}, true},
+		{"Too many laps", Settings{Track: "USA", Laps: MaxLaps + 1, Seats: 2}, true},
+		{"No seats", Settings{Track: "USA", Seats: 0}, true},
+		{"Too many seats", Settings{Track: "USA", Seats: engine.MaxSeats + 1}, true},
+		{"Unknown module", Settings{Track: "USA", Seats: 2, Modules: []string{"weather"}}, true},
+		{"Module twice", Settings{Track: "USA", Seats: 2, Modules: []string{"garage", "garage"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSettings) {
+				t.Errorf("Validate() error = %v, want it to wrap ErrInvalidSettings", err)
+			}
+		})
+	}
+}
+
+func TestManager_Create(t *testing.T) {
+	manager := NewManager()
+	table, err := manager.Create(Settings{Track: "USA", Seats: 2})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if table.ID == "" || table.Status != StatusWaiting || len(table.Seats) != 0 {
+		t.Errorf("Create() = %+v, want a waiting game with an ID and no seats", table)
+	}
+	if table.Settings.Seed <= 0 {
+		t.Errorf("Seed = %d, want a random positive seed", table.Settings.Seed)
+	}
+
+	if _, err := manager.Create(Settings{Track: "USA", Seats: 0}); !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Create() with no seats error = %v, want ErrInvalidSettings", err)
+	}
+	if _, err := manager.GetTable("missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("GetTable() error = %v, want ErrGameNotFound", err)
+	}
+}
+
+func TestManager_Join(t *testing.T) {
+	manager := NewManager()
+	table, _ := manager.Create(Settings{Track: "USA", Seats: 3})
+
+	tests := []struct {
+		name      string
+		seat      Seat
+		wantColor models.Color
+		wantErr   error
+	}{
+		{"First free color", Seat{Name: "Ada"}, models.Red, nil},
+		{"Missing name", Seat{Name: " ", Color: models.Blue}, "", ErrInvalidSeat},
+		{"Unknown color", Seat{Name: "Brian", Color: "Purple"}, "", ErrInvalidSeat},
+		{"Taken color", Seat{Name: "Brian", Color: "red"}, "", ErrColorTaken},
+		{"Color asked for", Seat{Name: "Brian", Color: "gray"}, models.Gray, nil},
+		{"Next free color", Seat{Name: "Cleo"}, models.Blue, nil},
+		{"Full game", Seat{Name: "Dan"}, "", ErrGameFull},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seat, token, err := manager.Join(table.ID, tt.seat)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Join() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if token == "" {
+				t.Error("Join() token is empty")
+			}
+			joined, _ := manager.GetTable(table.ID)
+			if got := joined.Seats[seat].Color; got != tt.wantColor {
+				t.Errorf("Color = %q, want %q", got, tt.wantColor)
+			}
+		})
+	}
+}
+
+func TestManager_Start(t *testing.T) {
+	manager := NewManager()
+	table, _ := manager.Create(Settings{Track: "USA", Laps: 1, Seats: 2})
+	_, token, _ := manager.Join(table.ID, Seat{Name: "Ada"})
+
+	if err := manager.Start(table.ID, "wrong"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Start() with wrong token error = %v, want ErrInvalidToken", err)
+	}
+	if err := manager.Start(table.ID, token); !errors.Is(err, ErrSeatsOpen) {
+		t.Errorf("Start() with an open seat error = %v, want ErrSeatsOpen", err)
+	}
+	if _, err := manager.GetGame(table.ID); !errors.Is(err, ErrGameNotStarted) {
+		t.Errorf("GetGame() before start error = %v, want ErrGameNotStarted", err)
+	}
+
+	manager.Join(table.ID, Seat{Name: "Brian"})
+	if err := manager.Start(table.ID, token); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := manager.Start(table.ID, token); !errors.Is(err, ErrGameStarted) {
+		t.Errorf("Start() twice error = %v, want ErrGameStarted", err)
+	}
+	if _, _, err := manager.Join(table.ID, Seat{Name: "Cleo"}); !errors.Is(err, ErrGameStarted) {
+		t.Errorf("Join() after start error = %v, want ErrGameStarted", err)
+	}
+
+	started, _ := manager.GetTable(table.ID)
+	if started.Status != StatusRacing {
+		t.Errorf("Status = %q, want %q", started.Status, StatusRacing)
+	}
+	game, err := manager.GetGame(table.ID)
+	if err != nil {
+		t.Fatalf("GetGame() error = %v", err)
+	}
+	if players := game.GetPlayers(); len(players) != 2 || players[0].GetName() != "Ada" {
+		t.Errorf("players = %d, first %q, want Ada and Brian", len(players), players[0].GetName())
+	}
+}
+
+func TestManager_Submit(t *testing.T) {
+	manager := NewManager()
+	id, tokens := createFullGame(t, manager, Settings{Track: "USA", Laps: 1, Seats: 2, Seed: 5})
+
+	plan := engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{0}}
+	if err := manager.Submit(id, tokens[0], plan); !errors.Is(err, ErrGameNotStarted) {
+		t.Errorf("Submit() before start error = %v, want ErrGameNotStarted", err)
+	}
+	manager.Start(id, tokens[0])
+
+	tests := []struct {
+		name    string
+		token   string
+		action  engine.Action
+		wantErr error
+	}{
+		{"Wrong token", "wrong", plan, ErrInvalidToken},
+		{"Wrong phase", tokens[0], engine.Action{Type: engine.ActionDiscard}, ErrIllegalAction},
+		{"Too many cards", tokens[0], engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{0, 1}}, ErrIllegalAction},
+		{"Legal plan", tokens[0], plan, nil},
+		{"Planned already", tokens[0], plan, ErrNotYourTurn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := manager.Submit(id, tt.token, tt.action); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Submit() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_PlaysToTheFinish(t *testing.T) {
+	manager := NewManager()
+	id, tokens := createFullGame(t, manager, Settings{Track: "USA", Laps: 1, Seats: 2, Seed: 7})
+	manager.Start(id, tokens[0])
+
+	bot, err := ai.NewBot("normal", 1)
+	if err != nil {
+		t.Fatalf("NewBot() error = %v", err)
+	}
+	for turn := 0; turn < 1000; turn++ {
+		var seat int
+		var action engine.Action
+		finished := false
+		err := manager.View(id, func(game engine.Game) error {
+			finished = game.IsFinished()
+			if finished {
+				return nil
+			}
+			for seat = range tokens {
+				if game.IsWaitingFor(seat) {
+					break
+				}
+			}
+			var err error
+			action, err = bot.Choose(game, seat)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("View() error = %v", err)
+		}
+		if finished {
+			break
+		}
+		if err := manager.Submit(id, tokens[seat], action); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	table, _ := manager.GetTable(id)
+	if table.Status != StatusFinished {
+		t.Fatalf("Status = %q, want %q", table.Status, StatusFinished)
+	}
+	if err := manager.Submit(id, tokens[0], engine.Action{Type: engine.ActionPlan}); !errors.Is(err, ErrRaceFinished) {
+		t.Errorf("Submit() after the finish error = %v, want ErrRaceFinished", err)
+	}
+}
+
+func TestManager_Subscribe(t *testing.T) {
+	manager := NewManager()
+	table, _ := manager.Create(Settings{Track: "USA", Laps: 1, Seats: 3})
+	if _, _, err := manager.Subscribe("missing"); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("Subscribe() error = %v, want ErrGameNotFound", err)
+	}
+
+	changes, unsubscribe, err := manager.Subscribe(table.ID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	// Two changes before the subscriber looks are merged into one signal
+	manager.Join(table.ID, Seat{Name: "Ada"})
+	manager.Join(table.ID, Seat{Name: "Brian"})
+	select {
+	case <-changes:
+	default:
+		t.Fatal("no signal after joining")
+	}
+	select {
+	case <-changes:
+		t.Fatal("second signal for changes made before the first was read")
+	default:
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if _, _, err := manager.Join(table.ID, Seat{Name: "Cleo"}); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	select {
+	case <-changes:
+		t.Error("signal after unsubscribing")
+	default:
+	}
+}
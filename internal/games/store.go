@@ -0,0 +1,147 @@
+package games
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"race-cars/internal/engine"
+)
+
+// Store keeps games so they outlive the server
+// The manager saves new games, seats and starts before making them; a move is played first, then saved with the events
+// it caused, and a move that cannot be saved is undone by replaying the saved ones
+// A race is saved as its settings, seed included, and the moves played, and is rebuilt by replaying them
+type Store interface {
+	// Create saves a new game waiting for players
+	// Input: table - the game's Table
+	// Returns: an error if the game cannot be saved
+	Create(table Table) error
+
+	// AddSeat saves a player joining a game
+	// Input: id - the game ID
+	//	index - the seat index
+	//	seat - the player's name and color
+	//	tokenHash - the hash of the seat token; tokens themselves are never saved
+	// Returns: an error if the seat cannot be saved
+	AddSeat(id string, index int, seat Seat, tokenHash string) error
+
+	// Start saves a race starting and the events it started with, all or nothing
+	// Input: id - the game ID
+	//	events - the events of the new race
+	// Returns: an error if the start cannot be saved
+	Start(id string, events []engine.Event) error
+
+	// AddMove saves a move, the events it caused and the game's status after it, all or nothing
+	// Input: id - the game ID
+	//	move - the move
+	//	events - the events the move caused
+	//	status - the game's status after the move
+	// Returns: an error if the move cannot be saved
+	AddMove(id string, move Move, events []engine.Event, status Status) error
+
+	// List returns every saved game, oldest first
+	// Input: none
+	// Returns: the Tables, an error if they cannot be read
+	List() ([]Table, error)
+
+	// Get returns everything saved about a game
+	// Input: id - the game ID
+	// Returns: the Record, ErrGameNotFound if there is no game with the ID
+	Get(id string) (Record, error)
+}
+
+// Move is an action played for a seat
+type Move struct {
+	// Sequence numbers the moves of a game from 1
+	Sequence int           `json:"sequence"`
+	Seat     int           `json:"seat"`
+	Action   engine.Action `json:"action"`
+}
+
+// Record is everything saved about a game
+type Record struct {
+	Table Table
+
+	// TokenHashes are the hashes of the seat tokens, by seat
+	TokenHashes []string
+
+	// Moves are the moves played, in order
+	Moves []Move
+
+	// Events are the race's events, in order
+	Events []engine.Event
+}
+
+// hashToken hashes a seat token, so neither the manager nor a store keeps tokens that could be used if leaked
+// Input: token - the seat token
+// Returns: the SHA-256 hash in hex
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// replay plays a table's race from the start
+// Input: table - the table with every seat taken
+//
+//	moves - the moves to play, in order
+//
+// Returns: the race, an error if it cannot be set up or a move is rejected
+func replay(table Table, moves []Move) (engine.Game, error) {
+	race, err := engine.NewGame(newConfig(table))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSettings, err)
+	}
+	for _, move := range moves {
+		if err := race.Submit(move.Seat, move.Action); err != nil {
+			return nil, fmt.Errorf("error replaying move %d: %w", move.Sequence, err)
+		}
+	}
+	return race, nil
+}
+
+// restore rebuilds a saved game, replaying its race when it has started
+// Input: record - everything saved about the game
+// Returns: the game, an error if the race does not replay to the events saved with it
+func restore(record Record) (*entry, error) {
+	if len(record.TokenHashes) != len(record.Table.Seats) {
+		return nil, errors.New("seats and tokens do not match")
+	}
+	game := &entry{
+		table:       record.Table,
+		tokens:      record.TokenHashes,
+		moves:       record.Moves,
+		subscribers: make(map[chan struct{}]bool),
+	}
+	if game.table.Status == StatusWaiting {
+		return game, nil
+	}
+
+	race, err := replay(game.table, game.moves)
+	if err != nil {
+		return nil, err
+	}
+
+	// A race that replays differently, after a change to the engine for example, would not be the race that was played
+	replayed, err := json.Marshal(race.GetEvents())
+	if err != nil {
+		return nil, err
+	}
+	saved, err := json.Marshal(record.Events)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(replayed, saved) {
+		return nil, errors.New("race does not replay to the events saved with it")
+	}
+
+	game.game = race
+	game.table.Status = StatusRacing
+	if race.IsFinished() {
+		game.table.Status = StatusFinished
+	}
+	return game, nil
+}
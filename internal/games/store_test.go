@@ -0,0 +1,211 @@
+package games
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/gamestest"
+)
+
+// testStore keeps saved games in memory and can be told to fail
+type testStore struct {
+	records map[string]*Record
+	ids     []string
+	fail    error
+}
+
+// Helper function to create an empty test store
+func newTestStore() *testStore {
+	return &testStore{records: make(map[string]*Record)}
+}
+
+func (s *testStore) Create(table Table) error {
+	if s.fail != nil {
+		return s.fail
+	}
+	s.records[table.ID] = &Record{Table: copyTable(table)}
+	s.ids = append(s.ids, table.ID)
+	return nil
+}
+
+func (s *testStore) AddSeat(id string, index int, seat Seat, tokenHash string) error {
+	if s.fail != nil {
+		return s.fail
+	}
+	record := s.records[id]
+	record.Table.Seats = append(record.Table.Seats, seat)
+	record.TokenHashes = append(record.TokenHashes, tokenHash)
+	return nil
+}
+
+func (s *testStore) Start(id string, events []engine.Event) error {
+	if s.fail != nil {
+		return s.fail
+	}
+	record := s.records[id]
+	record.Table.Status = StatusRacing
+	record.Events = append(record.Events, events...)
+	return nil
+}
+
+func (s *testStore) AddMove(id string, move Move, events []engine.Event, status Status) error {
+	if s.fail != nil {
+		return s.fail
+	}
+	record := s.records[id]
+	record.Moves = append(record.Moves, move)
+	record.Events = append(record.Events, events...)
+	record.Table.Status = status
+	return nil
+}
+
+func (s *testStore) List() ([]Table, error) {
+	tables := make([]Table, 0, len(s.ids))
+	for _, id := range s.ids {
+		tables = append(tables, copyTable(s.records[id].Table))
+	}
+	return tables, nil
+}
+
+func (s *testStore) Get(id string) (Record, error) {
+	record, ok := s.records[id]
+	if !ok {
+		return Record{}, ErrGameNotFound
+	}
+	return Record{
+		Table:       copyTable(record.Table),
+		TokenHashes: append([]string(nil), record.TokenHashes...),
+		Moves:       append([]Move(nil), record.Moves...),
+		Events:      append([]engine.Event(nil), record.Events...),
+	}, nil
+}
+
+func TestLoadManager_RestoresRaces(t *testing.T) {
+	store := newTestStore()
+	manager, err := LoadManager(store)
+	if err != nil {
+		t.Fatalf("LoadManager() error = %v", err)
+	}
+	waiting, _ := manager.Create(Settings{Track: "USA", Seats: 2})
+	manager.Join(waiting.ID, Seat{Name: "Ada"})
+	id, tokens := createFullGame(t, manager, Settings{Track: "USA", Laps: 1, Seats: 2, Modules: []string{"garage"}})
+	manager.Start(id, tokens[0])
+	gamestest.PlayMoves(t, manager, id, tokens, 10)
+
+	for _, hash := range store.records[id].TokenHashes {
+		for _, token := range tokens {
+			if hash == token {
+				t.Fatal("store keeps a seat token rather than its hash")
+			}
+		}
+	}
+
+	restarted, err := LoadManager(store)
+	if err != nil {
+		t.Fatalf("LoadManager() after restart error = %v", err)
+	}
+	if table, err := restarted.GetTable(waiting.ID); err != nil || table.Status != StatusWaiting || len(table.Seats) != 1 {
+		t.Errorf("waiting game after restart = %+v, %v, want it waiting with one seat", table, err)
+	}
+
+	before, after := gamestest.EventsOf(t, manager, id), gamestest.EventsOf(t, restarted, id)
+	if !reflect.DeepEqual(after, before) {
+		t.Fatalf("race after restart has %d events, want the %d played", len(after), len(before))
+	}
+	if seat, err := restarted.Authorize(id, tokens[1]); err != nil || seat != 1 {
+		t.Errorf("Authorize() after restart = %d, %v, want seat 1", seat, err)
+	}
+
+	// Both managers play on from the same saved race in the same way
+	gamestest.PlayMoves(t, restarted, id, tokens, 5)
+	gamestest.PlayMoves(t, manager, id, tokens, 5)
+	if got, want := gamestest.EventsOf(t, restarted, id), gamestest.EventsOf(t, manager, id); !reflect.DeepEqual(got, want) {
+		t.Errorf("restarted race has %d events, want %d", len(got), len(want))
+	}
+}
+
+func TestManager_SubmitNotSaved(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves int
+	}{
+		{name: "First move of the race", moves: 0},
+		{name: "Move after saved moves", moves: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore()
+			manager, _ := LoadManager(store)
+			id, tokens := createFullGame(t, manager, Settings{Track: "USA", Laps: 1, Seats: 2, Seed: 5})
+			manager.Start(id, tokens[0])
+			gamestest.PlayMoves(t, manager, id, tokens, tt.moves)
+			saved := gamestest.EventsOf(t, manager, id)
+
+			store.fail = errors.New("database is down")
+			var seat int
+			var action engine.Action
+			manager.View(id, func(game engine.Game) error {
+				for seat = range tokens {
+					if game.IsWaitingFor(seat) {
+						break
+					}
+				}
+				action = game.LegalActions(seat)[0]
+				return nil
+			})
+			if err := manager.Submit(id, tokens[seat], action); !errors.Is(err, store.fail) {
+				t.Fatalf("Submit() error = %v, want the store's", err)
+			}
+
+			// The race is back where the last saved move left it, so the move can be played again
+			if got := gamestest.EventsOf(t, manager, id); !reflect.DeepEqual(got, saved) || !reflect.DeepEqual(got, store.records[id].Events) {
+				t.Fatalf("race has %d events after a move that was not saved, want the %d saved", len(got), len(saved))
+			}
+			manager.View(id, func(game engine.Game) error {
+				if !game.IsWaitingFor(seat) {
+					t.Errorf("IsWaitingFor(%d) = false after the move was undone", seat)
+				}
+				return nil
+			})
+
+			store.fail = nil
+			if err := manager.Submit(id, tokens[seat], action); err != nil {
+				t.Fatalf("Submit() again error = %v", err)
+			}
+			if len(store.records[id].Moves) != tt.moves+1 {
+				t.Errorf("saved moves = %d, want %d", len(store.records[id].Moves), tt.moves+1)
+			}
+		})
+	}
+}
+
+func TestLoadManager_SkipsChangedRaces(t *testing.T) {
+	store := newTestStore()
+	manager, _ := LoadManager(store)
+	settings := Settings{Track: "USA", Laps: 1, Seats: 2, Seed: 5}
+	badID, badTokens := createFullGame(t, manager, settings)
+	manager.Start(badID, badTokens[0])
+	gamestest.PlayMoves(t, manager, badID, badTokens, 2)
+	goodID, goodTokens := createFullGame(t, manager, settings)
+	manager.Start(goodID, goodTokens[0])
+	gamestest.PlayMoves(t, manager, goodID, goodTokens, 2)
+
+	store.records[badID].Events[0].Value++
+	loaded, err := LoadManager(store)
+	if err != nil {
+		t.Fatalf("LoadManager() error = %v", err)
+	}
+	if _, err := loaded.GetTable(badID); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("GetTable() of the changed race error = %v, want %v", err, ErrGameNotFound)
+	}
+	table, err := loaded.GetTable(goodID)
+	if err != nil {
+		t.Fatalf("GetTable() of the other race error = %v", err)
+	}
+	if table.Status != StatusRacing {
+		t.Errorf("Status = %s, want %s", table.Status, StatusRacing)
+	}
+}
@@ -0,0 +1,98 @@
+// Package gamestest holds the test helpers shared by the packages that keep, save and serve games
+// It only depends on the engine and models, so the games package's own tests can use it too
+package gamestest
+
+import (
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+// Races reads and plays races by game ID, like a games.Manager
+type Races interface {
+	// View reads a game's race while no action can change it
+	// Input: id - the game ID
+	//	read - called with the race
+	// Returns: the error read returns, or an error if there is no race
+	View(id string, read func(game engine.Game) error) error
+
+	// Submit plays an action for the seat a token belongs to
+	// Input: id - the game ID
+	//	token - the seat token
+	//	action - the decision for the current phase
+	// Returns: an error if the action cannot be played
+	Submit(id string, token string, action engine.Action) error
+}
+
+// PlayMoves plays the first legal action of every seat the race waits for, a number of times
+// Input: t - the test, failed if a move cannot be played
+//
+//	races - where the race is played
+//	id - the game ID
+//	tokens - the seat tokens, by seat
+//	count - the number of moves to play
+//
+// Returns: none
+func PlayMoves(t testing.TB, races Races, id string, tokens []string, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		var seat int
+		var action engine.Action
+		if err := races.View(id, func(game engine.Game) error {
+			for seat = range tokens {
+				if game.IsWaitingFor(seat) {
+					break
+				}
+			}
+			action = game.LegalActions(seat)[0]
+			return nil
+		}); err != nil {
+			t.Fatalf("View() error = %v", err)
+		}
+		if err := races.Submit(id, tokens[seat], action); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+}
+
+// EventsOf reads every event of a game's race
+// Input: t - the test, failed if the race cannot be read
+//
+//	races - where the race is played
+//	id - the game ID
+//
+// Returns: the events of the race
+func EventsOf(t testing.TB, races Races, id string) []engine.Event {
+	t.Helper()
+	var events []engine.Event
+	if err := races.View(id, func(game engine.Game) error {
+		events = game.GetEvents()
+		return nil
+	}); err != nil {
+		t.Fatalf("View() error = %v", err)
+	}
+	return events
+}
+
+// CatalogCar creates a valid catalog car, a Ferrari F40 under the name given
+// Input: name - the car's name
+//
+//	color - the car color it races in
+//
+// Returns: the CatalogCar, without an ID or timestamps
+func CatalogCar(name string, color models.Color) models.CatalogCar {
+	return models.CatalogCar{
+		Name:       name,
+		Brand:      "Ferrari",
+		Model:      "F40",
+		Year:       1987,
+		EngineSize: 2.9,
+		Horsepower: 471,
+		TopSpeed:   324,
+		Weight:     1100,
+		Category:   "Supercar",
+		ImageURL:   "https://example.com/ferrari-f40.jpg",
+		Color:      color,
+	}
+}
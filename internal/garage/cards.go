@@ -0,0 +1,74 @@
+package garage
+
+import (
+	"fmt"
+
+	"race-cars/internal/models"
+)
+
+// UpgradeCopies is the number of copies of each upgrade in the upgrade deck
+const UpgradeCopies = 3
+
+// Upgrade describes an upgrade card of the Garage module
+type Upgrade struct {
+	Name  string
+	Speed int
+	Icons map[models.Icon]int
+}
+
+// upgrades is the catalog of Garage upgrade cards
+var upgrades = []Upgrade{
+	{Name: "Body", Speed: 2, Icons: map[models.Icon]int{IconReduceStress: 1}},
+	{Name: "Brakes", Speed: 1, Icons: map[models.Icon]int{IconDirectPlay: 1}},
+	{Name: "Cooling System", Speed: 1, Icons: map[models.Icon]int{models.IconCooling: 1, IconSuperCool: 1}},
+	{Name: "Gas Pedal", Speed: 3, Icons: map[models.Icon]int{IconDirectPlay: 1, IconHeatControl: 1}},
+	{Name: "R.P.M.", Speed: 2, Icons: map[models.Icon]int{IconRefresh: 1}},
+	{Name: "Suspension", Speed: 2, Icons: map[models.Icon]int{IconSalvage: 2}},
+	{Name: "Tires", Speed: 3, Icons: map[models.Icon]int{IconScrap: 2}},
+	{Name: "Turbocharger", Speed: 6, Icons: map[models.Icon]int{IconHeatControl: 1}},
+	{Name: "Fuel", Speed: 2, Icons: map[models.Icon]int{IconSuperCool: 2}},
+}
+
+// GetUpgrades returns the catalog of upgrade cards
+// Input: none
+// Returns: a copy of every upgrade in the catalog
+func GetUpgrades() []Upgrade {
+	result := make([]Upgrade, len(upgrades))
+	copy(result, upgrades)
+	return result
+}
+
+// NewUpgradeCard creates a card from the upgrade catalog
+// Input: name - the name of the upgrade
+// Returns: a new Card, an error if the upgrade does not exist
+func NewUpgradeCard(name string) (models.Card, error) {
+	for _, upgrade := range upgrades {
+		if upgrade.Name == name {
+			return upgrade.NewCard(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown upgrade %q", name)
+}
+
+// NewCard creates a card for the upgrade
+// Upgrade cards can be played and discarded, but are not basic cards
+// Input: none
+// Returns: a new Card
+func (u Upgrade) NewCard() models.Card {
+	return models.NewCard(u.Name, u.Speed, u.Icons, true, true, false)
+}
+
+// NewUpgradeDeck creates the unshuffled upgrade cards the draft market is dealt from
+// Input: none
+// Returns: a slice of Cards with UpgradeCopies of every upgrade
+func NewUpgradeDeck() []models.Card {
+	cards := make([]models.Card, 0, len(upgrades)*UpgradeCopies)
+	for _, upgrade := range upgrades {
+		for i := 0; i < UpgradeCopies; i++ {
+			cards = append(cards, upgrade.NewCard())
+		}
+	}
+
+	return cards
+}
@@ -0,0 +1,68 @@
+package garage
+
+import (
+	"testing"
+)
+
+func TestNewUpgradeCard(t *testing.T) {
+	tests := []struct {
+		name        string
+		upgrade     string
+		wantErr     bool
+		expectSpeed int
+	}{
+		{
+			name:        "Known upgrade",
+			upgrade:     "Turbocharger",
+			expectSpeed: 6,
+		},
+		{
+			name:    "Unknown upgrade",
+			upgrade: "Rocket",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := NewUpgradeCard(tt.upgrade)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewUpgradeCard() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if card.GetName() != tt.upgrade {
+				t.Errorf("GetName() = %s, want %s", card.GetName(), tt.upgrade)
+			}
+			if card.GetSpeed() != tt.expectSpeed {
+				t.Errorf("GetSpeed() = %d, want %d", card.GetSpeed(), tt.expectSpeed)
+			}
+			if !card.IsPlayable() || !card.IsDiscardable() || card.IsBasic() {
+				t.Error("Upgrade cards should be playable, discardable and not basic")
+			}
+		})
+	}
+}
+
+func TestGetUpgrades_CarryGarageIcons(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, upgrade := range GetUpgrades() {
+		for icon := range upgrade.Icons {
+			seen[icon.String()] = true
+		}
+	}
+
+	for _, name := range iconNames {
+		if !seen[name] {
+			t.Errorf("No upgrade carries the %s icon", name)
+		}
+	}
+}
+
+func TestNewUpgradeDeck(t *testing.T) {
+	cards := NewUpgradeDeck()
+	if len(cards) != len(GetUpgrades())*UpgradeCopies {
+		t.Errorf("NewUpgradeDeck() has %d cards, want %d", len(cards), len(GetUpgrades())*UpgradeCopies)
+	}
+}
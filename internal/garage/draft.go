@@ -0,0 +1,176 @@
+package garage
+
+import (
+	"errors"
+	"math/rand"
+
+	"race-cars/internal/models"
+)
+
+const (
+	// DraftRounds is the number of upgrade cards each player drafts before the race
+	DraftRounds = 3
+
+	// MarketExtra is the number of upgrades dealt to the market on top of one per player
+	MarketExtra = 3
+)
+
+// Draft is the pre-race draft of upgrade cards
+// Every round a market of upgrades is dealt and players pick one card each in snake order,
+// so the player picking last in one round picks first in the next
+type Draft interface {
+	// GetMarket returns the upgrades still available this round
+	// Returns: a copy of the market cards
+	GetMarket() []models.Card
+
+	// GetRound returns the current round, starting at 1
+	// Returns: the round number
+	GetRound() int
+
+	// GetCurrentPlayer returns the player whose turn it is to pick
+	// Returns: the player to pick, nil once the draft is complete
+	GetCurrentPlayer() models.Player
+
+	// Pick takes a card from the market for the current player and adds it to their deck
+	// Input: index - the index of the card in the market
+	// Returns: an error if the draft is complete or the index is invalid
+	Pick(index int) error
+
+	// IsComplete returns whether every player has made all their picks
+	// Returns: a boolean
+	IsComplete() bool
+}
+
+type draft struct {
+	players []models.Player
+	supply  []models.Card
+	market  []models.Card
+	order   []int
+	turn    int
+	round   int
+	rounds  int
+}
+
+// NewDraft creates a draft for the given players
+// The upgrade deck is shuffled with rng and the first market is dealt immediately
+// Input: players - the players taking part, in seating order
+//
+//	rounds - the number of picks per player
+//	rng - the random source used to shuffle the upgrade deck
+//
+// Returns: a new Draft, an error if there are not enough upgrades for every market
+func NewDraft(players []models.Player, rounds int, rng *rand.Rand) (Draft, error) {
+	if len(players) == 0 {
+		return nil, errors.New("draft needs at least one player")
+	}
+	if rounds < 1 {
+		return nil, errors.New("draft needs at least one round")
+	}
+	if rng == nil {
+		return nil, errors.New("draft needs a random source")
+	}
+
+	supply := NewUpgradeDeck()
+	if len(supply) < rounds*(len(players)+MarketExtra) {
+		return nil, errors.New("not enough upgrades for the draft")
+	}
+	rng.Shuffle(len(supply), func(i, j int) {
+		supply[i], supply[j] = supply[j], supply[i]
+	})
+
+	d := &draft{
+		players: players,
+		supply:  supply,
+		rounds:  rounds,
+	}
+	d.startRound()
+	return d, nil
+}
+
+// GetMarket returns the upgrades still available this round
+// Input: none
+// Returns: a copy of the market cards
+func (d *draft) GetMarket() []models.Card {
+	result := make([]models.Card, len(d.market))
+	copy(result, d.market)
+	return result
+}
+
+// GetRound returns the current round, starting at 1
+// Input: none
+// Returns: the round number
+func (d *draft) GetRound() int {
+	return d.round
+}
+
+// GetCurrentPlayer returns the player whose turn it is to pick
+// Input: none
+// Returns: the player to pick, nil once the draft is complete
+func (d *draft) GetCurrentPlayer() models.Player {
+	if d.IsComplete() {
+		return nil
+	}
+	return d.players[d.order[d.turn]]
+}
+
+// Pick takes a card from the market for the current player and adds it to their deck
+// The deck is shuffled once the player has made their last pick
+// Input: index - the index of the card in the market
+// Returns: an error if the draft is complete or the index is invalid
+func (d *draft) Pick(index int) error {
+	if d.IsComplete() {
+		return errors.New("draft is complete")
+	}
+	if index < 0 || index >= len(d.market) {
+		return errors.New("invalid market index")
+	}
+
+	player := d.GetCurrentPlayer()
+	card := d.market[index]
+	d.market = append(d.market[:index], d.market[index+1:]...)
+	player.GetDeck().AddCardsToTop([]models.Card{card})
+
+	d.turn++
+	if d.turn < len(d.order) {
+		return nil
+	}
+
+	if d.round == d.rounds {
+		d.round++
+		for _, p := range d.players {
+			p.GetDeck().Shuffle()
+		}
+		return nil
+	}
+
+	d.startRound()
+	return nil
+}
+
+// IsComplete returns whether every player has made all their picks
+// Input: none
+// Returns: a boolean
+func (d *draft) IsComplete() bool {
+	return d.round > d.rounds
+}
+
+// startRound deals a new market and sets the snake pick order for the next round
+// Input: none
+// Returns: none
+func (d *draft) startRound() {
+	d.round++
+	d.turn = 0
+
+	size := len(d.players) + MarketExtra
+	d.market = d.supply[:size:size]
+	d.supply = d.supply[size:]
+
+	d.order = make([]int, len(d.players))
+	for i := range d.order {
+		if d.round%2 == 1 {
+			d.order[i] = i
+		} else {
+			d.order[i] = len(d.players) - 1 - i
+		}
+	}
+}
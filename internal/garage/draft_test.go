@@ -0,0 +1,128 @@
+package garage
+
+import (
+	"math/rand"
+	"testing"
+
+	"race-cars/internal/models"
+)
+
+// Helper function to create draft players with empty decks
+func createDraftPlayers(n int) []models.Player {
+	players := make([]models.Player, n)
+	for i := range players {
+		players[i] = models.NewPlayer(string(rune('A'+i)), models.NewCar("red", 6), models.NewDiscardPile(), models.NewDeck([]models.Card{}), models.NewHand())
+	}
+	return players
+}
+
+// Helper function to count the cards left in a deck
+func countDeck(deck models.Deck) int {
+	count := 0
+	for deck.DrawCard() != nil {
+		count++
+	}
+	return count
+}
+
+func TestNewDraft(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int
+		rounds  int
+		rng     *rand.Rand
+		wantErr bool
+	}{
+		{name: "Two players", players: 2, rounds: DraftRounds, rng: rand.New(rand.NewSource(1))},
+		{name: "Full grid", players: 6, rounds: DraftRounds, rng: rand.New(rand.NewSource(1))},
+		{name: "No players", players: 0, rounds: DraftRounds, rng: rand.New(rand.NewSource(1)), wantErr: true},
+		{name: "No rounds", players: 2, rounds: 0, rng: rand.New(rand.NewSource(1)), wantErr: true},
+		{name: "No random source", players: 2, rounds: DraftRounds, wantErr: true},
+		{name: "Too many rounds", players: 6, rounds: 10, rng: rand.New(rand.NewSource(1)), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDraft(createDraftPlayers(tt.players), tt.rounds, tt.rng)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDraft() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(d.GetMarket()) != tt.players+MarketExtra {
+				t.Errorf("Market has %d cards, want %d", len(d.GetMarket()), tt.players+MarketExtra)
+			}
+			if d.GetRound() != 1 {
+				t.Errorf("GetRound() = %d, want 1", d.GetRound())
+			}
+		})
+	}
+}
+
+func TestDraft_SnakeOrder(t *testing.T) {
+	players := createDraftPlayers(3)
+	d, err := NewDraft(players, DraftRounds, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("NewDraft() error = %v", err)
+	}
+
+	expected := []string{"A", "B", "C", "C", "B", "A", "A", "B", "C"}
+	for i, name := range expected {
+		current := d.GetCurrentPlayer()
+		if current == nil || current.GetName() != name {
+			t.Fatalf("Pick %d: current player = %v, want %s", i, current, name)
+		}
+		if err := d.Pick(0); err != nil {
+			t.Fatalf("Pick() error = %v", err)
+		}
+	}
+
+	if !d.IsComplete() {
+		t.Error("Draft should be complete after every pick")
+	}
+	if d.GetCurrentPlayer() != nil {
+		t.Error("GetCurrentPlayer() should be nil after the draft")
+	}
+	if err := d.Pick(0); err == nil {
+		t.Error("Pick() after the draft should fail")
+	}
+
+	for _, player := range players {
+		if n := countDeck(player.GetDeck()); n != DraftRounds {
+			t.Errorf("%s has %d upgrades, want %d", player.GetName(), n, DraftRounds)
+		}
+	}
+}
+
+func TestDraft_Pick(t *testing.T) {
+	players := createDraftPlayers(2)
+	d, _ := NewDraft(players, 1, rand.New(rand.NewSource(7)))
+
+	market := d.GetMarket()
+	if err := d.Pick(len(market)); err == nil {
+		t.Error("Pick() with an invalid index should fail")
+	}
+
+	if err := d.Pick(2); err != nil {
+		t.Fatalf("Pick() error = %v", err)
+	}
+	if len(d.GetMarket()) != len(market)-1 {
+		t.Errorf("Market has %d cards, want %d", len(d.GetMarket()), len(market)-1)
+	}
+	if card := players[0].GetDeck().DrawCard(); card != market[2] {
+		t.Errorf("Picked card = %v, want %v", card, market[2])
+	}
+}
+
+func TestDraft_Deterministic(t *testing.T) {
+	first, _ := NewDraft(createDraftPlayers(4), DraftRounds, rand.New(rand.NewSource(99)))
+	second, _ := NewDraft(createDraftPlayers(4), DraftRounds, rand.New(rand.NewSource(99)))
+
+	a, b := first.GetMarket(), second.GetMarket()
+	for i := range a {
+		if a[i].GetName() != b[i].GetName() {
+			t.Errorf("Market[%d] = %s and %s, want the same card for the same seed", i, a[i].GetName(), b[i].GetName())
+		}
+	}
+}
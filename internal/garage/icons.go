@@ -0,0 +1,297 @@
+package garage
+
+import (
+	"errors"
+	"fmt"
+
+	"race-cars/internal/models"
+)
+
+// Icons added by the Garage module
+// They start at 100 so they never collide with the base game icons
+const (
+	IconScrap models.Icon = iota + 100
+	IconSalvage
+	IconRefresh
+	IconDirectPlay
+	IconReduceStress
+	IconSuperCool
+	IconHeatControl
+)
+
+var iconNames = map[models.Icon]string{
+	IconScrap:        "Scrap",
+	IconSalvage:      "Salvage",
+	IconRefresh:      "Refresh",
+	IconDirectPlay:   "Direct Play",
+	IconReduceStress: "Reduce Stress",
+	IconSuperCool:    "Super Cool",
+	IconHeatControl:  "Heat Control",
+}
+
+func init() {
+	for icon, name := range iconNames {
+		if err := models.RegisterIcon(icon, name); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterEffects adds the react-phase effects of the Garage icons to a registry
+// Input: registry - the icon registry of the game
+// Returns: an error if one of the icons is already registered
+func RegisterEffects(registry models.IconRegistry) error {
+	if registry == nil {
+		return errors.New("icon registry is nil")
+	}
+
+	for _, effect := range iconEffects() {
+		if err := registry.Register(effect); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// iconEffects returns the effects of the Garage icons
+// Input: none
+// Returns: a slice of IconEffects
+func iconEffects() []models.IconEffect {
+	return []models.IconEffect{
+		{Icon: IconDirectPlay, Order: 0, Mandatory: false, Resolve: resolveDirectPlay},
+		{Icon: IconReduceStress, Order: 5, Mandatory: false, Resolve: resolveReduceStress},
+		{Icon: IconSuperCool, Order: 15, Mandatory: false, Resolve: resolveSuperCool},
+		{Icon: IconHeatControl, Order: 25, Mandatory: true, Resolve: resolveHeatControl},
+		{Icon: IconScrap, Order: 30, Mandatory: true, Resolve: resolveScrap},
+		{Icon: IconSalvage, Order: 40, Mandatory: false, Resolve: resolveSalvage},
+		{Icon: IconRefresh, Order: 50, Mandatory: false, Resolve: resolveRefresh},
+	}
+}
+
+// DirectPlay plays a Direct Play card straight from the hand during the react phase
+// The card's speed is added to the car and its other icons are added to the player for resolution
+// Input: player - the player playing the card
+//
+//	index - the index of the card in the player's hand
+//
+// Returns: an error if the card does not have the Direct Play icon or cannot be played
+func DirectPlay(player models.Player, index int) error {
+	if player == nil {
+		return errors.New("player is nil")
+	}
+
+	cards := player.GetHand().GetCards()
+	if index < 0 || index >= len(cards) {
+		return errors.New("invalid card index")
+	}
+
+	card := cards[index]
+	if card == nil || card.GetIcons()[IconDirectPlay] == 0 {
+		return errors.New("card cannot be played directly")
+	}
+
+	if err := player.PlayCard(index); err != nil {
+		return err
+	}
+
+	icons := card.GetIcons()
+	delete(icons, IconDirectPlay)
+
+	car := player.GetCar()
+	car.SetSpeed(car.GetSpeed() + card.GetSpeed())
+	player.AddIcons(icons)
+	return nil
+}
+
+// HeatCost returns the number of heat cards a card needs from the engine when it is played
+// Input: card - the card to check
+// Returns: the heat cost of the card
+func HeatCost(card models.Card) int {
+	if card == nil {
+		return 0
+	}
+	return card.GetIcons()[IconHeatControl]
+}
+
+// resolveDirectPlay does nothing for played cards
+// Direct Play only matters while the card is in the hand, see DirectPlay
+func resolveDirectPlay(player models.Player, count int) error {
+	return nil
+}
+
+// resolveReduceStress discards up to count Stress cards from the player's hand
+// Input: player - the player reducing stress
+//
+//	count - the number of Reduce Stress icons
+//
+// Returns: an error if a card cannot be removed from the hand
+func resolveReduceStress(player models.Player, count int) error {
+	for i := 0; i < count; i++ {
+		index := findCard(player.GetHand().GetCards(), models.Stress)
+		if index < 0 {
+			return nil
+		}
+
+		card, err := player.GetHand().RemoveCard(index)
+		if err != nil {
+			return err
+		}
+		player.GetDiscardPile().AddCard(card)
+	}
+
+	return nil
+}
+
+// resolveSuperCool moves up to count Heat cards from the discard pile back into the engine
+// Input: player - the player cooling down
+//
+//	count - the number of Super Cool icons
+//
+// Returns: an error if a card cannot be removed from the discard pile
+func resolveSuperCool(player models.Player, count int) error {
+	for i := 0; i < count; i++ {
+		index := findCard(player.GetDiscardPile().GetCards(), models.Heat)
+		if index < 0 {
+			return nil
+		}
+
+		if _, err := player.GetDiscardPile().RemoveCard(index); err != nil {
+			return err
+		}
+		player.GetCar().SetEngine(player.GetCar().GetEngine() + 1)
+	}
+
+	return nil
+}
+
+// resolveHeatControl pays count heat from the engine into the discard pile
+// Input: player - the player paying heat
+//
+//	count - the number of Heat Control icons
+//
+// Returns: an error if the engine does not hold enough heat
+func resolveHeatControl(player models.Player, count int) error {
+	car := player.GetCar()
+	if car.GetEngine() < count {
+		return fmt.Errorf("engine has %d heat, %d needed", car.GetEngine(), count)
+	}
+
+	car.SetEngine(car.GetEngine() - count)
+	for i := 0; i < count; i++ {
+		player.GetDiscardPile().AddCard(models.NewHeatCard())
+	}
+
+	return nil
+}
+
+// resolveScrap discards the top count cards of the player's deck
+// Input: player - the player scrapping cards
+//
+//	count - the number of Scrap icons
+//
+// Returns: none, scrapping stops when the deck is empty
+func resolveScrap(player models.Player, count int) error {
+	for i := 0; i < count; i++ {
+		card := player.GetDeck().DrawCard()
+		if card == nil {
+			return nil
+		}
+		player.GetDiscardPile().AddCard(card)
+	}
+
+	return nil
+}
+
+// resolveSalvage returns up to count of the fastest speed cards from the discard pile to the deck
+// The deck is shuffled afterwards
+// Input: player - the player salvaging cards
+//
+//	count - the number of Salvage icons
+//
+// Returns: an error if a card cannot be removed from the discard pile
+func resolveSalvage(player models.Player, count int) error {
+	salvaged := make([]models.Card, 0, count)
+	for i := 0; i < count; i++ {
+		index := fastestPlayableCard(player.GetDiscardPile().GetCards())
+		if index < 0 {
+			break
+		}
+
+		card, err := player.GetDiscardPile().RemoveCard(index)
+		if err != nil {
+			return err
+		}
+		salvaged = append(salvaged, card)
+	}
+
+	if len(salvaged) > 0 {
+		player.GetDeck().AddCardsToTop(salvaged)
+		player.GetDeck().Shuffle()
+	}
+
+	return nil
+}
+
+// resolveRefresh puts up to count played Refresh cards back on top of the deck instead of discarding them
+// Input: player - the player refreshing cards
+//
+//	count - the number of Refresh icons
+//
+// Returns: an error if a played card cannot be removed
+func resolveRefresh(player models.Player, count int) error {
+	for refreshed := 0; refreshed < count; {
+		index := -1
+		for i, card := range player.GetPlayedCards() {
+			if card.GetIcons()[IconRefresh] > 0 {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil
+		}
+
+		card, err := player.RemovePlayedCard(index)
+		if err != nil {
+			return err
+		}
+		player.GetDeck().AddCardsToTop([]models.Card{card})
+		refreshed += card.GetIcons()[IconRefresh]
+	}
+
+	return nil
+}
+
+// findCard returns the index of the first card with the given name
+// Input: cards - the cards to search
+//
+//	name - the card name to look for
+//
+// Returns: the index of the card, -1 if it is not found
+func findCard(cards []models.Card, name string) int {
+	for i, card := range cards {
+		if card != nil && card.GetName() == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// fastestPlayableCard returns the index of the playable, non-stress card with the highest speed
+// Input: cards - the cards to search
+// Returns: the index of the card, -1 if there is none
+func fastestPlayableCard(cards []models.Card) int {
+	best := -1
+	for i, card := range cards {
+		if card == nil || !card.IsPlayable() || card.GetName() == models.Stress {
+			continue
+		}
+		if best < 0 || card.GetSpeed() > cards[best].GetSpeed() {
+			best = i
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,270 @@
+package garage
+
+import (
+	"testing"
+
+	"race-cars/internal/models"
+)
+
+// Helper function to create a player for garage icon tests
+func createGarageTestPlayer(handCards, deckCards []models.Card, engine int) models.Player {
+	hand := models.NewHand()
+	hand.AddCards(handCards)
+	return models.NewPlayer("TestPlayer", models.NewCar("red", engine), models.NewDiscardPile(), models.NewDeck(deckCards), hand)
+}
+
+// Helper function to create a registry with the Garage effects
+func createGarageRegistry(t *testing.T) models.IconRegistry {
+	registry := models.NewIconRegistry()
+	if err := RegisterEffects(registry); err != nil {
+		t.Fatalf("RegisterEffects() error = %v", err)
+	}
+	return registry
+}
+
+func acceptAll(effect models.IconEffect, count int) bool {
+	return true
+}
+
+func TestIconNames(t *testing.T) {
+	for icon, name := range iconNames {
+		if icon.String() != name {
+			t.Errorf("Icon(%d).String() = %s, want %s", int(icon), icon.String(), name)
+		}
+	}
+}
+
+func TestRegisterEffects(t *testing.T) {
+	registry := createGarageRegistry(t)
+
+	for icon := range iconNames {
+		if _, ok := registry.GetEffect(icon); !ok {
+			t.Errorf("GetEffect(%s) not found", icon)
+		}
+	}
+
+	if err := RegisterEffects(registry); err == nil {
+		t.Error("RegisterEffects() twice should fail")
+	}
+	if err := RegisterEffects(nil); err == nil {
+		t.Error("RegisterEffects(nil) should fail")
+	}
+}
+
+func TestResolveReduceStress(t *testing.T) {
+	speedCard := models.NewSpeedCard(2)
+	player := createGarageTestPlayer([]models.Card{models.NewStressCard(), speedCard, models.NewStressCard()}, nil, 0)
+	player.AddIcons(map[models.Icon]int{IconReduceStress: 1})
+
+	if err := createGarageRegistry(t).ResolveIcons(player, acceptAll); err != nil {
+		t.Fatalf("ResolveIcons() error = %v", err)
+	}
+
+	cards := player.GetHand().GetCards()
+	if len(cards) != 2 {
+		t.Fatalf("Hand has %d cards, want 2", len(cards))
+	}
+	if len(player.GetDiscardPile().GetCards()) != 1 {
+		t.Errorf("Discard pile has %d cards, want 1", len(player.GetDiscardPile().GetCards()))
+	}
+}
+
+func TestResolveSuperCool(t *testing.T) {
+	player := createGarageTestPlayer(nil, nil, 0)
+	player.GetDiscardPile().AddCard(models.NewHeatCard())
+	player.GetDiscardPile().AddCard(models.NewSpeedCard(1))
+	player.AddIcons(map[models.Icon]int{IconSuperCool: 2})
+
+	if err := createGarageRegistry(t).ResolveIcons(player, acceptAll); err != nil {
+		t.Fatalf("ResolveIcons() error = %v", err)
+	}
+
+	if player.GetCar().GetEngine() != 1 {
+		t.Errorf("Engine = %d, want 1", player.GetCar().GetEngine())
+	}
+	if len(player.GetDiscardPile().GetCards()) != 1 {
+		t.Errorf("Discard pile has %d cards, want 1", len(player.GetDiscardPile().GetCards()))
+	}
+}
+
+func TestResolveHeatControl(t *testing.T) {
+	tests := []struct {
+		name           string
+		engine         int
+		icons          int
+		wantErr        bool
+		expectedEngine int
+	}{
+		{
+			name:           "Pay heat from engine",
+			engine:         3,
+			icons:          2,
+			expectedEngine: 1,
+		},
+		{
+			name:           "Not enough heat",
+			engine:         1,
+			icons:          2,
+			wantErr:        true,
+			expectedEngine: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := createGarageTestPlayer(nil, nil, tt.engine)
+			player.AddIcons(map[models.Icon]int{IconHeatControl: tt.icons})
+
+			// Heat Control is mandatory, so declining must not skip it
+			err := createGarageRegistry(t).ResolveIcons(player, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveIcons() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if player.GetCar().GetEngine() != tt.expectedEngine {
+				t.Errorf("Engine = %d, want %d", player.GetCar().GetEngine(), tt.expectedEngine)
+			}
+		})
+	}
+}
+
+func TestResolveScrap(t *testing.T) {
+	player := createGarageTestPlayer(nil, []models.Card{models.NewSpeedCard(1), models.NewSpeedCard(2), models.NewSpeedCard(3)}, 0)
+	player.AddIcons(map[models.Icon]int{IconScrap: 2})
+
+	if err := createGarageRegistry(t).ResolveIcons(player, nil); err != nil {
+		t.Fatalf("ResolveIcons() error = %v", err)
+	}
+
+	if len(player.GetDiscardPile().GetCards()) != 2 {
+		t.Errorf("Discard pile has %d cards, want 2", len(player.GetDiscardPile().GetCards()))
+	}
+	if card := player.GetDeck().DrawCard(); card == nil || card.GetSpeed() != 3 {
+		t.Errorf("Top of deck = %v, want Speed 3", card)
+	}
+}
+
+func TestResolveSalvage(t *testing.T) {
+	player := createGarageTestPlayer(nil, nil, 0)
+	player.GetDiscardPile().AddCard(models.NewSpeedCard(1))
+	player.GetDiscardPile().AddCard(models.NewHeatCard())
+	player.GetDiscardPile().AddCard(models.NewSpeedCard(4))
+	player.GetDiscardPile().AddCard(models.NewStressCard())
+	player.AddIcons(map[models.Icon]int{IconSalvage: 1})
+
+	if err := createGarageRegistry(t).ResolveIcons(player, acceptAll); err != nil {
+		t.Fatalf("ResolveIcons() error = %v", err)
+	}
+
+	if card := player.GetDeck().DrawCard(); card == nil || card.GetSpeed() != 4 {
+		t.Errorf("Salvaged card = %v, want Speed 4", card)
+	}
+	if len(player.GetDiscardPile().GetCards()) != 3 {
+		t.Errorf("Discard pile has %d cards, want 3", len(player.GetDiscardPile().GetCards()))
+	}
+}
+
+func TestResolveRefresh(t *testing.T) {
+	refreshCard, err := NewUpgradeCard("R.P.M.")
+	if err != nil {
+		t.Fatalf("NewUpgradeCard() error = %v", err)
+	}
+	player := createGarageTestPlayer([]models.Card{models.NewSpeedCard(1), refreshCard}, nil, 0)
+	player.PlayCard(0)
+	player.PlayCard(0)
+	player.ResolvePlayedCards()
+
+	if err := createGarageRegistry(t).ResolveIcons(player, acceptAll); err != nil {
+		t.Fatalf("ResolveIcons() error = %v", err)
+	}
+
+	if len(player.GetPlayedCards()) != 1 {
+		t.Errorf("Played cards = %d, want 1", len(player.GetPlayedCards()))
+	}
+	if card := player.GetDeck().DrawCard(); card != refreshCard {
+		t.Errorf("Top of deck = %v, want the refreshed card", card)
+	}
+}
+
+func TestDirectPlay(t *testing.T) {
+	brakes, _ := NewUpgradeCard("Brakes")
+	gasPedal, _ := NewUpgradeCard("Gas Pedal")
+
+	tests := []struct {
+		name          string
+		hand          []models.Card
+		index         int
+		wantErr       bool
+		expectedSpeed int
+		expectedIcons map[models.Icon]int
+	}{
+		{
+			name:          "Play Brakes directly",
+			hand:          []models.Card{models.NewSpeedCard(1), brakes},
+			index:         1,
+			expectedSpeed: 1,
+			expectedIcons: map[models.Icon]int{},
+		},
+		{
+			name:          "Direct play keeps the other icons",
+			hand:          []models.Card{gasPedal},
+			index:         0,
+			expectedSpeed: 3,
+			expectedIcons: map[models.Icon]int{IconHeatControl: 1},
+		},
+		{
+			name:    "Card without Direct Play",
+			hand:    []models.Card{models.NewSpeedCard(1)},
+			index:   0,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid index",
+			hand:    []models.Card{brakes},
+			index:   3,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := createGarageTestPlayer(tt.hand, nil, 3)
+
+			err := DirectPlay(player, tt.index)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DirectPlay() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if player.GetCar().GetSpeed() != tt.expectedSpeed {
+				t.Errorf("Speed = %d, want %d", player.GetCar().GetSpeed(), tt.expectedSpeed)
+			}
+			if player.GetIcons()[IconDirectPlay] != 0 {
+				t.Error("Direct Play icon should not be added to the player")
+			}
+			for icon, count := range tt.expectedIcons {
+				if player.GetIcons()[icon] != count {
+					t.Errorf("Icon %s = %d, want %d", icon, player.GetIcons()[icon], count)
+				}
+			}
+			if len(player.GetPlayedCards()) != 1 {
+				t.Errorf("Played cards = %d, want 1", len(player.GetPlayedCards()))
+			}
+		})
+	}
+}
+
+func TestHeatCost(t *testing.T) {
+	turbo, _ := NewUpgradeCard("Turbocharger")
+
+	if HeatCost(turbo) != 1 {
+		t.Errorf("HeatCost(Turbocharger) = %d, want 1", HeatCost(turbo))
+	}
+	if HeatCost(models.NewSpeedCard(4)) != 0 {
+		t.Errorf("HeatCost(Speed 4) = %d, want 0", HeatCost(models.NewSpeedCard(4)))
+	}
+	if HeatCost(nil) != 0 {
+		t.Errorf("HeatCost(nil) = %d, want 0", HeatCost(nil))
+	}
+}
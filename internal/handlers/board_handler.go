@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"race-cars/internal/middleware"
+	"race-cars/internal/models"
+	"race-cars/internal/render"
+	"race-cars/internal/tracks"
+
+	"github.com/gorilla/mux"
+)
+
+// BoardHandler draws the boards of running games
+type BoardHandler struct {
+	games SeatFinder
+	delay time.Duration
+}
+
+// NewBoardHandler creates a new board handler
+// Input: games - where the games are looked up
+//
+//	delay - the spectator feed's broadcast delay; while it is set the board is only drawn for seated players
+//
+// Returns: a new BoardHandler
+func NewBoardHandler(games SeatFinder, delay time.Duration) *BoardHandler {
+	return &BoardHandler{games: games, delay: delay}
+}
+
+// RegisterRoutes adds the board endpoints to a router
+// Input: router - the router for the API, like the /api subrouter
+// Returns: none
+func (h *BoardHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/games/{id}/board.svg", h.GetBoardSVG).Methods("GET")
+	router.HandleFunc("/games/{id}/board.txt", h.GetBoardText).Methods("GET")
+}
+
+// GetBoardSVG handles GET /games/{id}/board.svg, with the seat token while a broadcast delay is set
+// It returns the board with the cars on it as an SVG image
+func (h *BoardHandler) GetBoardSVG(w http.ResponseWriter, r *http.Request) {
+	h.writeBoard(w, r, "image/svg+xml", render.WriteSVG)
+}
+
+// GetBoardText handles GET /games/{id}/board.txt, with the seat token while a broadcast delay is set
+// It returns the board as plain text, for pasting into bug reports
+func (h *BoardHandler) GetBoardText(w http.ResponseWriter, r *http.Request) {
+	h.writeBoard(w, r, "text/plain; charset=utf-8", render.WriteText)
+}
+
+// writeBoard draws the board of the game in the request
+// Input: w - the response
+//
+//	r - the request
+//	contentType - the content type of the drawing
+//	draw - draws the board
+//
+// Returns: none
+func (h *BoardHandler) writeBoard(w http.ResponseWriter, r *http.Request, contentType string, draw func(io.Writer, tracks.Track, models.Board) error) {
+	id := mux.Vars(r)["id"]
+	if h.delay > 0 {
+		token, ok := requireToken(w, r)
+		if !ok {
+			return
+		}
+		if _, err := h.games.Authorize(id, token); err != nil {
+			gameError(w, err)
+			return
+		}
+	}
+
+	game, err := h.games.GetGame(id)
+	if err != nil {
+		middleware.ErrorResponse(w, http.StatusNotFound, "Game not found")
+		return
+	}
+
+	var drawing bytes.Buffer
+	if err := draw(&drawing, game.GetConfig().Track, game.GetBoard()); err != nil {
+		middleware.ErrorResponse(w, http.StatusInternalServerError, "Failed to draw board")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(drawing.Bytes())
+}
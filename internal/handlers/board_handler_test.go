@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"race-cars/internal/games"
+
+	"github.com/gorilla/mux"
+)
+
+func TestBoardHandler(t *testing.T) {
+	router, _, id, _ := createStartedGame(t, twoSeatGame, func(manager games.Manager, router *mux.Router) {
+		NewBoardHandler(manager, 0).RegisterRoutes(router)
+	})
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+		contains    string
+	}{
+		{"/games/" + id + "/board.svg", http.StatusOK, "image/svg+xml", "<svg"},
+		{"/games/" + id + "/board.txt", http.StatusOK, "text/plain; charset=utf-8", "USA, 1 lap"},
+		{"/games/other/board.svg", http.StatusNotFound, "application/json", "Game not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := serve(router, "GET", tt.path, "")
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+			if got := recorder.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if !strings.Contains(recorder.Body.String(), tt.contains) {
+				t.Errorf("body = %q, want it to contain %q", recorder.Body.String(), tt.contains)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"race-cars/internal/middleware"
+	"race-cars/internal/models"
+	"race-cars/internal/repository"
+
+	"github.com/gorilla/mux"
+)
+
+// CarHandler serves the car catalog
+type CarHandler struct {
+	repo repository.CarRepository
+}
+
+// NewCarHandler creates a new car handler
+// Input: repo - the repository the catalog is kept in
+// Returns: a new CarHandler
+func NewCarHandler(repo repository.CarRepository) *CarHandler {
+	return &CarHandler{
+		repo: repo,
+	}
+}
+
+// RegisterRoutes adds the car catalog endpoints to a router
+// Input: router - the router for the API, like the /api subrouter
+// Returns: none
+func (h *CarHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/cars", h.GetCars).Methods("GET")
+	router.HandleFunc("/cars", h.CreateCar).Methods("POST")
+	router.HandleFunc("/cars/{id}", h.GetCar).Methods("GET")
+	router.HandleFunc("/cars/{id}", h.UpdateCar).Methods("PUT")
+	router.HandleFunc("/cars/{id}", h.DeleteCar).Methods("DELETE")
+}
+
+// GetCars handles GET /cars, optionally filtered with ?color={color}
+// It returns every car in the catalog
+func (h *CarHandler) GetCars(w http.ResponseWriter, r *http.Request) {
+	var color models.Color
+	if name := r.URL.Query().Get("color"); name != "" {
+		var err error
+		if color, err = models.ParseColor(name); err != nil {
+			middleware.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	cars, err := h.repo.GetAll(color)
+	if err != nil {
+		log.Printf("Error getting cars: %v", err)
+		middleware.ErrorResponse(w, http.StatusInternalServerError, "Failed to get cars")
+		return
+	}
+	middleware.SuccessResponse(w, http.StatusOK, cars)
+}
+
+// GetCar handles GET /cars/{id}
+// It returns one car from the catalog
+func (h *CarHandler) GetCar(w http.ResponseWriter, r *http.Request) {
+	id, ok := carID(w, r)
+	if !ok {
+		return
+	}
+
+	car, err := h.repo.GetByID(id)
+	if err != nil {
+		carError(w, err, "Failed to get car")
+		return
+	}
+	middleware.SuccessResponse(w, http.StatusOK, car)
+}
+
+// CreateCar handles POST /cars with the car as the body
+// It adds a valid car to the catalog and returns it with its ID
+func (h *CarHandler) CreateCar(w http.ResponseWriter, r *http.Request) {
+	car, ok := decodeCar(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Create(&car); err != nil {
+		carError(w, err, "Failed to create car")
+		return
+	}
+	middleware.SuccessResponse(w, http.StatusCreated, car)
+}
+
+// UpdateCar handles PUT /cars/{id} with the whole car as the body
+// It replaces a car in the catalog
+func (h *CarHandler) UpdateCar(w http.ResponseWriter, r *http.Request) {
+	id, ok := carID(w, r)
+	if !ok {
+		return
+	}
+	car, ok := decodeCar(w, r)
+	if !ok {
+		return
+	}
+
+	car.ID = id
+	if err := h.repo.Update(&car); err != nil {
+		carError(w, err, "Failed to update car")
+		return
+	}
+	middleware.SuccessResponse(w, http.StatusOK, car)
+}
+
+// DeleteCar handles DELETE /cars/{id}
+// It removes a car from the catalog
+func (h *CarHandler) DeleteCar(w http.ResponseWriter, r *http.Request) {
+	id, ok := carID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		carError(w, err, "Failed to delete car")
+		return
+	}
+	middleware.JSONResponse(w, http.StatusOK, middleware.Response{
+		Success: true,
+		Message: "Car deleted successfully",
+	})
+}
+
+// carID reads the car ID from the path, answering 400 when it is not a positive number
+// Input: w - the response
+//
+//	r - the request
+//
+// Returns: the ID, false if the request was answered
+func carID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		middleware.ErrorResponse(w, http.StatusBadRequest, "Invalid car ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// decodeCar reads and validates the car in a request body, answering 400 when it is not valid
+// Input: w - the response
+//
+//	r - the request
+//
+// Returns: the car, false if the request was answered
+func decodeCar(w http.ResponseWriter, r *http.Request) (models.CatalogCar, bool) {
+	var car models.CatalogCar
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&car); err != nil {
+		middleware.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return models.CatalogCar{}, false
+	}
+	if err := car.Validate(); err != nil {
+		middleware.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return models.CatalogCar{}, false
+	}
+	return car, true
+}
+
+// carError answers a failed repository call: 404 for a missing car, 500 otherwise
+// Input: w - the response
+//
+//	err - the repository error
+//	message - the message for other errors
+//
+// Returns: none
+func carError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, repository.ErrCarNotFound) {
+		middleware.ErrorResponse(w, http.StatusNotFound, "Car not found")
+		return
+	}
+	log.Printf("%s: %v", message, err)
+	middleware.ErrorResponse(w, http.StatusInternalServerError, message)
+}
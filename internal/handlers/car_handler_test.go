@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"race-cars/internal/middleware"
+	"race-cars/internal/models"
+	"race-cars/internal/repository"
+
+	"github.com/gorilla/mux"
+)
+
+// Helper function to create a router serving a car catalog kept in memory
+func createCarRouter() *mux.Router {
+	router := mux.NewRouter()
+	NewCarHandler(repository.NewMemoryCarRepository()).RegisterRoutes(router)
+	return router
+}
+
+// Helper function to decode a successful response's data into a value
+func decodeCarData(t *testing.T, body io.Reader, data interface{}) {
+	t.Helper()
+	var response struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !response.Success {
+		t.Fatal("Success = false, want true")
+	}
+	if err := json.Unmarshal(response.Data, data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+}
+
+func TestCarHandler_ManagesCatalog(t *testing.T) {
+	router := createCarRouter()
+
+	recorder := serve(router, "POST", "/cars", `{"name": "Ferrari F40", "brand": "Ferrari", "model": "F40", "year": 1987, "color": "red"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, want %d", recorder.Code, http.StatusCreated)
+	}
+	var ferrari models.CatalogCar
+	decodeCarData(t, recorder.Body, &ferrari)
+	if ferrari.ID != 1 || ferrari.Color != models.Red || ferrari.CreatedAt.IsZero() {
+		t.Errorf("created car = %+v, want ID 1 in Red with a creation time", ferrari)
+	}
+
+	recorder = serve(router, "POST", "/cars", `{"name": "Lamborghini Countach", "brand": "Lamborghini", "model": "Countach", "year": 1974, "color": "Yellow"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, want %d", recorder.Code, http.StatusCreated)
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		names []string
+	}{
+		{"Every car", "/cars", []string{"Ferrari F40", "Lamborghini Countach"}},
+		{"Filtered by color", "/cars?color=yellow", []string{"Lamborghini Countach"}},
+		{"No car in color", "/cars?color=Blue", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(router, "GET", tt.path, "")
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+			}
+			var cars []models.CatalogCar
+			decodeCarData(t, recorder.Body, &cars)
+			if len(cars) != len(tt.names) {
+				t.Fatalf("got %d cars, want %d", len(cars), len(tt.names))
+			}
+			for i, car := range cars {
+				if car.Name != tt.names[i] {
+					t.Errorf("car %d = %q, want %q", i, car.Name, tt.names[i])
+				}
+			}
+		})
+	}
+
+	recorder = serve(router, "PUT", "/cars/1", `{"name": "Ferrari F40 LM", "brand": "Ferrari", "model": "F40", "year": 1989, "color": "Red"}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("update status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	recorder = serve(router, "GET", "/cars/1", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("get status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	var updated models.CatalogCar
+	decodeCarData(t, recorder.Body, &updated)
+	if updated.Name != "Ferrari F40 LM" || updated.Year != 1989 {
+		t.Errorf("updated car = %q from %d, want Ferrari F40 LM from 1989", updated.Name, updated.Year)
+	}
+	if !updated.CreatedAt.Equal(ferrari.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want it kept as %v", updated.CreatedAt, ferrari.CreatedAt)
+	}
+
+	if recorder := serve(router, "DELETE", "/cars/1", ""); recorder.Code != http.StatusOK {
+		t.Fatalf("delete status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if recorder := serve(router, "GET", "/cars/1", ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("get deleted status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestCarHandler_MissingCar(t *testing.T) {
+	router := createCarRouter()
+	valid := `{"name": "Ferrari F40", "brand": "Ferrari", "model": "F40", "year": 1987, "color": "Red"}`
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+	}{
+		{"Get", "GET", ""},
+		{"Update", "PUT", valid},
+		{"Delete", "DELETE", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(router, tt.method, "/cars/42", tt.body)
+			if recorder.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusNotFound)
+			}
+			var response middleware.Response
+			if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if response.Error != "Car not found" {
+				t.Errorf("Error = %q, want %q", response.Error, "Car not found")
+			}
+		})
+	}
+}
+
+func TestCarHandler_RejectsInvalidRequests(t *testing.T) {
+	router := createCarRouter()
+	valid := `{"name": "Ferrari F40", "brand": "Ferrari", "model": "F40", "year": 1987, "color": "Red"}`
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		status  int
+		message string
+	}{
+		{"Unknown color filter", "GET", "/cars?color=Purple", "", http.StatusBadRequest, `unknown color "Purple"`},
+		{"Get with text ID", "GET", "/cars/abc", "", http.StatusBadRequest, "Invalid car ID"},
+		{"Get with zero ID", "GET", "/cars/0", "", http.StatusBadRequest, "Invalid car ID"},
+		{"Delete with negative ID", "DELETE", "/cars/-3", "", http.StatusBadRequest, "Invalid car ID"},
+		{"Update with text ID", "PUT", "/cars/abc", valid, http.StatusBadRequest, "Invalid car ID"},
+		{"Create with malformed JSON", "POST", "/cars", `{"name":`, http.StatusBadRequest, "Invalid request body"},
+		{"Create with unknown field", "POST", "/cars", `{"name": "F40", "wings": 2}`, http.StatusBadRequest, "Invalid request body"},
+		{"Create without name", "POST", "/cars", `{"brand": "Ferrari", "model": "F40", "year": 1987, "color": "Red"}`, http.StatusBadRequest, "name is required"},
+		{"Create without color", "POST", "/cars", `{"name": "Ferrari F40", "brand": "Ferrari", "model": "F40", "year": 1987}`, http.StatusBadRequest, "color is required"},
+		{"Update with bad year", "PUT", "/cars/1", `{"name": "Ferrari F40", "brand": "Ferrari", "model": "F40", "year": 1800, "color": "Red"}`, http.StatusBadRequest, "year must be between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(router, tt.method, tt.path, tt.body)
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+
+			var response middleware.Response
+			if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if response.Success {
+				t.Error("Success = true, want false")
+			}
+			if !strings.HasPrefix(response.Error, tt.message) {
+				t.Errorf("Error = %q, want it to start with %q", response.Error, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+	"race-cars/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+// eventKeepAlive is how often an idle feed sends a comment so proxies keep it open
+const eventKeepAlive = 15 * time.Second
+
+// EventHandler streams the public event log of games to spectators as Server-Sent Events
+// Events can be held back for a broadcast delay, so players cannot watch the feed to learn what others did
+type EventHandler struct {
+	games games.Manager
+	delay time.Duration
+}
+
+// delayedEvent is an event waiting for the broadcast delay to pass
+type delayedEvent struct {
+	event engine.Event
+	due   time.Time
+}
+
+// NewEventHandler creates a new event handler
+// Input: manager - where the games are kept
+//
+//	delay - how long after the server sees an event it is streamed, 0 to stream at once
+//
+// Returns: a new EventHandler
+func NewEventHandler(manager games.Manager, delay time.Duration) *EventHandler {
+	return &EventHandler{games: manager, delay: delay}
+}
+
+// RegisterRoutes adds the event feed to a router
+// Input: router - the router for the API, like the /api subrouter
+// Returns: none
+func (h *EventHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/games/{id}/events", h.StreamEvents).Methods("GET")
+}
+
+// StreamEvents handles GET /games/{id}/events as an event stream of every event after Last-Event-ID
+// Each event is sent with its sequence number as the ID, so browsers resume where they stopped by themselves
+// Once the race is over and every event has been sent the stream ends, and a feed with nothing left answers 204 No Content
+func (h *EventHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	lastEvent := 0
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value != "" {
+		var err error
+		if lastEvent, err = strconv.Atoi(value); err != nil || lastEvent < 0 {
+			middleware.ErrorResponse(w, http.StatusBadRequest, "Invalid last event ID")
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		middleware.ErrorResponse(w, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	changes, unsubscribe, err := h.games.Subscribe(id)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	defer unsubscribe()
+
+	var pending []delayedEvent
+	queued := lastEvent
+	finished := false
+	load := func() error {
+		err := h.games.View(id, func(game engine.Game) error {
+			due := time.Now().Add(h.delay)
+			for _, event := range game.GetEvents() {
+				if event.Sequence > queued {
+					pending = append(pending, delayedEvent{event: event, due: due})
+					queued = event.Sequence
+				}
+			}
+			finished = game.IsFinished()
+			return nil
+		})
+		if errors.Is(err, games.ErrGameNotStarted) {
+			return nil
+		}
+		return err
+	}
+
+	if err := load(); err != nil {
+		gameError(w, err)
+		return
+	}
+	if finished && len(pending) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		now := time.Now()
+		for len(pending) > 0 && !pending[0].due.After(now) {
+			if err := writeEvent(w, pending[0].event); err != nil {
+				return
+			}
+			pending = pending[1:]
+		}
+		flusher.Flush()
+		if finished && len(pending) == 0 {
+			return
+		}
+
+		// The timer wakes the feed for the next pending event, or to keep an idle feed alive
+		wait := eventKeepAlive
+		if len(pending) > 0 {
+			wait = min(wait, time.Until(pending[0].due))
+		}
+		timer := time.NewTimer(wait)
+		var err error
+		select {
+		case <-changes:
+			err = load()
+		case <-timer.C:
+			if len(pending) == 0 || pending[0].due.After(time.Now()) {
+				_, err = fmt.Fprint(w, ": keep-alive\n\n")
+			}
+		case <-r.Context().Done():
+			err = r.Context().Err()
+		}
+		timer.Stop()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes an event in the event stream format, its sequence number as the ID and its JSON as the data
+// Input: w - the stream
+//
+//	event - the event
+//
+// Returns: an error if the stream cannot be written to
+func writeEvent(w http.ResponseWriter, event engine.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.Sequence, data)
+	return err
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+
+	"github.com/gorilla/mux"
+)
+
+// Helper function to make a function adding the event feed with a delay to a router
+func registerEvents(delay time.Duration) func(manager games.Manager, router *mux.Router) {
+	return func(manager games.Manager, router *mux.Router) {
+		NewEventHandler(manager, delay).RegisterRoutes(router)
+	}
+}
+
+// Helper function to open the event feed of a game, resuming after lastEventID when it is not empty
+func openFeed(t *testing.T, server *httptest.Server, id string, lastEventID string) *http.Response {
+	req, _ := http.NewRequest("GET", server.URL+"/games/"+id+"/events", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	response, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("GET events error = %v", err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+// Helper function to read the next event of a feed, checking its ID matches its sequence number
+func readEvent(t *testing.T, feed *bufio.Reader) engine.Event {
+	var id string
+	for {
+		line, err := feed.ReadString('\n')
+		if err != nil {
+			t.Fatalf("ReadString() error = %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			var event engine.Event
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if id != strconv.Itoa(event.Sequence) {
+				t.Errorf("event %d has ID %q", event.Sequence, id)
+			}
+			return event
+		}
+	}
+}
+
+// Helper function to find the sequence number of a game's last event
+func lastEventOf(t *testing.T, manager games.Manager, id string) int {
+	var last int
+	if err := manager.View(id, func(game engine.Game) error {
+		events := game.GetEvents()
+		last = events[len(events)-1].Sequence
+		return nil
+	}); err != nil {
+		t.Fatalf("View() error = %v", err)
+	}
+	return last
+}
+
+func TestEventHandler_StreamsEvents(t *testing.T) {
+	server, manager, id, tokens := createGameServer(t, registerEvents(0))
+
+	response := openFeed(t, server, id, "")
+	if response.StatusCode != http.StatusOK || response.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("GET events = %d %s, want an event stream", response.StatusCode, response.Header.Get("Content-Type"))
+	}
+	feed := bufio.NewReader(response.Body)
+	last := lastEventOf(t, manager, id)
+	for sequence := 1; sequence <= last; sequence++ {
+		if event := readEvent(t, feed); event.Sequence != sequence {
+			t.Fatalf("event %d arrived as number %d", event.Sequence, sequence)
+		}
+	}
+
+	// Once both seats plan, the race moves on and the new events follow
+	for seat, token := range tokens {
+		var plan engine.Action
+		manager.View(id, func(game engine.Game) error {
+			plan = game.LegalActions(seat)[0]
+			return nil
+		})
+		if err := manager.Submit(id, token, plan); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+	if event := readEvent(t, feed); event.Sequence != last+1 {
+		t.Errorf("next event = %d, want %d", event.Sequence, last+1)
+	}
+}
+
+func TestEventHandler_Resume(t *testing.T) {
+	server, manager, id, _ := createGameServer(t, registerEvents(0))
+	last := lastEventOf(t, manager, id)
+
+	feed := bufio.NewReader(openFeed(t, server, id, strconv.Itoa(last-1)).Body)
+	if event := readEvent(t, feed); event.Sequence != last {
+		t.Errorf("first event after resuming = %d, want %d", event.Sequence, last)
+	}
+}
+
+func TestEventHandler_Delay(t *testing.T) {
+	delay := 200 * time.Millisecond
+	server, _, id, _ := createGameServer(t, registerEvents(delay))
+
+	start := time.Now()
+	readEvent(t, bufio.NewReader(openFeed(t, server, id, "").Body))
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("first event arrived after %v, want at least %v", elapsed, delay)
+	}
+}
+
+func TestEventHandler_Errors(t *testing.T) {
+	server, _, id, _ := createGameServer(t, registerEvents(0))
+
+	tests := []struct {
+		name        string
+		id          string
+		lastEventID string
+		status      int
+	}{
+		{"Unknown game", "missing", "", http.StatusNotFound},
+		{"Bad last event ID", id, "first", http.StatusBadRequest},
+		{"Negative last event ID", id, "-1", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := openFeed(t, server, tt.id, tt.lastEventID).StatusCode; got != tt.status {
+				t.Errorf("GET events = %d, want %d", got, tt.status)
+			}
+		})
+	}
+}
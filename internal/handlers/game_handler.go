@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+	"race-cars/internal/middleware"
+	"race-cars/internal/models"
+	"race-cars/internal/repository"
+	"race-cars/internal/views"
+
+	"github.com/gorilla/mux"
+)
+
+// GameHandler serves the life of a game: setting it up, joining, starting and racing
+// Requests acting for a seat carry the seat token handed out on joining as "Authorization: Bearer <token>"
+type GameHandler struct {
+	games games.Manager
+	cars  repository.CarRepository
+	delay time.Duration
+}
+
+// joinRequest is the body of a request taking a seat
+// CarID picks a car from the catalog to race in its color, instead of giving the color
+type joinRequest struct {
+	Name  string       `json:"name"`
+	Color models.Color `json:"color"`
+	CarID int          `json:"car_id"`
+}
+
+// joinResponse tells a player their seat and the token that proves it
+type joinResponse struct {
+	Seat  int         `json:"seat"`
+	Token string      `json:"token"`
+	Table games.Table `json:"table"`
+}
+
+// actionRequest is the body of a request submitting a decision
+// Cards and DirectPlay are card IDs: the positions of the cards in the hand, or in the market while drafting
+type actionRequest struct {
+	Type       engine.ActionType `json:"type"`
+	Gear       int               `json:"gear"`
+	Cards      []int             `json:"cards"`
+	DirectPlay []int             `json:"direct_play"`
+	Boost      bool              `json:"boost"`
+	Cool       bool              `json:"cool"`
+	Icons      []string          `json:"icons"`
+	Slipstream bool              `json:"slipstream"`
+}
+
+// gameState is what a request sees of a game
+// The race is a views.PlayerView for the seat whose token came with the request and a views.SpectatorView for anyone
+// else, so hidden cards never leave the server
+type gameState struct {
+	games.Table
+
+	// Race is the race once it has started
+	Race interface{} `json:"race,omitempty"`
+}
+
+// NewGameHandler creates a new game handler
+// Input: manager - where the games are kept
+//
+//	cars - the car catalog players can pick their car from
+//	delay - the spectator feed's broadcast delay; while it is set the live race is only shown to seated players
+//
+// Returns: a new GameHandler
+func NewGameHandler(manager games.Manager, cars repository.CarRepository, delay time.Duration) *GameHandler {
+	return &GameHandler{
+		games: manager,
+		cars:  cars,
+		delay: delay,
+	}
+}
+
+// RegisterRoutes adds the game endpoints to a router
+// Input: router - the router for the API, like the /api subrouter
+// Returns: none
+func (h *GameHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/games", h.CreateGame).Methods("POST")
+	router.HandleFunc("/games/{id}", h.GetGame).Methods("GET")
+	router.HandleFunc("/games/{id}/seats", h.JoinGame).Methods("POST")
+	router.HandleFunc("/games/{id}/start", h.StartGame).Methods("POST")
+	router.HandleFunc("/games/{id}/actions", h.SubmitAction).Methods("POST")
+}
+
+// CreateGame handles POST /games with the settings as the body, like {"track": "USA", "laps": 2, "seats": 3}
+// It sets up a game waiting for players
+func (h *GameHandler) CreateGame(w http.ResponseWriter, r *http.Request) {
+	var settings games.Settings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		middleware.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	table, err := h.games.Create(settings)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	middleware.SuccessResponse(w, http.StatusCreated, table)
+}
+
+// GetGame handles GET /games/{id}
+// It returns the public state of the game, with the hand and legal actions of the seat whose token is sent.
+// Under a broadcast delay a request without a token gets the table alone and follows the race on the delayed event feed
+func (h *GameHandler) GetGame(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	seat := -1
+	if token := seatToken(r); token != "" {
+		var err error
+		if seat, err = h.games.Authorize(id, token); err != nil {
+			gameError(w, err)
+			return
+		}
+	}
+
+	if seat < 0 && h.delay > 0 {
+		table, err := h.games.GetTable(id)
+		if err != nil {
+			gameError(w, err)
+			return
+		}
+		middleware.SuccessResponse(w, http.StatusOK, gameState{Table: table})
+		return
+	}
+	h.writeState(w, id, seat)
+}
+
+// JoinGame handles POST /games/{id}/seats with a body like {"name": "Ada", "color": "Red"} or {"name": "Ada", "car_id": 1}
+// It takes the next free seat; the color can be left out for the first free one, or taken from a catalog car
+func (h *GameHandler) JoinGame(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var request joinRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		middleware.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if request.CarID != 0 {
+		if request.Color != "" {
+			middleware.ErrorResponse(w, http.StatusBadRequest, "Give either a color or a car_id, not both")
+			return
+		}
+		car, err := h.cars.GetByID(request.CarID)
+		if errors.Is(err, repository.ErrCarNotFound) {
+			middleware.ErrorResponse(w, http.StatusBadRequest, "Car not found")
+			return
+		}
+		if err != nil {
+			carError(w, err, "Failed to get car")
+			return
+		}
+		request.Color = car.Color
+	}
+
+	seat, token, err := h.games.Join(id, games.Seat{Name: request.Name, Color: request.Color})
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	table, err := h.games.GetTable(id)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	middleware.SuccessResponse(w, http.StatusCreated, joinResponse{Seat: seat, Token: token, Table: table})
+}
+
+// StartGame handles POST /games/{id}/start from any seated player
+// It starts the race once every seat is taken
+func (h *GameHandler) StartGame(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	token, ok := requireToken(w, r)
+	if !ok {
+		return
+	}
+	if err := h.games.Start(id, token); err != nil {
+		gameError(w, err)
+		return
+	}
+	seat, err := h.games.Authorize(id, token)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	h.writeState(w, id, seat)
+}
+
+// SubmitAction handles POST /games/{id}/actions with a decision for the current phase, like
+// {"type": "plan", "gear": 2, "cards": [0, 3]} or {"type": "react", "boost": true, "cool": true}
+// It returns the game as the seat sees it after the action
+func (h *GameHandler) SubmitAction(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	token, ok := requireToken(w, r)
+	if !ok {
+		return
+	}
+	seat, err := h.games.Authorize(id, token)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+
+	var request actionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		middleware.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	action, err := request.toAction()
+	if err != nil {
+		middleware.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.games.Submit(id, token, action); err != nil {
+		gameError(w, err)
+		return
+	}
+	h.writeState(w, id, seat)
+}
+
+// writeState answers with a game as a seat sees it
+// Input: w - the response
+//
+//	id - the game ID
+//	seat - the seat of the request, -1 for anyone else
+//
+// Returns: none
+func (h *GameHandler) writeState(w http.ResponseWriter, id string, seat int) {
+	state, err := loadState(h.games, id, seat)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	middleware.SuccessResponse(w, http.StatusOK, state)
+}
+
+// loadState reads a game as a seat sees it
+// Input: manager - where the game is kept
+//
+//	id - the game ID
+//	seat - the seat of the request, -1 for anyone else
+//
+// Returns: the gameState, an error if the game is not found
+func loadState(manager games.Manager, id string, seat int) (gameState, error) {
+	table, err := manager.GetTable(id)
+	if err != nil {
+		return gameState{}, err
+	}
+
+	state := gameState{Table: table}
+	err = manager.View(id, func(game engine.Game) error {
+		if seat < 0 {
+			state.Race = views.NewSpectatorView(game)
+			return nil
+		}
+		view, err := views.NewPlayerView(game, seat)
+		state.Race = view
+		return err
+	})
+	if err != nil && !errors.Is(err, games.ErrGameNotStarted) {
+		return gameState{}, err
+	}
+	return state, nil
+}
+
+// toAction turns a request into an engine action, looking up icons by name
+// Cool accepts the Cooling icons; Icons accepts any other optional icons
+// Input: none
+// Returns: the Action, an error if an icon is unknown
+func (a actionRequest) toAction() (engine.Action, error) {
+	action := engine.Action{
+		Type:       a.Type,
+		Gear:       a.Gear,
+		Cards:      a.Cards,
+		DirectPlay: a.DirectPlay,
+		Boost:      a.Boost,
+		Slipstream: a.Slipstream,
+	}
+	if a.Cool {
+		action.Icons = append(action.Icons, models.IconCooling)
+	}
+	for _, name := range a.Icons {
+		icon, err := models.ParseIcon(name)
+		if err != nil {
+			return engine.Action{}, err
+		}
+		action.Icons = append(action.Icons, icon)
+	}
+	return action, nil
+}
+
+// seatToken reads the seat token from the Authorization header
+// Input: r - the request
+// Returns: the token, empty when there is none
+func seatToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[len("Bearer "):])
+}
+
+// requireToken reads the seat token of a request that acts for a seat, answering 401 when there is none
+// Input: w - the response
+//
+//	r - the request
+//
+// Returns: the token, false if the request was answered
+func requireToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token := seatToken(r)
+	if token == "" {
+		middleware.ErrorResponse(w, http.StatusUnauthorized, "Seat token required")
+		return "", false
+	}
+	return token, true
+}
+
+// gameError answers a failed game request with the status the error calls for
+// Input: w - the response
+//
+//	err - the error from the game manager
+//
+// Returns: none
+func gameError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, games.ErrGameNotFound):
+		middleware.ErrorResponse(w, http.StatusNotFound, "Game not found")
+	case errors.Is(err, games.ErrInvalidToken):
+		middleware.ErrorResponse(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, games.ErrInvalidSettings), errors.Is(err, games.ErrInvalidSeat):
+		middleware.ErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, games.ErrIllegalAction):
+		middleware.ErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, games.ErrGameStarted), errors.Is(err, games.ErrGameNotStarted), errors.Is(err, games.ErrGameFull),
+		errors.Is(err, games.ErrSeatsOpen), errors.Is(err, games.ErrColorTaken), errors.Is(err, games.ErrRaceFinished),
+		errors.Is(err, games.ErrNotYourTurn):
+		middleware.ErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		log.Printf("Game request failed: %v", err)
+		middleware.ErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+	"race-cars/internal/gamestest"
+	"race-cars/internal/middleware"
+	"race-cars/internal/models"
+	"race-cars/internal/repository"
+	"race-cars/internal/views"
+
+	"github.com/gorilla/mux"
+)
+
+// Helper function to create a router serving games from a new manager
+func createGameRouter() *mux.Router {
+	router := mux.NewRouter()
+	NewGameHandler(games.NewManager(), repository.NewMemoryCarRepository(), 0).RegisterRoutes(router)
+	return router
+}
+
+// Helper function to send a request with a seat token and decode the data of the response
+func request(t *testing.T, router *mux.Router, method string, path string, token string, body string, data interface{}) int {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if data != nil && recorder.Code < 300 {
+		envelope := struct {
+			Success bool            `json:"success"`
+			Data    json.RawMessage `json:"data"`
+		}{}
+		if err := json.NewDecoder(recorder.Body).Decode(&envelope); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if err := json.Unmarshal(envelope.Data, data); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+	}
+	return recorder.Code
+}
+
+// Settings of the two-seat games the tests race in
+const (
+	twoSeatGame  = `{"track": "USA", "laps": 1, "seats": 2, "seed": 3}`
+	teachingGame = `{"track": "USA", "laps": 1, "seats": 2, "seed": 3, "hints": true}`
+)
+
+// Helper function to create a two-seat game on the router and join both seats, returning the game ID and tokens
+func createJoinedGame(t *testing.T, router *mux.Router) (string, []string) {
+	return createJoinedGameWith(t, router, twoSeatGame)
+}
+
+// Helper function to create a two-seat game with the settings given and join both seats, returning the game ID and tokens
+func createJoinedGameWith(t *testing.T, router *mux.Router, settings string) (string, []string) {
+	var table games.Table
+	if code := request(t, router, "POST", "/games", "", settings, &table); code != http.StatusCreated {
+		t.Fatalf("POST /games = %d, want %d", code, http.StatusCreated)
+	}
+
+	tokens := make([]string, 0, 2)
+	for _, body := range []string{`{"name": "Ada", "color": "Blue"}`, `{"name": "Brian"}`} {
+		var joined joinResponse
+		if code := request(t, router, "POST", "/games/"+table.ID+"/seats", "", body, &joined); code != http.StatusCreated {
+			t.Fatalf("POST seats = %d, want %d", code, http.StatusCreated)
+		}
+		tokens = append(tokens, joined.Token)
+	}
+	return table.ID, tokens
+}
+
+// Helper function to create a router behind the request logger serving the game routes and the routes each register
+// function adds, returning it with a started two-seat game made with the settings given
+func createStartedGame(t *testing.T, settings string, register ...func(manager games.Manager, router *mux.Router)) (*mux.Router, games.Manager, string, []string) {
+	manager := games.NewManager()
+	router := mux.NewRouter()
+	router.Use(middleware.Logger)
+	NewGameHandler(manager, repository.NewMemoryCarRepository(), 0).RegisterRoutes(router)
+	for _, add := range register {
+		add(manager, router)
+	}
+
+	id, tokens := createJoinedGameWith(t, router, settings)
+	if err := manager.Start(id, tokens[0]); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	return router, manager, id, tokens
+}
+
+// Helper function to start a server like createStartedGame, closed when the test ends
+func createGameServer(t *testing.T, register ...func(manager games.Manager, router *mux.Router)) (*httptest.Server, games.Manager, string, []string) {
+	router, manager, id, tokens := createStartedGame(t, twoSeatGame, register...)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, manager, id, tokens
+}
+
+// testState decodes a game state whatever view of the race it holds
+type testState struct {
+	games.Table
+	Race *views.PlayerView `json:"race"`
+}
+
+func TestGameHandler_Lifecycle(t *testing.T) {
+	router := createGameRouter()
+	id, tokens := createJoinedGame(t, router)
+
+	var waiting testState
+	request(t, router, "GET", "/games/"+id, "", "", &waiting)
+	if waiting.Status != games.StatusWaiting || len(waiting.Seats) != 2 || waiting.Seats[0].Color != "Blue" || waiting.Seats[1].Color != "Red" {
+		t.Fatalf("waiting game = %+v, want two seats, Blue then Red", waiting.Table)
+	}
+	if waiting.Race != nil {
+		t.Errorf("waiting game has a race")
+	}
+
+	var started testState
+	if code := request(t, router, "POST", "/games/"+id+"/start", tokens[0], "", &started); code != http.StatusOK {
+		t.Fatalf("POST start = %d, want %d", code, http.StatusOK)
+	}
+	if started.Status != games.StatusRacing || started.Race == nil || started.Race.Phase != "planning" || len(started.Race.Cars) != 2 {
+		t.Fatalf("started game = %+v, want two cars planning", started)
+	}
+	race := started.Race
+	if race.Seat != 0 || len(race.Hand) == 0 || len(race.LegalActions) == 0 {
+		t.Fatalf("started game shows seat %d, %d cards, %d actions, want seat 0 with its hand", race.Seat, len(race.Hand), len(race.LegalActions))
+	}
+
+	recorder := serve(router, "GET", "/games/"+id, "")
+	for _, hidden := range []string{`"hand"`, `"legal_actions"`} {
+		if strings.Contains(recorder.Body.String(), hidden) {
+			t.Errorf("state without a token contains %s", hidden)
+		}
+	}
+
+	plan := race.LegalActions[0]
+	body, _ := json.Marshal(actionRequest{Type: plan.Type, Gear: plan.Gear, Cards: plan.Cards})
+	var planned testState
+	if code := request(t, router, "POST", "/games/"+id+"/actions", tokens[0], string(body), &planned); code != http.StatusOK {
+		t.Fatalf("POST actions = %d, want %d", code, http.StatusOK)
+	}
+	if len(planned.Race.LegalActions) != 0 {
+		t.Errorf("legal actions after planning = %d, want 0", len(planned.Race.LegalActions))
+	}
+	if planned.Race.Plan == nil || planned.Race.Plan.Gear != plan.Gear {
+		t.Errorf("plan after planning = %+v, want gear %d", planned.Race.Plan, plan.Gear)
+	}
+	if got := planned.Race.Cars[0].PlayedCount; got != 0 {
+		t.Errorf("played_count before every seat planned = %d, want 0", got)
+	}
+	if code := request(t, router, "POST", "/games/"+id+"/actions", tokens[0], string(body), nil); code != http.StatusConflict {
+		t.Errorf("planning twice = %d, want %d", code, http.StatusConflict)
+	}
+}
+
+func TestGameHandler_SpectatorDelay(t *testing.T) {
+	manager := games.NewManager()
+	router := mux.NewRouter()
+	NewGameHandler(manager, repository.NewMemoryCarRepository(), time.Minute).RegisterRoutes(router)
+	NewSocketHandler(manager, time.Minute).RegisterRoutes(router)
+	NewBoardHandler(manager, time.Minute).RegisterRoutes(router)
+
+	id, tokens := createJoinedGame(t, router)
+	if err := manager.Start(id, tokens[0]); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	var plan engine.Action
+	manager.View(id, func(game engine.Game) error {
+		plan = game.LegalActions(0)[0]
+		return nil
+	})
+	if err := manager.Submit(id, tokens[0], plan); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	var anonymous testState
+	if code := request(t, router, "GET", "/games/"+id, "", "", &anonymous); code != http.StatusOK {
+		t.Fatalf("GET without a token = %d, want %d", code, http.StatusOK)
+	}
+	if anonymous.Status != games.StatusRacing || anonymous.Race != nil {
+		t.Errorf("state without a token = %+v, want the racing table without the race", anonymous)
+	}
+
+	var seated testState
+	request(t, router, "GET", "/games/"+id, tokens[1], "", &seated)
+	if seated.Race == nil || len(seated.Race.Events) == 0 {
+		t.Errorf("state with a token = %+v, want the race with its events", seated)
+	}
+
+	// Every other live view of the race needs a seat token too
+	for _, path := range []string{"/ws", "/board.svg", "/board.txt"} {
+		if code := request(t, router, "GET", "/games/"+id+path, "", "", nil); code != http.StatusUnauthorized {
+			t.Errorf("GET %s without a token = %d, want %d", path, code, http.StatusUnauthorized)
+		}
+	}
+	if code := request(t, router, "GET", "/games/"+id+"/board.txt", tokens[1], "", nil); code != http.StatusOK {
+		t.Errorf("GET board.txt with a token = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestGameHandler_Errors(t *testing.T) {
+	router := createGameRouter()
+	id, tokens := createJoinedGame(t, router)
+	game := "/games/" + id
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   string
+		status int
+	}{
+		{"Create with bad body", "POST", "/games", "", `{"track":`, http.StatusBadRequest},
+		{"Create on unknown track", "POST", "/games", "", `{"track": "Monaco", "seats": 2}`, http.StatusBadRequest},
+		{"Unknown game", "GET", "/games/missing", "", "", http.StatusNotFound},
+		{"Join unknown game", "POST", "/games/missing/seats", "", `{"name": "Cleo"}`, http.StatusNotFound},
+		{"Join full game", "POST", game + "/seats", "", `{"name": "Cleo"}`, http.StatusConflict},
+		{"State with wrong token", "GET", game, "wrong", "", http.StatusForbidden},
+		{"Start without token", "POST", game + "/start", "", "", http.StatusUnauthorized},
+		{"Start with wrong token", "POST", game + "/start", "wrong", "", http.StatusForbidden},
+		{"Act before start", "POST", game + "/actions", tokens[0], `{"type": "plan", "gear": 1, "cards": [0]}`, http.StatusConflict},
+		{"Start", "POST", game + "/start", tokens[1], "", http.StatusOK},
+		{"Start twice", "POST", game + "/start", tokens[0], "", http.StatusConflict},
+		{"Act without token", "POST", game + "/actions", "", `{"type": "plan"}`, http.StatusUnauthorized},
+		{"Act with bad body", "POST", game + "/actions", tokens[0], `{"type":`, http.StatusBadRequest},
+		{"Act with unknown icon", "POST", game + "/actions", tokens[0], `{"type": "react", "icons": ["Turbo"]}`, http.StatusBadRequest},
+		{"Act in the wrong phase", "POST", game + "/actions", tokens[0], `{"type": "discard"}`, http.StatusUnprocessableEntity},
+		{"Act with too many cards", "POST", game + "/actions", tokens[0], `{"type": "plan", "gear": 1, "cards": [0, 1, 2]}`, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := request(t, router, tt.method, tt.path, tt.token, tt.body, nil); got != tt.status {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, got, tt.status)
+			}
+		})
+	}
+}
+
+func TestGameHandler_JoinWithCatalogCar(t *testing.T) {
+	cars := repository.NewMemoryCarRepository()
+	ferrari := gamestest.CatalogCar("Ferrari F40", models.Red)
+	if err := cars.Create(&ferrari); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	router := mux.NewRouter()
+	NewGameHandler(games.NewManager(), cars, 0).RegisterRoutes(router)
+
+	var table games.Table
+	if code := request(t, router, "POST", "/games", "", `{"track": "USA", "seats": 3}`, &table); code != http.StatusCreated {
+		t.Fatalf("POST /games = %d, want %d", code, http.StatusCreated)
+	}
+	seats := "/games/" + table.ID + "/seats"
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		color  models.Color
+	}{
+		{"Join with a catalog car", `{"name": "Ada", "car_id": ` + strconv.Itoa(ferrari.ID) + `}`, http.StatusCreated, models.Red},
+		{"Join with a taken catalog car color", `{"name": "Brian", "car_id": ` + strconv.Itoa(ferrari.ID) + `}`, http.StatusConflict, ""},
+		{"Join with an unknown car", `{"name": "Brian", "car_id": 99}`, http.StatusBadRequest, ""},
+		{"Join with a car and a color", `{"name": "Brian", "car_id": ` + strconv.Itoa(ferrari.ID) + `, "color": "Blue"}`, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var joined joinResponse
+			if got := request(t, router, "POST", seats, "", tt.body, &joined); got != tt.status {
+				t.Fatalf("POST seats = %d, want %d", got, tt.status)
+			}
+			if tt.color != "" && joined.Table.Seats[joined.Seat].Color != tt.color {
+				t.Errorf("seat color = %s, want %s", joined.Table.Seats[joined.Seat].Color, tt.color)
+			}
+		})
+	}
+}
+
+func TestSeatToken(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Bearer abc", "abc"},
+		{"bearer abc ", "abc"},
+		{"Basic abc", ""},
+		{"Bearer", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Authorization", tt.header)
+			if got := seatToken(req); got != tt.want {
+				t.Errorf("seatToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
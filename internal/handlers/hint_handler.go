@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"race-cars/internal/analysis"
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+	"race-cars/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+// GameFinder looks up running games by ID
+type GameFinder interface {
+	// GetGame returns a running game
+	// Input: id - the game ID
+	// Returns: the Game, an error if there is no game with the ID
+	GetGame(id string) (engine.Game, error)
+}
+
+// SeatFinder looks up running games, their settings and the seats their tokens belong to
+type SeatFinder interface {
+	GameFinder
+
+	// GetTable returns a game's settings, seats and status
+	// Input: id - the game ID
+	// Returns: the Table, games.ErrGameNotFound if there is no game with the ID
+	GetTable(id string) (games.Table, error)
+
+	// Authorize finds the seat a token belongs to
+	// Input: id - the game ID
+	//	token - the seat token
+	// Returns: the seat index, games.ErrInvalidToken if the token is not one of the game's
+	Authorize(id string, token string) (int, error)
+}
+
+// HintHandler serves move suggestions for teaching games, the games created with hints in their settings
+// Every request carries a seat token like the game endpoints; suggestions name the cards in a hand, so a seat only
+// ever gets its own
+type HintHandler struct {
+	games SeatFinder
+}
+
+// NewHintHandler creates a new hint handler
+// Input: games - where the games and the seats of their tokens are looked up
+// Returns: a new HintHandler
+func NewHintHandler(games SeatFinder) *HintHandler {
+	return &HintHandler{
+		games: games,
+	}
+}
+
+// RegisterRoutes adds the hint endpoints to a router
+// Input: router - the router for the API, like the /api subrouter
+// Returns: none
+func (h *HintHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/games/{id}/hints", h.GetHints).Methods("GET")
+}
+
+// GetHints handles GET /games/{id}/hints
+// It returns the best few moves for the seat whose token is sent, with the reasons for each
+func (h *HintHandler) GetHints(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	token, ok := requireToken(w, r)
+	if !ok {
+		return
+	}
+	seat, err := h.games.Authorize(id, token)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	table, err := h.games.GetTable(id)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	if !table.Settings.Hints {
+		middleware.ErrorResponse(w, http.StatusForbidden, "Hints are not enabled for this game")
+		return
+	}
+
+	game, err := h.games.GetGame(id)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	suggestions, err := analysis.NewAdvisor(game).Suggest(game.GetPlayers()[seat])
+	if err != nil {
+		middleware.ErrorResponse(w, http.StatusConflict, err.Error())
+		return
+	}
+	middleware.SuccessResponse(w, http.StatusOK, suggestions)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"race-cars/internal/analysis"
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+
+	"github.com/gorilla/mux"
+)
+
+// Helper function to add the hint routes to a router
+func registerHints(manager games.Manager, router *mux.Router) {
+	NewHintHandler(manager).RegisterRoutes(router)
+}
+
+// Helper function to send a request to a router
+func serve(router *mux.Router, method string, path string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return recorder
+}
+
+func TestHintHandler_GetHints(t *testing.T) {
+	router, manager, id, tokens := createStartedGame(t, teachingGame, registerHints)
+	path := "/games/" + id + "/hints"
+
+	// The seat comes from the token, whatever seat the query asks for
+	var suggestions []analysis.Suggestion
+	if got := request(t, router, "GET", path+"?seat=0", tokens[1], "", &suggestions); got != http.StatusOK {
+		t.Fatalf("GET hints = %d, want %d", got, http.StatusOK)
+	}
+	if len(suggestions) == 0 {
+		t.Fatal("GET hints returned no suggestions")
+	}
+	game, err := manager.GetGame(id)
+	if err != nil {
+		t.Fatalf("GetGame() error = %v", err)
+	}
+	hand := game.GetPlayers()[1].GetHand()
+	for _, suggestion := range suggestions {
+		if suggestion.Action.Type != engine.ActionPlan || suggestion.Rationale == "" {
+			t.Errorf("suggestion = %+v, want a plan with a rationale", suggestion)
+		}
+		for i, index := range suggestion.Action.Cards {
+			if hand.GetCard(index).GetName() != suggestion.Cards[i] {
+				t.Errorf("suggestion plays %v, want cards from seat 1's hand", suggestion.Cards)
+			}
+		}
+	}
+}
+
+func TestHintHandler_OnlyTeachingGames(t *testing.T) {
+	router, _, _, _ := createStartedGame(t, teachingGame, registerHints)
+	id, tokens := createJoinedGame(t, router)
+	path := "/games/" + id + "/hints"
+	request(t, router, "POST", "/games/"+id+"/start", tokens[0], "", nil)
+
+	if got := request(t, router, "GET", path, tokens[0], "", nil); got != http.StatusForbidden {
+		t.Errorf("GET hints in a game without hints = %d, want %d", got, http.StatusForbidden)
+	}
+	if got := request(t, router, "PUT", path, tokens[0], `{"enabled": true}`, nil); got != http.StatusMethodNotAllowed {
+		t.Errorf("PUT hints = %d, want %d, hints are only set when the game is created", got, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHintHandler_RequiresSeatToken(t *testing.T) {
+	router, _, id, _ := createStartedGame(t, teachingGame, registerHints)
+	_, otherTokens := createJoinedGameWith(t, router, teachingGame)
+	path := "/games/" + id + "/hints"
+
+	tests := []struct {
+		name     string
+		token    string
+		expected int
+	}{
+		{name: "Hints without a token", expected: http.StatusUnauthorized},
+		{name: "Hints with another game's token", token: otherTokens[0], expected: http.StatusForbidden},
+		{name: "Hints with a made-up token", token: "guess", expected: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := request(t, router, "GET", path+"?seat=0", tt.token, "", nil); got != tt.expected {
+				t.Errorf("GET %s = %d, want %d", path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHintHandler_Errors(t *testing.T) {
+	router, manager, id, tokens := createStartedGame(t, teachingGame, registerHints)
+	var plan engine.Action
+	manager.View(id, func(game engine.Game) error {
+		plan = game.LegalActions(0)[0]
+		return nil
+	})
+	if err := manager.Submit(id, tokens[0], plan); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	waitingID, waitingTokens := createJoinedGameWith(t, router, teachingGame)
+
+	tests := []struct {
+		name     string
+		path     string
+		token    string
+		expected int
+	}{
+		{name: "Unknown game", path: "/games/other/hints", token: tokens[0], expected: http.StatusNotFound},
+		{name: "Seat already planned", path: "/games/" + id + "/hints", token: tokens[0], expected: http.StatusConflict},
+		{name: "Race not started", path: "/games/" + waitingID + "/hints", token: waitingTokens[0], expected: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := request(t, router, "GET", tt.path, tt.token, "", nil); got != tt.expected {
+				t.Errorf("GET %s = %d, want %d", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
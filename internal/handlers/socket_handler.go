@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+	"race-cars/internal/middleware"
+	"race-cars/internal/views"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// socketWriteWait is how long a client has to take a message before it is dropped
+	socketWriteWait = 10 * time.Second
+
+	// socketPongWait is how long a client may stay silent, pongs included, before it is dropped
+	socketPongWait = 60 * time.Second
+
+	// socketPingPeriod is how often clients are pinged; it must be shorter than socketPongWait
+	socketPingPeriod = socketPongWait * 9 / 10
+
+	// socketMaxMessage is the largest message a client may send
+	socketMaxMessage = 4096
+
+	// socketReplies is the number of replies waiting to be written before a client that does not read is dropped
+	socketReplies = 16
+)
+
+// Message types sent to socket clients
+const (
+	socketState = "state"
+	socketPatch = "patch"
+	socketEvent = "event"
+	socketError = "error"
+)
+
+// SocketHandler pushes games to players over WebSockets as they happen and takes their actions
+// A client gets every event it has not seen, then the whole game as its seat sees it, then merge patches (RFC 7386)
+// of that state after every change; reconnecting with the last event ID it saw replays only the events it missed
+type SocketHandler struct {
+	games    games.Manager
+	delay    time.Duration
+	upgrader websocket.Upgrader
+}
+
+// socketMessage is a message to a client
+type socketMessage struct {
+	Type string `json:"type"`
+
+	// EventID is the sequence number of the last event the client has been sent
+	EventID int `json:"event_id"`
+
+	State interface{}   `json:"state,omitempty"`
+	Patch interface{}   `json:"patch,omitempty"`
+	Event *engine.Event `json:"event,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// socketRequest is a message from a client, like {"type": "action", "action": {"type": "plan", "gear": 2, "cards": [0, 1]}}
+type socketRequest struct {
+	Type   string        `json:"type"`
+	Action actionRequest `json:"action"`
+}
+
+// socketClient is one connection to a game
+type socketClient struct {
+	conn  *websocket.Conn
+	games games.Manager
+	id    string
+	token string
+	seat  int
+
+	// lastEvent is the sequence number of the last event sent
+	lastEvent int
+
+	// sent is the last state sent as JSON values, nil until the first one is sent
+	sent map[string]interface{}
+
+	replies chan socketMessage
+}
+
+// NewSocketHandler creates a new socket handler
+// Input: manager - where the games are kept
+//
+//	delay - the spectator feed's broadcast delay; while it is set only seated players may connect
+//
+// Returns: a new SocketHandler
+func NewSocketHandler(manager games.Manager, delay time.Duration) *SocketHandler {
+	return &SocketHandler{
+		games: manager,
+		delay: delay,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+
+			// Any origin may connect, as with CORS; acting for a seat needs its token, which browsers never send by themselves
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// RegisterRoutes adds the socket endpoint to a router
+// Input: router - the router for the API, like the /api subrouter
+// Returns: none
+func (h *SocketHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/games/{id}/ws", h.Connect).Methods("GET")
+}
+
+// Connect handles GET /games/{id}/ws?token={token}&last_event_id={id} and upgrades it to a WebSocket
+// Without a token the client is a spectator, unless a broadcast delay is set and spectators are left to the event feed.
+// The last event ID can also come as the Last-Event-ID header
+func (h *SocketHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token = seatToken(r)
+	}
+	if token == "" && h.delay > 0 {
+		middleware.ErrorResponse(w, http.StatusUnauthorized, "Seat token required")
+		return
+	}
+
+	seat := -1
+	if token != "" {
+		var err error
+		if seat, err = h.games.Authorize(id, token); err != nil {
+			gameError(w, err)
+			return
+		}
+	}
+
+	lastEvent := 0
+	if value := r.URL.Query().Get("last_event_id"); value != "" || r.Header.Get("Last-Event-ID") != "" {
+		if value == "" {
+			value = r.Header.Get("Last-Event-ID")
+		}
+		var err error
+		if lastEvent, err = strconv.Atoi(value); err != nil || lastEvent < 0 {
+			middleware.ErrorResponse(w, http.StatusBadRequest, "Invalid last event ID")
+			return
+		}
+	}
+
+	changes, unsubscribe, err := h.games.Subscribe(id)
+	if err != nil {
+		gameError(w, err)
+		return
+	}
+	defer unsubscribe()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has answered the request
+		return
+	}
+	defer conn.Close()
+
+	client := &socketClient{
+		conn:      conn,
+		games:     h.games,
+		id:        id,
+		token:     token,
+		seat:      seat,
+		lastEvent: lastEvent,
+		replies:   make(chan socketMessage, socketReplies),
+	}
+	done := make(chan struct{})
+	go client.read(done)
+	client.write(changes, done)
+}
+
+// read takes the client's messages until the connection fails, playing the actions it sends
+// Input: done - closed when reading stops
+// Returns: none
+func (c *socketClient) read(done chan<- struct{}) {
+	defer close(done)
+	c.conn.SetReadLimit(socketMaxMessage)
+	c.conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Printf("Socket for game %s closed: %v", c.id, err)
+			}
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(socketPongWait))
+
+		var request socketRequest
+		if err := json.Unmarshal(data, &request); err != nil || request.Type != "action" {
+			c.reply("Invalid message")
+			continue
+		}
+		if c.seat < 0 {
+			c.reply("Seat token required")
+			continue
+		}
+		action, err := request.Action.toAction()
+		if err != nil {
+			c.reply(err.Error())
+			continue
+		}
+		if err := c.games.Submit(c.id, c.token, action); err != nil {
+			c.reply(err.Error())
+		}
+	}
+}
+
+// reply queues an error for the client, dropping a client that has stopped reading its replies
+// Input: message - the error message
+// Returns: none
+func (c *socketClient) reply(message string) {
+	select {
+	case c.replies <- socketMessage{Type: socketError, Error: message}:
+	default:
+		c.conn.Close()
+	}
+}
+
+// write sends the game to the client after every change, its replies and pings until the connection fails
+// Input: changes - signalled after the game changes
+//
+//	done - closed when the client stops reading
+//
+// Returns: none
+func (c *socketClient) write(changes <-chan struct{}, done <-chan struct{}) {
+	ticker := time.NewTicker(socketPingPeriod)
+	defer ticker.Stop()
+
+	if err := c.sendUpdate(); err != nil {
+		return
+	}
+	for {
+		var err error
+		select {
+		case <-changes:
+			err = c.sendUpdate()
+		case reply := <-c.replies:
+			reply.EventID = c.lastEvent
+			err = c.send(reply)
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+			err = c.conn.WriteMessage(websocket.PingMessage, nil)
+		case <-done:
+			c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				time.Now().Add(socketWriteWait))
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// sendUpdate sends the events the client has not seen, then the game as its seat sees it or the patch to it
+// Input: none
+// Returns: an error if the game cannot be read or the client cannot be written to
+func (c *socketClient) sendUpdate() error {
+	state, err := loadState(c.games, c.id, c.seat)
+	if err != nil {
+		return err
+	}
+
+	var events []engine.Event
+	switch view := state.Race.(type) {
+	case views.SpectatorView:
+		events = view.Events
+	case views.PlayerView:
+		events = view.Events
+	}
+	for i := range events {
+		if events[i].Sequence <= c.lastEvent {
+			continue
+		}
+		c.lastEvent = events[i].Sequence
+		if err := c.send(socketMessage{Type: socketEvent, EventID: c.lastEvent, Event: &events[i]}); err != nil {
+			return err
+		}
+	}
+
+	// Events are sent one by one, so the state leaves them out
+	current, err := toJSONObject(state)
+	if err != nil {
+		return err
+	}
+	if race, ok := current["race"].(map[string]interface{}); ok {
+		delete(race, "events")
+	}
+
+	message := socketMessage{Type: socketState, EventID: c.lastEvent, State: current}
+	if c.sent != nil {
+		patch := mergePatch(c.sent, current)
+		if len(patch) == 0 {
+			return nil
+		}
+		message = socketMessage{Type: socketPatch, EventID: c.lastEvent, Patch: patch}
+	}
+	c.sent = current
+	return c.send(message)
+}
+
+// send writes a message to the client, giving up after socketWriteWait
+// Input: message - the message
+// Returns: an error if the client cannot be written to
+func (c *socketClient) send(message socketMessage) error {
+	c.conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+	return c.conn.WriteJSON(message)
+}
+
+// toJSONObject turns a value into the JSON object it is sent as
+// Input: value - a value that marshals to a JSON object
+// Returns: the object, an error if the value is not one
+func toJSONObject(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, errors.New("state is not a JSON object")
+	}
+	return object, nil
+}
+
+// mergePatch works out the JSON merge patch (RFC 7386) that turns one JSON object into another
+// Objects are patched key by key; any other changed value, arrays included, is replaced whole
+// Input: before - the object the client has
+//
+//	after - the object the client should have
+//
+// Returns: the patch, empty when the objects are equal
+func mergePatch(before, after map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	for key, value := range after {
+		old, ok := before[key]
+		if ok && reflect.DeepEqual(old, value) {
+			continue
+		}
+		oldObject, oldIsObject := old.(map[string]interface{})
+		newObject, newIsObject := value.(map[string]interface{})
+		if oldIsObject && newIsObject {
+			patch[key] = mergePatch(oldObject, newObject)
+			continue
+		}
+		patch[key] = value
+	}
+	return patch
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+// Helper function to add the socket routes to a router
+func registerSocket(manager games.Manager, router *mux.Router) {
+	NewSocketHandler(manager, 0).RegisterRoutes(router)
+}
+
+// Helper function to open a socket to a game with a query like "token=abc"
+func dialGame(t *testing.T, server *httptest.Server, id string, query string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/games/" + id + "/ws?" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// testMessage decodes a message from the socket
+type testMessage struct {
+	Type    string                 `json:"type"`
+	EventID int                    `json:"event_id"`
+	State   map[string]interface{} `json:"state"`
+	Patch   map[string]interface{} `json:"patch"`
+	Event   *engine.Event          `json:"event"`
+	Error   string                 `json:"error"`
+}
+
+// Helper function to read messages until one of the given type arrives, returning it and the events before it
+func readUntil(t *testing.T, conn *websocket.Conn, messageType string) (testMessage, []engine.Event) {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var events []engine.Event
+	for {
+		var message testMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("ReadJSON() waiting for %s error = %v", messageType, err)
+		}
+		if message.Type == messageType {
+			return message, events
+		}
+		if message.Type == socketEvent {
+			events = append(events, *message.Event)
+		}
+	}
+}
+
+func TestSocketHandler_PushesUpdates(t *testing.T) {
+	server, manager, id, tokens := createGameServer(t, registerSocket)
+	conn := dialGame(t, server, id, "token="+tokens[0])
+
+	initial, events := readUntil(t, conn, socketState)
+	if len(events) == 0 || events[0].Sequence != 1 || initial.EventID != events[len(events)-1].Sequence {
+		t.Fatalf("connected with %d events and state at event %d, want every event then the state", len(events), initial.EventID)
+	}
+	race, ok := initial.State["race"].(map[string]interface{})
+	if !ok || race["hand"] == nil {
+		t.Fatalf("state = %v, want the race with seat 0's hand", initial.State)
+	}
+	if _, ok := race["events"]; ok {
+		t.Error("state repeats the events")
+	}
+
+	var plan engine.Action
+	manager.View(id, func(game engine.Game) error {
+		plan = game.LegalActions(0)[0]
+		return nil
+	})
+	conn.WriteJSON(socketRequest{Type: "action", Action: actionRequest{Type: plan.Type, Gear: plan.Gear, Cards: plan.Cards}})
+
+	patch, _ := readUntil(t, conn, socketPatch)
+	planned, ok := patch.Patch["race"].(map[string]interface{})["plan"].(map[string]interface{})
+	if !ok || planned["gear"] != float64(plan.Gear) {
+		t.Errorf("patch = %v, want seat 0's plan", patch.Patch)
+	}
+
+	conn.WriteJSON(socketRequest{Type: "action", Action: actionRequest{Type: plan.Type, Gear: plan.Gear, Cards: plan.Cards}})
+	if reply, _ := readUntil(t, conn, socketError); !strings.Contains(reply.Error, games.ErrNotYourTurn.Error()) {
+		t.Errorf("planning twice replies %q, want %q", reply.Error, games.ErrNotYourTurn)
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"type":`))
+	if reply, _ := readUntil(t, conn, socketError); reply.Error != "Invalid message" {
+		t.Errorf("bad message replies %q, want %q", reply.Error, "Invalid message")
+	}
+}
+
+func TestSocketHandler_Resume(t *testing.T) {
+	server, manager, id, _ := createGameServer(t, registerSocket)
+
+	var last int
+	manager.View(id, func(game engine.Game) error {
+		events := game.GetEvents()
+		last = events[len(events)-1].Sequence
+		return nil
+	})
+
+	conn := dialGame(t, server, id, "last_event_id="+strconv.Itoa(last-1))
+	state, events := readUntil(t, conn, socketState)
+	if len(events) != 1 || events[0].Sequence != last || state.EventID != last {
+		t.Fatalf("resumed with events %+v and state at event %d, want only event %d", events, state.EventID, last)
+	}
+	if race := state.State["race"].(map[string]interface{}); race["hand"] != nil {
+		t.Error("spectator state shows a hand")
+	}
+
+	conn.WriteJSON(socketRequest{Type: "action", Action: actionRequest{Type: engine.ActionPlan}})
+	if reply, _ := readUntil(t, conn, socketError); reply.Error != "Seat token required" {
+		t.Errorf("spectator action replies %q, want %q", reply.Error, "Seat token required")
+	}
+}
+
+func TestSocketHandler_Refused(t *testing.T) {
+	server, _, id, _ := createGameServer(t, registerSocket)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"Unknown game", "/games/missing/ws", http.StatusNotFound},
+		{"Wrong token", "/games/" + id + "/ws?token=wrong", http.StatusForbidden},
+		{"Bad last event ID", "/games/" + id + "/ws?last_event_id=-1", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := "ws" + strings.TrimPrefix(server.URL, "http") + tt.path
+			_, response, err := websocket.DefaultDialer.Dial(url, nil)
+			if err == nil || response == nil || response.StatusCode != tt.status {
+				t.Errorf("Dial() = %v, %v, want status %d", response, err, tt.status)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{"Equal", `{"a": 1, "b": {"c": [1]}}`, `{"a": 1, "b": {"c": [1]}}`, `{}`},
+		{"Changed value", `{"a": 1, "b": 2}`, `{"a": 1, "b": 3}`, `{"b": 3}`},
+		{"Removed key", `{"a": 1, "b": 2}`, `{"a": 1}`, `{"b": null}`},
+		{"Added key", `{"a": 1}`, `{"a": 1, "b": {"c": 2}}`, `{"b": {"c": 2}}`},
+		{"Nested object", `{"a": {"b": 1, "c": 2}}`, `{"a": {"b": 1, "c": 3}}`, `{"a": {"c": 3}}`},
+		{"Array replaced whole", `{"a": [1, 2]}`, `{"a": [1, 3]}`, `{"a": [1, 3]}`},
+		{"Object becomes value", `{"a": {"b": 1}}`, `{"a": 2}`, `{"a": 2}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before, after, want map[string]interface{}
+			json.Unmarshal([]byte(tt.before), &before)
+			json.Unmarshal([]byte(tt.after), &after)
+			json.Unmarshal([]byte(tt.want), &want)
+			if got := mergePatch(before, after); !reflect.DeepEqual(got, want) {
+				t.Errorf("mergePatch() = %v, want %v", got, want)
+			}
+		})
+	}
+}
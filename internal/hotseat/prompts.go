@@ -0,0 +1,255 @@
+package hotseat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"race-cars/internal/engine"
+)
+
+// prompt asks the seat at the keyboard for its decision in the current phase
+// Input errors are explained and asked again; the engine checks the rules when the action is submitted
+// Input: seat - the seat at the keyboard
+// Returns: the Action, an error if the input ends or the player quits
+func (s *session) prompt(seat int) (engine.Action, error) {
+	switch s.game.GetPhase() {
+	case engine.PhaseDraft:
+		return s.promptDraft()
+	case engine.PhasePlanning:
+		return s.promptPlan(seat)
+	case engine.PhaseReact:
+		return s.promptReact(seat)
+	case engine.PhaseSlipstream:
+		return s.promptSlipstream(seat)
+	default:
+		return s.promptDiscard(seat)
+	}
+}
+
+// promptDraft asks for an upgrade from the draft market
+// Input: none
+// Returns: the draft Action, an error if the input ends or the player quits
+func (s *session) promptDraft() (engine.Action, error) {
+	market := s.game.GetDraft().GetMarket()
+	fmt.Fprintln(s.out, "Draft market:")
+	for i, card := range market {
+		fmt.Fprintf(s.out, "  %d. %s\n", i+1, describeCard(card))
+	}
+
+	for {
+		line, err := s.readLine(fmt.Sprintf("Pick an upgrade (1-%d)", len(market)))
+		if err != nil {
+			return engine.Action{}, err
+		}
+		if picks, err := parseNumbers(line, len(market)); err != nil || len(picks) != 1 {
+			fmt.Fprintln(s.out, "Type the number of one upgrade.")
+		} else {
+			return engine.Action{Type: engine.ActionDraft, Cards: picks}, nil
+		}
+	}
+}
+
+// promptPlan asks for a gear and then for the cards to play in it
+// Input: seat - the planning seat
+// Returns: the plan Action, an error if the input ends or the player quits
+func (s *session) promptPlan(seat int) (engine.Action, error) {
+	current := s.game.GetPlayers()[seat].GetCar().GetGear()
+	plans := s.game.LegalActions(seat)
+
+	for {
+		line, err := s.readLine(fmt.Sprintf("Gear (%d-%d, Enter stays in gear %d)", max(current-2, 1), min(current+2, 5), current))
+		if err != nil {
+			return engine.Action{}, err
+		}
+		gear := current
+		if line != "" {
+			if gear, err = strconv.Atoi(line); err != nil {
+				fmt.Fprintln(s.out, "Type a gear number.")
+				continue
+			}
+		}
+
+		// Every legal plan in a gear plays the same number of cards
+		count := -1
+		for _, plan := range plans {
+			if plan.Gear == gear {
+				count = len(plan.Cards)
+				break
+			}
+		}
+		switch count {
+		case -1:
+			fmt.Fprintf(s.out, "You cannot shift to gear %d.\n", gear)
+			continue
+		case 0:
+			return engine.Action{Type: engine.ActionPlan, Gear: gear, Cards: []int{}}, nil
+		}
+
+		cards, err := s.askCards(seat, fmt.Sprintf("Play %s (hand numbers, like 1 3)", countCards(count)), count)
+		if err != nil {
+			return engine.Action{}, err
+		}
+		return engine.Action{Type: engine.ActionPlan, Gear: gear, Cards: cards}, nil
+	}
+}
+
+// promptReact offers every legal reaction as a menu, or just confirms the move when there is no choice
+// Input: seat - the reacting seat
+// Returns: the react Action, an error if the input ends or the player quits
+func (s *session) promptReact(seat int) (engine.Action, error) {
+	reactions := s.game.LegalActions(seat)
+	speed := s.game.GetPlayers()[seat].GetCar().GetSpeed()
+	if len(reactions) == 1 {
+		_, err := s.readLine(fmt.Sprintf("Press Enter to move at speed %d", speed))
+		return reactions[0], err
+	}
+
+	fmt.Fprintf(s.out, "Your cards are revealed for speed %d. Choose how to react:\n", speed)
+	for i, reaction := range reactions {
+		fmt.Fprintf(s.out, "  %d. %s\n", i+1, s.describeReaction(seat, reaction))
+	}
+	for {
+		line, err := s.readLine(fmt.Sprintf("Reaction (1-%d, Enter for 1)", len(reactions)))
+		if err != nil {
+			return engine.Action{}, err
+		}
+		if line == "" {
+			return reactions[0], nil
+		}
+		if choice, err := parseNumbers(line, len(reactions)); err != nil || len(choice) != 1 {
+			fmt.Fprintln(s.out, "Type the number of one reaction.")
+		} else {
+			return reactions[choice[0]], nil
+		}
+	}
+}
+
+// promptSlipstream asks whether to slipstream
+// Input: seat - the seat that may slipstream
+// Returns: the slipstream Action, an error if the input ends or the player quits
+func (s *session) promptSlipstream(seat int) (engine.Action, error) {
+	board := s.game.GetBoard()
+	space, err := board.FindCar(s.game.GetPlayers()[seat].GetCar())
+	if err != nil {
+		return engine.Action{}, err
+	}
+
+	distance := engine.SlipstreamDistance(board, space)
+	for {
+		line, err := s.readLine(fmt.Sprintf("Slipstream %d spaces? (y/N)", distance))
+		if err != nil {
+			return engine.Action{}, err
+		}
+		switch strings.ToLower(line) {
+		case "y", "yes":
+			return engine.Action{Type: engine.ActionSlipstream, Slipstream: true}, nil
+		case "", "n", "no":
+			return engine.Action{Type: engine.ActionSlipstream, Slipstream: false}, nil
+		}
+		fmt.Fprintln(s.out, "Type y or n.")
+	}
+}
+
+// promptDiscard asks which cards to discard before the hand is refilled
+// Input: seat - the discarding seat
+// Returns: the discard Action, an error if the input ends or the player quits
+func (s *session) promptDiscard(seat int) (engine.Action, error) {
+	cards, err := s.askCards(seat, "Discard cards (hand numbers, Enter for none)", -1)
+	if err != nil {
+		return engine.Action{}, err
+	}
+	return engine.Action{Type: engine.ActionDiscard, Cards: cards}, nil
+}
+
+// askCards asks for hand cards by their numbers on screen
+// Input: seat - the seat at the keyboard
+//
+//	prompt - the question
+//	count - the number of cards wanted, -1 for any number
+//
+// Returns: the hand indexes, an error if the input ends or the player quits
+func (s *session) askCards(seat int, prompt string, count int) ([]int, error) {
+	size := s.game.GetPlayers()[seat].GetHand().Size()
+	for {
+		line, err := s.readLine(prompt)
+		if err != nil {
+			return nil, err
+		}
+		cards, err := parseNumbers(line, size)
+		switch {
+		case err != nil:
+			fmt.Fprintln(s.out, err)
+		case count >= 0 && len(cards) != count:
+			fmt.Fprintf(s.out, "Pick exactly %s.\n", countCards(count))
+		default:
+			return cards, nil
+		}
+	}
+}
+
+// describeReaction explains a react action: the Direct Play cards, the boost and the icons used
+// Input: seat - the reacting seat
+//
+//	action - the react action
+//
+// Returns: the description
+func (s *session) describeReaction(seat int, action engine.Action) string {
+	hand := s.game.GetPlayers()[seat].GetHand().GetCards()
+	parts := make([]string, 0, 3)
+	for _, index := range action.DirectPlay {
+		parts = append(parts, "play "+hand[index].GetName()+" directly")
+	}
+	if action.Boost {
+		board := s.game.GetBoard()
+		space, _ := board.FindCar(s.game.GetPlayers()[seat].GetCar())
+		parts = append(parts, fmt.Sprintf("boost for %d heat", engine.BoostCost(board, space)))
+	}
+	if len(action.Icons) > 0 {
+		names := make([]string, len(action.Icons))
+		for i, icon := range action.Icons {
+			names[i] = icon.String()
+		}
+		parts = append(parts, "use "+strings.Join(names, ", "))
+	}
+	if len(parts) == 0 {
+		return "Move without using anything"
+	}
+
+	description := strings.Join(parts, ", ")
+	return strings.ToUpper(description[:1]) + description[1:]
+}
+
+// parseNumbers reads the numbers a player typed for items listed from 1
+// Input: line - the typed line, numbers separated by spaces or commas
+//
+//	size - the number of items listed
+//
+// Returns: the zero-based indexes, an error if a number is not on the list or typed twice
+func parseNumbers(line string, size int) ([]int, error) {
+	fields := strings.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == ',' })
+	indexes := make([]int, 0, len(fields))
+	seen := make(map[int]bool, len(fields))
+	for _, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 1 || number > size {
+			return nil, fmt.Errorf("%s is not between 1 and %d", field, size)
+		}
+		if seen[number] {
+			return nil, fmt.Errorf("%d is typed twice", number)
+		}
+		seen[number] = true
+		indexes = append(indexes, number-1)
+	}
+	return indexes, nil
+}
+
+// countCards formats a number of cards, like "1 card" or "3 cards"
+// Input: count - the number of cards
+// Returns: the phrase
+func countCards(count int) string {
+	if count == 1 {
+		return "1 card"
+	}
+	return fmt.Sprintf("%d cards", count)
+}
@@ -0,0 +1,212 @@
+package hotseat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/render"
+)
+
+const (
+	clearScreen = "\033[H\033[2J"
+	reset       = "\033[0m"
+	bold        = "\033[1m"
+	dim         = "\033[2m"
+
+	// recentEvents is the number of events shown above a prompt at most
+	recentEvents = 12
+)
+
+// drawTurn shows the track, the standings, what happened since the seat's last turn and the seat's own cards
+// Input: seat - the seat about to be prompted
+// Returns: none
+func (s *session) drawTurn(seat int) {
+	fmt.Fprint(s.out, clearScreen)
+	config := s.game.GetConfig()
+	board := s.game.GetBoard()
+	fmt.Fprintf(s.out, "%s%s%s  Round %d  %s phase", bold, config.Track.Name, reset, s.game.GetRound(), s.game.GetPhase())
+	if weather := board.GetWeather(); weather.Name != "" {
+		fmt.Fprintf(s.out, "  Weather: %s", weather.Name)
+	}
+	fmt.Fprint(s.out, "\n\n")
+
+	render.WriteANSI(s.out, config.Track, board)
+	fmt.Fprintln(s.out)
+	s.drawStandings()
+
+	events := s.game.GetEvents()
+	if recent := events[max(s.seen[seat], len(events)-recentEvents):]; len(recent) > 0 {
+		fmt.Fprintf(s.out, "\n%sSince your last turn%s\n", bold, reset)
+		for _, event := range recent {
+			fmt.Fprintf(s.out, "  %s\n", s.describeEvent(event))
+		}
+	}
+	s.seen[seat] = len(events)
+
+	fmt.Fprintln(s.out)
+	s.drawPlayer(seat)
+	if s.message != "" {
+		fmt.Fprintf(s.out, "\n%s\n", render.Paint(string(models.Red), s.message))
+		s.message = ""
+	}
+	fmt.Fprintln(s.out)
+}
+
+// drawStandings lists the racers in race order with what everyone can see of their cars
+// Input: none
+// Returns: none
+func (s *session) drawStandings() {
+	board := s.game.GetBoard()
+	laps := board.GetNumberOfLaps()
+	players := make(map[models.Car]bool)
+	for _, player := range s.game.GetPlayers() {
+		players[player.GetCar()] = true
+	}
+
+	for i, racer := range board.OrderRacers(s.game.GetRacers()) {
+		car := racer.GetCar()
+		space, _ := board.FindCar(car)
+		fmt.Fprintf(s.out, "%d. %s %s  lap %d/%d  space %d", i+1, render.Paint(car.GetColor(), bold+render.GetCarLetter(car.GetColor())),
+			render.Paint(car.GetColor(), racer.GetName()), min(car.GetLap()+1, laps), laps, space)
+		if players[car] {
+			fmt.Fprintf(s.out, "  gear %d  engine %d", car.GetGear(), car.GetEngine())
+		}
+		fmt.Fprintln(s.out)
+	}
+}
+
+// drawPlayer shows a seat's car and cards; only the seat at the keyboard ever sees this
+// Input: seat - the seat at the keyboard
+// Returns: none
+func (s *session) drawPlayer(seat int) {
+	player := s.game.GetPlayers()[seat]
+	car := player.GetCar()
+	fmt.Fprintf(s.out, "%s  Gear %d  Engine %d heat  Speed %d\n",
+		render.Paint(car.GetColor(), bold+player.GetName()), car.GetGear(), car.GetEngine(), car.GetSpeed())
+
+	if played := player.GetPlayedCards(); len(played) > 0 {
+		fmt.Fprintf(s.out, "Played: %s\n", strings.Join(cardNames(played), ", "))
+	}
+	if icons := describeIcons(player.GetIcons()); icons != "" {
+		fmt.Fprintf(s.out, "Icons: %s\n", icons)
+	}
+
+	fmt.Fprintln(s.out, "Hand:")
+	for i, card := range player.GetHand().GetCards() {
+		fmt.Fprintf(s.out, "  %d. %s\n", i+1, describeCard(card))
+	}
+	fmt.Fprintf(s.out, "Deck %d cards, discard pile %d cards\n", player.GetDeck().Size(), player.GetDiscardPile().Size())
+}
+
+// drawResults shows the final standings
+// Input: none
+// Returns: none
+func (s *session) drawResults() {
+	fmt.Fprint(s.out, clearScreen)
+	config := s.game.GetConfig()
+	fmt.Fprintf(s.out, "%s%s is over%s\n\n", bold, config.Track.Name, reset)
+	render.WriteANSI(s.out, config.Track, s.game.GetBoard())
+	fmt.Fprintln(s.out)
+
+	racers := s.game.GetRacers()
+	for _, result := range s.game.GetResults() {
+		name := render.Paint(racers[result.Seat].GetCar().GetColor(), result.Name)
+		if result.Round > 0 {
+			fmt.Fprintf(s.out, "%d. %s, finished in round %d\n", result.Position, name, result.Round)
+		} else {
+			fmt.Fprintf(s.out, "%d. %s, did not finish\n", result.Position, name)
+		}
+	}
+}
+
+// describeEvent turns an event into a sentence
+// Input: event - the event
+// Returns: the sentence
+func (s *session) describeEvent(event engine.Event) string {
+	name := ""
+	if racers := s.game.GetRacers(); event.Seat >= 0 && event.Seat < len(racers) {
+		name = render.Paint(racers[event.Seat].GetCar().GetColor(), racers[event.Seat].GetName())
+	}
+
+	switch event.Type {
+	case engine.EventRoundStarted:
+		return fmt.Sprintf("Round %d starts", event.Value)
+	case engine.EventLegendCard:
+		return fmt.Sprintf("The Legends card shows speed %d", event.Value)
+	case engine.EventDrafted:
+		return fmt.Sprintf("%s drafts %s", name, strings.Join(event.Cards, ", "))
+	case engine.EventGearShifted:
+		return fmt.Sprintf("%s shifts to gear %d", name, event.Value)
+	case engine.EventCardsRevealed:
+		return fmt.Sprintf("%s reveals %s for speed %d", name, strings.Join(event.Cards, ", "), event.Value)
+	case engine.EventAdrenaline:
+		return fmt.Sprintf("%s gets adrenaline", name)
+	case engine.EventBoosted:
+		return fmt.Sprintf("%s boosts for %d more speed", name, event.Value)
+	case engine.EventCooled:
+		return fmt.Sprintf("%s cools %d heat", name, event.Value)
+	case engine.EventMoved:
+		return fmt.Sprintf("%s moves to space %d", name, event.Value)
+	case engine.EventSlipstreamed:
+		return fmt.Sprintf("%s slipstreams to space %d", name, event.Value)
+	case engine.EventHeatPaid:
+		return fmt.Sprintf("%s pays %d heat in a corner", name, event.Value)
+	case engine.EventSpunOut:
+		return fmt.Sprintf("%s spins out back to space %d", name, event.Value)
+	case engine.EventSponsored:
+		return fmt.Sprintf("%s earns a sponsor card at the corner on space %d", name, event.Value)
+	case engine.EventFinished:
+		return fmt.Sprintf("%s finishes in position %d", name, event.Value)
+	case engine.EventRaceFinished:
+		return fmt.Sprintf("The race ends in round %d", event.Value)
+	default:
+		return fmt.Sprintf("%s %s %d", name, event.Type, event.Value)
+	}
+}
+
+// describeCard names a card with its speed and icons
+// Input: card - the card
+// Returns: the description, like "Speed 3" or "Heat (cannot be played)"
+func describeCard(card models.Card) string {
+	description := card.GetName()
+	if icons := describeIcons(card.GetIcons()); icons != "" {
+		description += " [" + icons + "]"
+	}
+	if !card.IsPlayable() {
+		description += dim + " (cannot be played)" + reset
+	}
+	return description
+}
+
+// describeIcons lists icons with their counts in icon order
+// Input: icons - the icon counts
+// Returns: the list, like "Cooling x2", empty when there are none
+func describeIcons(icons map[models.Icon]int) string {
+	present := make([]models.Icon, 0, len(icons))
+	for icon, count := range icons {
+		if count > 0 {
+			present = append(present, icon)
+		}
+	}
+	sort.Slice(present, func(i, j int) bool { return present[i] < present[j] })
+
+	parts := make([]string, len(present))
+	for i, icon := range present {
+		parts[i] = fmt.Sprintf("%s x%d", icon, icons[icon])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// cardNames returns the names of cards
+// Input: cards - the cards
+// Returns: the names in order
+func cardNames(cards []models.Card) []string {
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = card.GetName()
+	}
+	return names
+}
@@ -0,0 +1,26 @@
+package hotseat
+
+import (
+	"testing"
+
+	"race-cars/internal/models"
+)
+
+func TestDescribeCard(t *testing.T) {
+	tests := []struct {
+		card models.Card
+		want string
+	}{
+		{models.NewCard("Speed 3", 3, nil, true, true, true), "Speed 3"},
+		{models.NewCard("Cooler", 0, map[models.Icon]int{models.IconCooling: 2, models.IconBoost: 0}, true, true, false), "Cooler [Cooling x2]"},
+		{models.NewHeatCard(), "Heat (cannot be played)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := stripANSI(describeCard(tt.card)); got != tt.want {
+				t.Errorf("describeCard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
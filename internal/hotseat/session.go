@@ -0,0 +1,203 @@
+package hotseat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"race-cars/internal/ai"
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/render"
+)
+
+// ErrQuit is returned by Run when a player types quit at a prompt
+var ErrQuit = errors.New("quit")
+
+// quitCommand ends the session at any prompt
+const quitCommand = "quit"
+
+// Config describes a hot-seat race
+type Config struct {
+	// Race is the race to play; its seats are filled from Players and Bots
+	Race engine.Config
+
+	// Players are the names of the people sharing the keyboard, in seat order
+	Players []string
+
+	// Bots are bot names for extra seats after the players, like "hard" or "mcts:100"
+	Bots []string
+}
+
+// Session runs a race in the terminal for players sharing one keyboard
+// The screen is cleared and the keyboard handed over between players, so nobody sees another player's hand
+type Session interface {
+	// Run plays the race to the end, prompting each player in turn
+	// Returns: ErrQuit if a player quits, an error if the input ends or the engine fails
+	Run() error
+
+	// GetGame returns the race being played
+	// Returns: the Game
+	GetGame() engine.Game
+}
+
+type session struct {
+	game   engine.Game
+	bots   []ai.Bot
+	humans int
+	in     *bufio.Reader
+	out    io.Writer
+
+	// shown is the seat whose hand is on screen, -1 when the screen shows no hand
+	shown int
+
+	// seen is the number of events each seat has been shown
+	seen []int
+
+	// message is an error to show with the next prompt
+	message string
+}
+
+// NewSession sets up a hot-seat race
+// Input: config - the race, players and bots
+//
+//	in - where the players type
+//	out - the terminal
+//
+// Returns: a new Session, an error if there are no players, too many seats or the race is invalid
+func NewSession(config Config, in io.Reader, out io.Writer) (Session, error) {
+	if len(config.Players) == 0 {
+		return nil, errors.New("hot-seat race needs at least 1 player")
+	}
+	if len(config.Players)+len(config.Bots) > engine.MaxSeats {
+		return nil, fmt.Errorf("race has room for %d seats", engine.MaxSeats)
+	}
+
+	colors := []models.Color{models.Red, models.Blue, models.Green, models.Yellow, models.Orange, models.Black}
+	race := config.Race
+	race.Seats = make([]engine.Seat, 0, len(config.Players)+len(config.Bots))
+	bots := make([]ai.Bot, 0, cap(race.Seats))
+	for _, name := range config.Players {
+		race.Seats = append(race.Seats, engine.Seat{Name: name, Color: colors[len(race.Seats)]})
+		bots = append(bots, nil)
+	}
+	for _, name := range config.Bots {
+		seat := len(race.Seats)
+		bot, err := ai.NewBot(name, race.Seed*engine.MaxSeats+int64(seat))
+		if err != nil {
+			return nil, err
+		}
+		race.Seats = append(race.Seats, engine.Seat{Name: fmt.Sprintf("%s bot", name), Color: colors[seat]})
+		bots = append(bots, bot)
+	}
+
+	game, err := engine.NewGame(race)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session{
+		game:   game,
+		bots:   bots,
+		humans: len(config.Players),
+		in:     bufio.NewReader(in),
+		out:    out,
+		shown:  -1,
+		seen:   make([]int, len(bots)),
+	}, nil
+}
+
+// GetGame returns the race being played
+func (s *session) GetGame() engine.Game {
+	return s.game
+}
+
+// Run plays the race to the end, prompting each player in turn
+func (s *session) Run() error {
+	for !s.game.IsFinished() {
+		seat := s.nextSeat()
+		if seat < 0 {
+			return errors.New("game is not waiting for any seat")
+		}
+
+		if bot := s.bots[seat]; bot != nil {
+			action, err := bot.Choose(s.game, seat)
+			if err != nil {
+				return err
+			}
+			if err := s.game.Submit(seat, action); err != nil {
+				return fmt.Errorf("%s chose an illegal action for seat %d: %w", bot.GetName(), seat, err)
+			}
+			continue
+		}
+
+		if seat != s.shown {
+			if err := s.handOver(seat); err != nil {
+				return err
+			}
+		}
+
+		s.drawTurn(seat)
+		action, err := s.prompt(seat)
+		if err != nil {
+			return err
+		}
+		if err := s.game.Submit(seat, action); err != nil {
+			s.message = err.Error()
+		}
+	}
+
+	s.drawResults()
+	return nil
+}
+
+// nextSeat returns the first seat the game is waiting for
+// Input: none
+// Returns: the seat index, -1 if the game is not waiting for anyone
+func (s *session) nextSeat() int {
+	for seat := range s.bots {
+		if s.game.IsWaitingFor(seat) {
+			return seat
+		}
+	}
+	return -1
+}
+
+// handOver clears the screen and waits for the next player to take the keyboard
+// With a single player there is nobody to hide the hand from, so the screen only changes
+// Input: seat - the seat taking over
+// Returns: an error if the input ends or the player quits
+func (s *session) handOver(seat int) error {
+	s.shown = seat
+	if s.humans == 1 {
+		return nil
+	}
+
+	racer := s.game.GetPlayers()[seat]
+	fmt.Fprint(s.out, clearScreen)
+	fmt.Fprintf(s.out, "Pass the keyboard to %s.\n\n", render.Paint(racer.GetCar().GetColor(), bold+racer.GetName()))
+	_, err := s.readLine(fmt.Sprintf("%s, press Enter when nobody else is looking", racer.GetName()))
+	return err
+}
+
+// readLine prompts and reads one line of input
+// Input: prompt - the question to show
+// Returns: the trimmed line, ErrQuit if the player typed quit, an error if the input ends
+func (s *session) readLine(prompt string) (string, error) {
+	fmt.Fprintf(s.out, "%s: ", prompt)
+	line, err := s.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+
+	line = strings.TrimSpace(line)
+	if strings.EqualFold(line, quitCommand) {
+		return "", ErrQuit
+	}
+	return line, nil
+}
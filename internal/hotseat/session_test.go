@@ -0,0 +1,259 @@
+package hotseat
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"race-cars/internal/ai"
+	"race-cars/internal/engine"
+	"race-cars/internal/tracks"
+)
+
+// maxTypedLines stops a test race that never ends
+const maxTypedLines = 5000
+
+func TestNewSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []string
+		bots    []string
+		wantErr bool
+	}{
+		{"solo", []string{"Ada"}, nil, false},
+		{"players and bots", []string{"Ada", "Brian"}, []string{"hard", "easy"}, false},
+		{"no players", nil, []string{"hard"}, true},
+		{"too many seats", []string{"A", "B", "C", "D"}, []string{"easy", "easy", "easy"}, true},
+		{"unknown bot", []string{"Ada"}, []string{"expert"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t, tt.players, tt.bots)
+			session, err := NewSession(config, strings.NewReader(""), io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(session.GetGame().GetPlayers()) != len(tt.players)+len(tt.bots) {
+				t.Errorf("game has %d players, want %d", len(session.GetGame().GetPlayers()), len(tt.players)+len(tt.bots))
+			}
+		})
+	}
+}
+
+func TestSession_Run(t *testing.T) {
+	for _, modules := range [][]string{nil, {engine.ModuleGarage}} {
+		t.Run(strings.Join(append([]string{"modules"}, modules...), " "), func(t *testing.T) {
+			config := createTestConfig(t, []string{"Ada", "Brian"}, []string{"normal"})
+			config.Race.Modules = modules
+			var out bytes.Buffer
+			typist := &typist{out: &out}
+			play, err := NewSession(config, typist, &out)
+			if err != nil {
+				t.Fatalf("NewSession() error = %v", err)
+			}
+			typist.session = play.(*session)
+
+			if err := play.Run(); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !play.GetGame().IsFinished() || len(play.GetGame().GetResults()) != 3 {
+				t.Fatal("Run() should play the race to the end")
+			}
+			screens := strings.Split(stripANSI(out.String()), clearScreen)
+			if !strings.Contains(screens[len(screens)-1], "USA is over") {
+				t.Errorf("last screen = %q, want the results", screens[len(screens)-1])
+			}
+		})
+	}
+}
+
+func TestSession_HidesHands(t *testing.T) {
+	config := createTestConfig(t, []string{"Ada", "Brian"}, nil)
+	var out bytes.Buffer
+	typist := &typist{out: &out}
+	play, err := NewSession(config, typist, &out)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	typist.session = play.(*session)
+	if err := play.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// Every hand-over screen shows no cards, and the screen after it only shows the new player's hand
+	screens := strings.Split(stripANSI(out.String()), clearScreen)
+	handOvers := 0
+	for i, screen := range screens {
+		name, ok := strings.CutPrefix(screen, "Pass the keyboard to ")
+		if !ok {
+			continue
+		}
+		handOvers++
+		name = name[:strings.Index(name, ".")]
+		if strings.Contains(screen, "Hand:") {
+			t.Fatalf("hand-over screen shows a hand: %q", screen)
+		}
+		if i+1 < len(screens) && strings.Contains(screens[i+1], "Hand:") && !strings.Contains(screens[i+1], "\n"+name+"  Gear") {
+			t.Fatalf("screen after handing over to %s shows another hand: %q", name, screens[i+1])
+		}
+	}
+	if handOvers < 2 {
+		t.Errorf("race handed the keyboard over %d times, want every round", handOvers)
+	}
+}
+
+func TestSession_Quit(t *testing.T) {
+	config := createTestConfig(t, []string{"Ada"}, []string{"easy"})
+	session, err := NewSession(config, strings.NewReader("5\nquit\n"), io.Discard)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if err := session.Run(); !errors.Is(err, ErrQuit) {
+		t.Errorf("Run() error = %v, want ErrQuit", err)
+	}
+
+	session, _ = NewSession(config, strings.NewReader(""), io.Discard)
+	if err := session.Run(); err == nil {
+		t.Error("Run() should fail when the input ends")
+	}
+}
+
+func TestParseNumbers(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []int
+		wantErr bool
+	}{
+		{"", []int{}, false},
+		{"1", []int{0}, false},
+		{"3 1,2", []int{2, 0, 1}, false},
+		{"0", nil, true},
+		{"5", nil, true},
+		{"two", nil, true},
+		{"2 2", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := parseNumbers(tt.line, 4)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNumbers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNumbers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// typist answers every prompt on the keyboard with what a bot would choose
+type typist struct {
+	session *session
+	out     *bytes.Buffer
+	bots    map[int]ai.Bot
+	plan    engine.Action
+	lines   int
+}
+
+// Read types the answer to the prompt on screen, one line per call
+func (t *typist) Read(p []byte) (int, error) {
+	t.lines++
+	if t.lines > maxTypedLines {
+		return 0, io.EOF
+	}
+	answer, err := t.answer()
+	if err != nil {
+		return 0, err
+	}
+
+	// A terminal echoes what is typed
+	t.out.WriteString(answer + "\n")
+	return copy(p, answer+"\n"), nil
+}
+
+// answer works out the line to type from the prompt on screen
+func (t *typist) answer() (string, error) {
+	screen := t.out.String()
+	prompt := screen[strings.LastIndex(screen, "\n")+1:]
+	seat := t.session.shown
+	game := t.session.game
+
+	if strings.Contains(prompt, "press Enter") || strings.HasPrefix(prompt, "Press Enter") {
+		return "", nil
+	}
+	if strings.HasPrefix(prompt, "Play ") {
+		return typeNumbers(t.plan.Cards), nil
+	}
+
+	if t.bots == nil {
+		t.bots = make(map[int]ai.Bot)
+	}
+	if t.bots[seat] == nil {
+		t.bots[seat], _ = ai.NewBot("normal", int64(seat))
+	}
+	action, err := t.bots[seat].Choose(game, seat)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case strings.HasPrefix(prompt, "Gear"):
+		t.plan = action
+		return strconv.Itoa(action.Gear), nil
+	case strings.HasPrefix(prompt, "Reaction"):
+		for i, reaction := range game.LegalActions(seat) {
+			if reflect.DeepEqual(reaction, action) {
+				return strconv.Itoa(i + 1), nil
+			}
+		}
+		return "", nil
+	case strings.HasPrefix(prompt, "Slipstream"):
+		if action.Slipstream {
+			return "y", nil
+		}
+		return "n", nil
+	case strings.HasPrefix(prompt, "Pick an upgrade"):
+		return strconv.Itoa(action.Cards[0] + 1), nil
+	default:
+		return typeNumbers(action.Cards), nil
+	}
+}
+
+// Helper function to type card indexes as the numbers shown on screen
+func typeNumbers(indexes []int) string {
+	numbers := make([]string, len(indexes))
+	for i, index := range indexes {
+		numbers[i] = strconv.Itoa(index + 1)
+	}
+	return strings.Join(numbers, " ")
+}
+
+// Helper function to remove the colors from terminal output, keeping the clear-screen code
+func stripANSI(text string) string {
+	parts := strings.Split(text, clearScreen)
+	colors := regexp.MustCompile("\033\\[[0-9;]*m")
+	for i, part := range parts {
+		parts[i] = colors.ReplaceAllString(part, "")
+	}
+	return strings.Join(parts, clearScreen)
+}
+
+// Helper function to create a one-lap hot-seat race
+func createTestConfig(t *testing.T, players []string, bots []string) Config {
+	t.Helper()
+	track, err := tracks.GetTrack("USA")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+	return Config{
+		Race:    engine.Config{Track: track, Laps: 1, Seed: 3},
+		Players: players,
+		Bots:    bots,
+	}
+}
@@ -0,0 +1,107 @@
+package legends
+
+import (
+	"math/rand"
+)
+
+// Card is a Legends card: one is flipped each round and moves every Legend
+type Card struct {
+	// Speed is how far a Legend moves on a straight, before its level is added
+	Speed int `json:"speed"`
+
+	// Bonus is how many spaces over the speed limit a Legend takes a corner
+	Bonus int `json:"bonus"`
+}
+
+// cards is the Legends deck
+var cards = []Card{
+	{Speed: 8, Bonus: 0},
+	{Speed: 9, Bonus: 0},
+	{Speed: 9, Bonus: 1},
+	{Speed: 10, Bonus: 0},
+	{Speed: 10, Bonus: 1},
+	{Speed: 10, Bonus: 2},
+	{Speed: 11, Bonus: 1},
+	{Speed: 11, Bonus: 2},
+	{Speed: 12, Bonus: 1},
+	{Speed: 12, Bonus: 2},
+}
+
+// GetCards returns the cards of the Legends deck
+// Input: none
+// Returns: a copy of every card in the deck
+func GetCards() []Card {
+	result := make([]Card, len(cards))
+	copy(result, cards)
+	return result
+}
+
+// Deck is the shuffled Legends deck
+// The whole deck is reshuffled once every card has been flipped
+type Deck interface {
+	// Draw flips the next card
+	// Returns: the flipped Card
+	Draw() Card
+
+	// GetRemaining returns the number of cards left before the next reshuffle
+	// Returns: the number of cards
+	GetRemaining() int
+
+	// Clone copies the deck with the cards still to be flipped
+	// Input: rng - the random source the copy shuffles with
+	// Returns: a new Deck
+	Clone(rng *rand.Rand) Deck
+}
+
+type deck struct {
+	cards []Card
+	next  int
+	rng   *rand.Rand
+}
+
+// NewDeck creates a shuffled Legends deck
+// Input: rng - the random source used for every shuffle
+// Returns: a new Deck
+func NewDeck(rng *rand.Rand) Deck {
+	d := &deck{cards: GetCards(), rng: rng}
+	d.shuffle()
+	return d
+}
+
+// Draw flips the next card
+// Input: none
+// Returns: the flipped Card
+func (d *deck) Draw() Card {
+	if d.next >= len(d.cards) {
+		d.shuffle()
+	}
+	card := d.cards[d.next]
+	d.next++
+	return card
+}
+
+// GetRemaining returns the number of cards left before the next reshuffle
+// Input: none
+// Returns: the number of cards
+func (d *deck) GetRemaining() int {
+	return len(d.cards) - d.next
+}
+
+// shuffle puts every card back and shuffles the deck
+// Input: none
+// Returns: none
+func (d *deck) shuffle() {
+	d.rng.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	})
+	d.next = 0
+}
+
+// Clone copies the deck with the cards still to be flipped
+// Input: rng - the random source the copy shuffles with
+// Returns: a new Deck
+func (d *deck) Clone(rng *rand.Rand) Deck {
+	cards := make([]Card, len(d.cards))
+	copy(cards, d.cards)
+	return &deck{cards: cards, next: d.next, rng: rng}
+}
@@ -0,0 +1,61 @@
+package legends
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestNewDeck(t *testing.T) {
+	deck := NewDeck(rand.New(rand.NewSource(3)))
+	again := NewDeck(rand.New(rand.NewSource(3)))
+
+	if deck.GetRemaining() != len(GetCards()) {
+		t.Errorf("GetRemaining() = %d, want %d", deck.GetRemaining(), len(GetCards()))
+	}
+
+	drawn := make([]Card, 0)
+	for i := 0; i < len(GetCards()); i++ {
+		card := deck.Draw()
+		if card != again.Draw() {
+			t.Fatal("Decks with the same seed should flip the same cards")
+		}
+		drawn = append(drawn, card)
+	}
+	if deck.GetRemaining() != 0 {
+		t.Errorf("GetRemaining() = %d after every card, want 0", deck.GetRemaining())
+	}
+
+	// Every card is flipped exactly once before the reshuffle
+	counts := make(map[Card]int)
+	for _, card := range drawn {
+		counts[card]++
+	}
+	expected := make(map[Card]int)
+	for _, card := range GetCards() {
+		expected[card]++
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Flipped cards = %v, want %v", counts, expected)
+	}
+
+	deck.Draw()
+	if deck.GetRemaining() != len(GetCards())-1 {
+		t.Errorf("GetRemaining() after the reshuffle = %d, want %d", deck.GetRemaining(), len(GetCards())-1)
+	}
+}
+
+func TestDeck_Clone(t *testing.T) {
+	original := NewDeck(rand.New(rand.NewSource(1)))
+	original.Draw()
+
+	clone := original.Clone(rand.New(rand.NewSource(2)))
+	if clone.GetRemaining() != original.GetRemaining() {
+		t.Fatalf("Clone() has %d cards remaining, want %d", clone.GetRemaining(), original.GetRemaining())
+	}
+	for clone.GetRemaining() > 0 {
+		if clone.Draw() != original.Draw() {
+			t.Fatal("the clone should flip the same cards until the next reshuffle")
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package legends
+
+import (
+	"errors"
+	"fmt"
+
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+const (
+	// MaxLevel is the hardest Legend level, each level adds one space on straights
+	MaxLevel = 3
+
+	// DefaultLineDistance places the Legend line this many spaces before a corner on tracks without markers
+	DefaultLineDistance = 3
+)
+
+// Driver describes a Legend taking part in a race
+type Driver struct {
+	Name  string       `json:"name"`
+	Color models.Color `json:"color"`
+	Level int          `json:"level"`
+}
+
+// Legend is an automated driver from the solo rules
+// A Legend has no hand or deck: it moves by the Legends card flipped for the round
+type Legend interface {
+	models.Racer
+
+	// GetLevel returns the Legend's level
+	// Returns: the level, from 0 to MaxLevel
+	GetLevel() int
+
+	// Move drives the Legend's car for the round
+	// Before the Legend line of the next corner the car moves the card's speed plus its level, stopping short of the corner;
+	// from the line on it takes the corner at its speed limit plus the card's bonus
+	// Input: board - the board the car is on
+	//
+	//	lines - the Legend line of every corner, by corner space
+	//	card - the Legends card flipped this round
+	//
+	// Returns: the space the car ended on, an error if the car is not on the board
+	Move(board models.Board, lines map[int]int, card Card) (int, error)
+
+	// Clone copies the Legend with another car, used when the board is copied
+	// Input: car - the copy of the Legend's car
+	// Returns: a new Legend driving car
+	Clone(car models.Car) Legend
+}
+
+type legend struct {
+	name  string
+	car   models.Car
+	level int
+}
+
+// NewLegend creates a Legend and its car
+// Legends never pay heat, so their car has an empty engine
+// Input: driver - the Legend's name, color and level
+// Returns: a new Legend, an error if the driver is invalid
+func NewLegend(driver Driver) (Legend, error) {
+	if driver.Name == "" {
+		return nil, errors.New("legend needs a name")
+	}
+	if driver.Level < 0 || driver.Level > MaxLevel {
+		return nil, fmt.Errorf("legend level must be between 0 and %d", MaxLevel)
+	}
+
+	return &legend{
+		name:  driver.Name,
+		car:   models.NewCar(string(driver.Color), 0),
+		level: driver.Level,
+	}, nil
+}
+
+// GetLines returns the Legend line of every corner on a track
+// Corners without a marker get one DefaultLineDistance spaces before them, but never on or before the previous corner
+// Input: track - the track the race is run on
+// Returns: a map of corner spaces to Legend line spaces
+func GetLines(track tracks.Track) map[int]int {
+	lines := make(map[int]int, len(track.Corners))
+	previous := 0
+	for _, corner := range track.Corners {
+		line := corner.LegendLine
+		if line == 0 {
+			line = max(corner.Space-DefaultLineDistance, previous+1)
+		}
+		lines[corner.Space] = line
+		previous = corner.Space
+	}
+	return lines
+}
+
+// GetName returns the Legend's name
+// Input: none
+// Returns: the Legend's name as a string
+func (l *legend) GetName() string {
+	return l.name
+}
+
+// GetCar returns the Legend's car
+// Input: none
+// Returns: the Legend's car instance
+func (l *legend) GetCar() models.Car {
+	return l.car
+}
+
+// GetLevel returns the Legend's level
+// Input: none
+// Returns: the level, from 0 to MaxLevel
+func (l *legend) GetLevel() int {
+	return l.level
+}
+
+// Move drives the Legend's car for the round
+// Input: board - the board the car is on
+//
+//	lines - the Legend line of every corner, by corner space
+//	card - the Legends card flipped this round
+//
+// Returns: the space the car ended on, an error if the car is not on the board
+func (l *legend) Move(board models.Board, lines map[int]int, card Card) (int, error) {
+	from, err := board.FindCar(l.car)
+	if err != nil {
+		return 0, err
+	}
+
+	distance := card.Speed + l.level
+	corner, toCorner := nextCorner(board.GetSpaces(), from)
+	if corner >= 0 {
+		toLine := (lines[corner] - from + len(board.GetSpaces())) % len(board.GetSpaces())
+		if toLine == 0 || toLine > toCorner {
+			distance = toCorner + board.GetCornerLimit(corner) + card.Bonus
+		} else {
+			distance = min(distance, toCorner-1)
+		}
+	}
+
+	l.car.SetSpeed(distance)
+	to, err := board.MoveCar(l.car, distance)
+	l.car.ResetPassedCorners()
+	return to, err
+}
+
+// nextCorner finds the first corner ahead of a space
+// Input: spaces - the spaces of the board
+//
+//	from - the index of the space to look ahead from
+//
+// Returns: the index of the corner space and the distance to it, -1 and 0 if the track has no corners
+func nextCorner(spaces []models.Space, from int) (int, int) {
+	for distance := 1; distance <= len(spaces); distance++ {
+		index := (from + distance) % len(spaces)
+		if spaces[index].GetCorner() > 0 {
+			return index, distance
+		}
+	}
+	return -1, 0
+}
+
+// Clone copies the Legend with another car, used when the board is copied
+// Input: car - the copy of the Legend's car
+// Returns: a new Legend driving car
+func (l *legend) Clone(car models.Car) Legend {
+	return &legend{name: l.name, car: car, level: l.level}
+}
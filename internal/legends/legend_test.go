@@ -0,0 +1,162 @@
+package legends
+
+import (
+	"reflect"
+	"testing"
+
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// Helper function to create a board with one corner on space 10 and its Legend line on space 6
+func createTestBoard(t *testing.T) (models.Board, map[int]int) {
+	track := tracks.Track{
+		Name:    "Test",
+		Length:  30,
+		Laps:    2,
+		Corners: []tracks.Corner{{Space: 10, SpeedLimit: 3, LegendLine: 6}},
+	}
+	board, err := track.NewBoard(0)
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	return board, GetLines(track)
+}
+
+func TestNewLegend(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  Driver
+		wantErr bool
+	}{
+		{name: "Valid legend", driver: Driver{Name: "Legend", Color: models.Black, Level: 2}},
+		{name: "No name", driver: Driver{Color: models.Black}, wantErr: true},
+		{name: "Level too high", driver: Driver{Name: "Legend", Color: models.Black, Level: MaxLevel + 1}, wantErr: true},
+		{name: "Negative level", driver: Driver{Name: "Legend", Color: models.Black, Level: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legend, err := NewLegend(tt.driver)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewLegend() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var _ models.Racer = legend
+			if legend.GetName() != tt.driver.Name || legend.GetLevel() != tt.driver.Level {
+				t.Errorf("NewLegend() = %s level %d, want %s level %d", legend.GetName(), legend.GetLevel(), tt.driver.Name, tt.driver.Level)
+			}
+			if legend.GetCar().GetColor() != string(tt.driver.Color) {
+				t.Errorf("Car color = %s, want %s", legend.GetCar().GetColor(), tt.driver.Color)
+			}
+		})
+	}
+}
+
+func TestGetLines(t *testing.T) {
+	track := tracks.Track{
+		Name:   "Test",
+		Length: 30,
+		Laps:   1,
+		Corners: []tracks.Corner{
+			{Space: 8, SpeedLimit: 3, LegendLine: 2},
+			{Space: 10, SpeedLimit: 3},
+			{Space: 20, SpeedLimit: 3},
+		},
+	}
+
+	expected := map[int]int{8: 2, 10: 9, 20: 17}
+	if lines := GetLines(track); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("GetLines() = %v, want %v", lines, expected)
+	}
+}
+
+func TestLegend_Move(t *testing.T) {
+	tests := []struct {
+		name          string
+		start         int
+		level         int
+		card          Card
+		expectedSpace int
+		expectedLap   int
+	}{
+		{
+			name:          "Stops short of the corner before the line",
+			start:         2,
+			card:          Card{Speed: 9, Bonus: 1},
+			expectedSpace: 9,
+		},
+		{
+			name:          "Short move before the line",
+			start:         0,
+			card:          Card{Speed: 4, Bonus: 1},
+			expectedSpace: 4,
+		},
+		{
+			name:          "Takes the corner from the line",
+			start:         6,
+			card:          Card{Speed: 9, Bonus: 1},
+			expectedSpace: 14,
+		},
+		{
+			name:          "Takes the corner past the line",
+			start:         9,
+			card:          Card{Speed: 9, Bonus: 2},
+			expectedSpace: 15,
+		},
+		{
+			name:          "Level adds speed on straights",
+			start:         20,
+			level:         2,
+			card:          Card{Speed: 9, Bonus: 1},
+			expectedSpace: 1,
+			expectedLap:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, lines := createTestBoard(t)
+			legend, _ := NewLegend(Driver{Name: "Legend", Color: models.Black, Level: tt.level})
+			if err := board.PlaceCar(legend.GetCar(), tt.start); err != nil {
+				t.Fatalf("PlaceCar() error = %v", err)
+			}
+
+			to, err := legend.Move(board, lines, tt.card)
+			if err != nil {
+				t.Fatalf("Move() error = %v", err)
+			}
+			if to != tt.expectedSpace {
+				t.Errorf("Move() = space %d, want %d", to, tt.expectedSpace)
+			}
+			if legend.GetCar().GetLap() != tt.expectedLap {
+				t.Errorf("Lap = %d, want %d", legend.GetCar().GetLap(), tt.expectedLap)
+			}
+			if len(legend.GetCar().GetPassedCorners()) != 0 {
+				t.Error("Legends never pay for corners, passed corners should be cleared")
+			}
+		})
+	}
+
+	legend, _ := NewLegend(Driver{Name: "Legend", Color: models.Black})
+	board, lines := createTestBoard(t)
+	if _, err := legend.Move(board, lines, Card{Speed: 9}); err == nil {
+		t.Error("Move() with a car that is not on the board should fail")
+	}
+}
+
+func TestLegend_Clone(t *testing.T) {
+	original, err := NewLegend(Driver{Name: "Legend", Color: models.Green, Level: 2})
+	if err != nil {
+		t.Fatalf("NewLegend() error = %v", err)
+	}
+
+	car := original.GetCar().Clone()
+	clone := original.Clone(car)
+	if clone.GetName() != "Legend" || clone.GetLevel() != 2 || clone.GetCar() != car {
+		t.Errorf("Clone() = %s level %d, want Legend level 2 driving the copied car", clone.GetName(), clone.GetLevel())
+	}
+}
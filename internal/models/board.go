@@ -1,5 +1,10 @@
 package models
 
+import (
+	"fmt"
+	"sort"
+)
+
 // Board represents a racing board with spaces and turn management
 // The board manages the physical layout of the race track and the turn order of racers
 type Board interface {
@@ -18,12 +23,84 @@ type Board interface {
 	// GetNextRacer returns the next racer in turn order and removes them from the queue
 	// Returns: the next car to take their turn
 	GetNextRacer() Car
+
+	// GetNumberOfLaps returns the number of laps required to win the race
+	// Returns: the number of laps
+	GetNumberOfLaps() int
+
+	// GetWeather returns the weather tile for the race
+	// Returns: the weather, the zero value for clear weather
+	GetWeather() Weather
+
+	// SetWeather sets the weather tile for the race
+	// Input: weather - the weather tile
+	SetWeather(weather Weather)
+
+	// GetSectors returns the straight sectors between corners
+	// Returns: a copy of the sectors, sector i starts after the i-th corner
+	GetSectors() []Sector
+
+	// GetSectorIndex returns the sector a space belongs to
+	// Input: space - the index of the space
+	// Returns: the index of the sector, -1 if the space is not on the board
+	GetSectorIndex(space int) int
+
+	// SetSectorCondition places a road-condition token on a sector
+	// Input: sector - the index of the sector
+	//	condition - the road condition
+	// Returns: an error if the sector does not exist
+	SetSectorCondition(sector int, condition RoadCondition) error
+
+	// GetCornerLimit returns the speed limit of a corner including its road condition
+	// Input: space - the index of the corner space
+	// Returns: the speed limit, 0 if the space is not a corner
+	GetCornerLimit(space int) int
+
+	// FindCar returns the index of the space a car is on
+	// Input: car - the car to find
+	// Returns: the index of the space, an error if the car is not on the board
+	FindCar(car Car) (int, error)
+
+	// PlaceCar puts a car on a space, used to set up the starting grid
+	// Input: car - the car to place
+	//	space - the index of the space
+	// Returns: an error if the space does not exist or is full
+	PlaceCar(car Car, space int) error
+
+	// MoveCar moves a car forward, recording the corners it passes and the laps it completes
+	// Input: car - the car to move
+	//	distance - the number of spaces to move
+	// Returns: the index of the space the car ends on, an error if the car is not on the board
+	MoveCar(car Car, distance int) (int, error)
+
+	// SpinOut moves a car back to the first free space before a corner
+	// Input: car - the car that spun out
+	//	corner - the index of the corner space
+	// Returns: the index of the space the car ends on, an error if the car is not on the board
+	SpinOut(car Car, corner int) (int, error)
+
+	// GetRanking returns the cars ordered by race position
+	// Cars are ordered by lap, then by space, then by lane, leader first
+	// Returns: slice of cars in race order
+	GetRanking() []Car
+
+	// OrderRacers sorts racers into turn order by the race position of their cars
+	// Input: racers - the racers to order, human players and automated drivers alike
+	// Returns: a new slice of racers, leader first, with racers whose car is not on the board last
+	OrderRacers(racers []Racer) []Racer
+
+	// Clone copies the board with its spaces, weather, road conditions and turn order
+	// Input: cars - maps the original cars to their copies, cars missing from it are cloned and added
+	// Returns: a new Board holding the copied cars
+	Clone(cars map[Car]Car) Board
 }
 
 type board struct {
 	spaces         []Space
 	racerTurnOrder []Car
 	numberOfLaps   int
+	weather        Weather
+	sectors        []Sector
 }
 
 // NewBoard creates a new board instance
@@ -37,6 +114,7 @@ func NewBoard(spaces []Space, numberOfLaps int) Board {
 		spaces:         spaces,
 		racerTurnOrder: make([]Car, 0),
 		numberOfLaps:   numberOfLaps,
+		sectors:        buildSectors(spaces),
 	}
 }
 
@@ -81,6 +159,273 @@ func (b *board) GetNextRacer() Car {
 	return nextRacer
 }
 
+// GetNumberOfLaps returns the number of laps required to win the race
+// Input: none
+// Returns: the number of laps
+func (b *board) GetNumberOfLaps() int {
+	return b.numberOfLaps
+}
+
+// GetWeather returns the weather tile for the race
+// Input: none
+// Returns: the weather, the zero value for clear weather
+func (b *board) GetWeather() Weather {
+	return b.weather
+}
+
+// SetWeather sets the weather tile for the race
+// Input: weather - the weather tile
+// Returns: none
+func (b *board) SetWeather(weather Weather) {
+	b.weather = weather
+}
+
+// GetSectors returns the straight sectors between corners
+// Input: none
+// Returns: a copy of the sectors, sector i starts after the i-th corner
+func (b *board) GetSectors() []Sector {
+	result := make([]Sector, len(b.sectors))
+	copy(result, b.sectors)
+	return result
+}
+
+// GetSectorIndex returns the sector a space belongs to
+// Input: space - the index of the space
+// Returns: the index of the sector, -1 if the space is not on the board
+func (b *board) GetSectorIndex(space int) int {
+	if space < 0 || space >= len(b.spaces) {
+		return -1
+	}
+
+	for i, sector := range b.sectors {
+		if sector.Start <= sector.End {
+			if space >= sector.Start && space <= sector.End {
+				return i
+			}
+		} else if space >= sector.Start || space <= sector.End {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// SetSectorCondition places a road-condition token on a sector
+// Input: sector - the index of the sector
+//
+//	condition - the road condition
+//
+// Returns: an error if the sector does not exist
+func (b *board) SetSectorCondition(sector int, condition RoadCondition) error {
+	if sector < 0 || sector >= len(b.sectors) {
+		return fmt.Errorf("sector %d does not exist", sector)
+	}
+	b.sectors[sector].Condition = condition
+	return nil
+}
+
+// GetCornerLimit returns the speed limit of a corner including its road condition
+// A road condition can never lower a speed limit below 1
+// Input: space - the index of the corner space
+// Returns: the speed limit, 0 if the space is not a corner
+func (b *board) GetCornerLimit(space int) int {
+	if space < 0 || space >= len(b.spaces) || b.spaces[space].GetCorner() <= 0 {
+		return 0
+	}
+
+	limit := b.spaces[space].GetCorner() + b.spaces[space].GetRoadCondition().SpeedLimitModifier
+	if limit < 1 {
+		return 1
+	}
+	return limit
+}
+
+// FindCar returns the index of the space a car is on
+// Input: car - the car to find
+// Returns: the index of the space, an error if the car is not on the board
+func (b *board) FindCar(car Car) (int, error) {
+	for i, space := range b.spaces {
+		for _, c := range space.GetCars() {
+			if c == car {
+				return i, nil
+			}
+		}
+	}
+	return -1, fmt.Errorf("car not on board")
+}
+
+// PlaceCar puts a car on a space, used to set up the starting grid
+// Input: car - the car to place
+//
+//	space - the index of the space
+//
+// Returns: an error if the space does not exist or is full
+func (b *board) PlaceCar(car Car, space int) error {
+	if space < 0 || space >= len(b.spaces) {
+		return fmt.Errorf("space %d does not exist", space)
+	}
+	return b.spaces[space].AddCar(car)
+}
+
+// MoveCar moves a car forward, recording the corners it passes and the laps it completes
+// If the destination space is full the car stops on the first free space behind it
+// Input: car - the car to move
+//
+//	distance - the number of spaces to move
+//
+// Returns: the index of the space the car ends on, an error if the car is not on the board
+func (b *board) MoveCar(car Car, distance int) (int, error) {
+	from, err := b.FindCar(car)
+	if err != nil {
+		return -1, err
+	}
+
+	moved := 0
+	for d := distance; d > 0; d-- {
+		if !b.spaces[(from+d)%len(b.spaces)].IsFull() {
+			moved = d
+			break
+		}
+	}
+	if moved == 0 {
+		return from, nil
+	}
+
+	to := (from + moved) % len(b.spaces)
+	if err := b.spaces[from].RemoveCar(car); err != nil {
+		return -1, err
+	}
+	if err := b.spaces[to].AddCar(car); err != nil {
+		return -1, err
+	}
+
+	for d := 1; d <= moved; d++ {
+		index := (from + d) % len(b.spaces)
+		if b.spaces[index].GetCorner() > 0 {
+			car.AddPassedCorner(index)
+		}
+		if b.spaces[index].IsFinishLine() {
+			car.IncreaseLap()
+		}
+	}
+
+	return to, nil
+}
+
+// SpinOut moves a car back to the first free space before a corner
+// The lap counter is decreased if the car goes back across the finish line
+// Input: car - the car that spun out
+//
+//	corner - the index of the corner space
+//
+// Returns: the index of the space the car ends on, an error if the car is not on the board
+func (b *board) SpinOut(car Car, corner int) (int, error) {
+	from, err := b.FindCar(car)
+	if err != nil {
+		return -1, err
+	}
+	if corner < 0 || corner >= len(b.spaces) {
+		return -1, fmt.Errorf("space %d does not exist", corner)
+	}
+	if err := b.spaces[from].RemoveCar(car); err != nil {
+		return -1, err
+	}
+
+	// Undo the laps completed between the corner and the car's position
+	for index := from; index != corner; index = (index - 1 + len(b.spaces)) % len(b.spaces) {
+		if b.spaces[index].IsFinishLine() {
+			car.DecreaseLap()
+		}
+	}
+
+	index := corner
+	for i := 0; i < len(b.spaces); i++ {
+		if b.spaces[index].IsFinishLine() {
+			car.DecreaseLap()
+		}
+		index = (index - 1 + len(b.spaces)) % len(b.spaces)
+		if !b.spaces[index].IsFull() {
+			break
+		}
+	}
+
+	if err := b.spaces[index].AddCar(car); err != nil {
+		return -1, err
+	}
+	return index, nil
+}
+
+// GetRanking returns the cars ordered by race position
+// Cars are ordered by lap, then by space, then by lane, leader first
+// Input: none
+// Returns: slice of cars in race order
+func (b *board) GetRanking() []Car {
+	ranking := make([]Car, 0)
+	for i := len(b.spaces) - 1; i >= 0; i-- {
+		ranking = append(ranking, b.spaces[i].GetCars()...)
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].GetLap() > ranking[j].GetLap()
+	})
+	return ranking
+}
+
+// OrderRacers sorts racers into turn order by the race position of their cars
+// Input: racers - the racers to order, human players and automated drivers alike
+// Returns: a new slice of racers, leader first, with racers whose car is not on the board last
+func (b *board) OrderRacers(racers []Racer) []Racer {
+	positions := make(map[Car]int)
+	for i, car := range b.GetRanking() {
+		positions[car] = i
+	}
+
+	position := func(racer Racer) int {
+		if index, ok := positions[racer.GetCar()]; ok {
+			return index
+		}
+		return len(positions)
+	}
+
+	result := make([]Racer, len(racers))
+	copy(result, racers)
+	sort.SliceStable(result, func(i, j int) bool {
+		return position(result[i]) < position(result[j])
+	})
+	return result
+}
+
+// buildSectors splits the track into straight sectors between corner spaces
+// Sector i runs from the space after the i-th corner to the next corner's space
+// A track without corners is a single sector
+// Input: spaces - the spaces of the board
+// Returns: a slice of Sectors
+func buildSectors(spaces []Space) []Sector {
+	corners := make([]int, 0)
+	for i, space := range spaces {
+		if space.GetCorner() > 0 {
+			corners = append(corners, i)
+		}
+	}
+
+	if len(spaces) == 0 {
+		return make([]Sector, 0)
+	}
+	if len(corners) == 0 {
+		return []Sector{{Start: 0, End: len(spaces) - 1}}
+	}
+
+	sectors := make([]Sector, len(corners))
+	for i, corner := range corners {
+		next := corners[(i+1)%len(corners)]
+		sectors[i] = Sector{
+			Start: (corner + 1) % len(spaces),
+			End:   next,
+		}
+	}
+	return sectors
+}
+
 // insertRacerInTurnOrder inserts a car into the turn order based on lap count
 // Cars with higher lap counts go first, cars with same lap count maintain relative order
 // Input: b - the board instance
@@ -101,3 +446,59 @@ func insertRacerInTurnOrder(b *board, car Car) {
 		}
 	}
 }
+
+// Clone copies the board with its spaces, weather, road conditions and turn order
+// Input: cars - maps the original cars to their copies, cars missing from it are cloned and added
+// Returns: a new Board holding the copied cars
+func (b *board) Clone(cars map[Car]Car) Board {
+	clone := func(car Car) Car {
+		if copied, ok := cars[car]; ok {
+			return copied
+		}
+		copied := car.Clone()
+		cars[car] = copied
+		return copied
+	}
+
+	// Spaces are allocated in one block and linked by index, so the copy keeps the track's loop
+	index := make(map[Space]int, len(b.spaces))
+	for i, s := range b.spaces {
+		index[s] = i
+	}
+	block := make([]space, len(b.spaces))
+	spaces := make([]Space, len(b.spaces))
+	for i, s := range b.spaces {
+		occupants := s.GetCars()
+		block[i] = space{
+			cars:          occupants,
+			corner:        s.GetCorner(),
+			finishLine:    s.IsFinishLine(),
+			roadCondition: s.GetRoadCondition(),
+		}
+		for j, car := range occupants {
+			occupants[j] = clone(car)
+		}
+		spaces[i] = &block[i]
+	}
+	for i, s := range b.spaces {
+		if next, ok := index[s.GetNext()]; ok {
+			block[i].next = spaces[next]
+		}
+		if previous, ok := index[s.GetPrevious()]; ok {
+			block[i].previous = spaces[previous]
+		}
+	}
+
+	order := make([]Car, len(b.racerTurnOrder))
+	for i, car := range b.racerTurnOrder {
+		order[i] = clone(car)
+	}
+
+	return &board{
+		spaces:         spaces,
+		racerTurnOrder: order,
+		numberOfLaps:   b.numberOfLaps,
+		weather:        b.weather,
+		sectors:        b.GetSectors(),
+	}
+}
@@ -269,3 +269,337 @@ func BenchmarkBoardOperations_Complete(b *testing.B) {
 		}
 	}
 }
+
+// Helper function to create a looped board for movement tests
+// Space 0 is the finish line and corners maps space indexes to speed limits
+func createLoopBoard(length int, corners map[int]int, laps int) Board {
+	spaces := make([]Space, length)
+	for i := range spaces {
+		spaces[i] = NewSpace(nil, nil, corners[i], i == 0)
+	}
+	return NewBoard(spaces, laps)
+}
+
+func TestBoard_PlaceCarAndFindCar(t *testing.T) {
+	board := createLoopBoard(10, nil, 1)
+	car := NewCar("red", 6)
+
+	if _, err := board.FindCar(car); err == nil {
+		t.Error("FindCar() should fail for a car that is not on the board")
+	}
+	if err := board.PlaceCar(car, 3); err != nil {
+		t.Fatalf("PlaceCar() error = %v", err)
+	}
+	if index, err := board.FindCar(car); err != nil || index != 3 {
+		t.Errorf("FindCar() = %d, %v, want 3", index, err)
+	}
+	if err := board.PlaceCar(NewCar("blue", 6), 10); err == nil {
+		t.Error("PlaceCar() off the board should fail")
+	}
+}
+
+func TestBoard_MoveCar(t *testing.T) {
+	tests := []struct {
+		name            string
+		start           int
+		distance        int
+		blocked         []int
+		expectedSpace   int
+		expectedCorners []int
+		expectedLap     int
+	}{
+		{
+			name:          "Move along a straight",
+			start:         1,
+			distance:      2,
+			expectedSpace: 3,
+		},
+		{
+			name:            "Pass a corner",
+			start:           2,
+			distance:        4,
+			expectedSpace:   6,
+			expectedCorners: []int{5},
+		},
+		{
+			name:            "Stop behind a full space",
+			start:           2,
+			distance:        4,
+			blocked:         []int{6},
+			expectedSpace:   5,
+			expectedCorners: []int{5},
+		},
+		{
+			name:          "Cross the finish line",
+			start:         8,
+			distance:      3,
+			expectedSpace: 1,
+			expectedLap:   1,
+		},
+		{
+			name:          "Zero distance",
+			start:         4,
+			distance:      0,
+			expectedSpace: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := createLoopBoard(10, map[int]int{5: 3}, 2)
+			for _, index := range tt.blocked {
+				board.PlaceCar(NewCar("gray", 0), index)
+				board.PlaceCar(NewCar("black", 0), index)
+			}
+			car := NewCar("red", 6)
+			board.PlaceCar(car, tt.start)
+
+			index, err := board.MoveCar(car, tt.distance)
+			if err != nil {
+				t.Fatalf("MoveCar() error = %v", err)
+			}
+			if index != tt.expectedSpace {
+				t.Errorf("MoveCar() = %d, want %d", index, tt.expectedSpace)
+			}
+			if !reflect.DeepEqual(car.GetPassedCorners(), append([]int{}, tt.expectedCorners...)) {
+				t.Errorf("GetPassedCorners() = %v, want %v", car.GetPassedCorners(), tt.expectedCorners)
+			}
+			if car.GetLap() != tt.expectedLap {
+				t.Errorf("GetLap() = %d, want %d", car.GetLap(), tt.expectedLap)
+			}
+		})
+	}
+
+	t.Run("Car not on board", func(t *testing.T) {
+		board := createLoopBoard(10, nil, 1)
+		if _, err := board.MoveCar(NewCar("red", 6), 2); err == nil {
+			t.Error("MoveCar() should fail for a car that is not on the board")
+		}
+	})
+}
+
+func TestBoard_SpinOut(t *testing.T) {
+	t.Run("Back to the space before the corner", func(t *testing.T) {
+		board := createLoopBoard(10, map[int]int{5: 3}, 2)
+		car := NewCar("red", 6)
+		board.PlaceCar(car, 7)
+
+		index, err := board.SpinOut(car, 5)
+		if err != nil {
+			t.Fatalf("SpinOut() error = %v", err)
+		}
+		if index != 4 {
+			t.Errorf("SpinOut() = %d, want 4", index)
+		}
+	})
+
+	t.Run("Skips full spaces", func(t *testing.T) {
+		board := createLoopBoard(10, map[int]int{5: 3}, 2)
+		board.PlaceCar(NewCar("gray", 0), 4)
+		board.PlaceCar(NewCar("black", 0), 4)
+		car := NewCar("red", 6)
+		board.PlaceCar(car, 6)
+
+		index, _ := board.SpinOut(car, 5)
+		if index != 3 {
+			t.Errorf("SpinOut() = %d, want 3", index)
+		}
+	})
+
+	t.Run("Back across the finish line", func(t *testing.T) {
+		board := createLoopBoard(10, map[int]int{1: 2}, 2)
+		car := NewCar("red", 6)
+		board.PlaceCar(car, 8)
+		board.MoveCar(car, 4)
+		if car.GetLap() != 1 {
+			t.Fatalf("GetLap() = %d, want 1 after crossing the line", car.GetLap())
+		}
+
+		index, _ := board.SpinOut(car, 1)
+		if index != 0 {
+			t.Errorf("SpinOut() = %d, want 0", index)
+		}
+		if car.GetLap() != 1 {
+			t.Errorf("GetLap() = %d, want 1 while on the finish line", car.GetLap())
+		}
+
+		board.PlaceCar(NewCar("gray", 0), 0)
+		board.SpinOut(car, 1)
+		if car.GetLap() != 0 {
+			t.Errorf("GetLap() = %d, want 0 once behind the finish line", car.GetLap())
+		}
+	})
+}
+
+func TestBoard_Sectors(t *testing.T) {
+	board := createLoopBoard(12, map[int]int{3: 2, 8: 4}, 1)
+
+	expected := []Sector{{Start: 4, End: 8}, {Start: 9, End: 3}}
+	if !reflect.DeepEqual(board.GetSectors(), expected) {
+		t.Fatalf("GetSectors() = %v, want %v", board.GetSectors(), expected)
+	}
+
+	tests := []struct {
+		space    int
+		expected int
+	}{
+		{space: 0, expected: 1},
+		{space: 3, expected: 1},
+		{space: 4, expected: 0},
+		{space: 8, expected: 0},
+		{space: 11, expected: 1},
+		{space: 12, expected: -1},
+	}
+	for _, tt := range tests {
+		if got := board.GetSectorIndex(tt.space); got != tt.expected {
+			t.Errorf("GetSectorIndex(%d) = %d, want %d", tt.space, got, tt.expected)
+		}
+	}
+
+	condition := RoadCondition{Name: "Free Boost", FreeBoost: true}
+	if err := board.SetSectorCondition(1, condition); err != nil {
+		t.Fatalf("SetSectorCondition() error = %v", err)
+	}
+	if board.GetSectors()[1].Condition != condition {
+		t.Errorf("Sector condition = %v, want %v", board.GetSectors()[1].Condition, condition)
+	}
+	if err := board.SetSectorCondition(2, condition); err == nil {
+		t.Error("SetSectorCondition() on a missing sector should fail")
+	}
+
+	if sectors := createLoopBoard(5, nil, 1).GetSectors(); len(sectors) != 1 {
+		t.Errorf("Board without corners has %d sectors, want 1", len(sectors))
+	}
+}
+
+func TestBoard_GetCornerLimit(t *testing.T) {
+	board := createLoopBoard(10, map[int]int{3: 2, 6: 5}, 1)
+	board.GetSpaces()[3].SetRoadCondition(RoadCondition{SpeedLimitModifier: -3})
+	board.GetSpaces()[6].SetRoadCondition(RoadCondition{SpeedLimitModifier: 1})
+
+	tests := []struct {
+		space    int
+		expected int
+	}{
+		{space: 3, expected: 1},
+		{space: 6, expected: 6},
+		{space: 4, expected: 0},
+		{space: 20, expected: 0},
+	}
+	for _, tt := range tests {
+		if got := board.GetCornerLimit(tt.space); got != tt.expected {
+			t.Errorf("GetCornerLimit(%d) = %d, want %d", tt.space, got, tt.expected)
+		}
+	}
+}
+
+func TestBoard_Weather(t *testing.T) {
+	board := createLoopBoard(5, nil, 1)
+	if board.GetWeather() != (Weather{}) {
+		t.Errorf("GetWeather() = %v, want clear weather", board.GetWeather())
+	}
+
+	weather := GetWeathers()[0]
+	board.SetWeather(weather)
+	if board.GetWeather() != weather {
+		t.Errorf("GetWeather() = %v, want %v", board.GetWeather(), weather)
+	}
+}
+
+func TestBoard_GetRanking(t *testing.T) {
+	board := createLoopBoard(10, nil, 2)
+	behind := NewCar("red", 6)
+	leader := NewCar("blue", 6)
+	inside := NewCar("green", 6)
+	outside := NewCar("yellow", 6)
+
+	board.PlaceCar(behind, 8)
+	board.PlaceCar(leader, 1)
+	leader.IncreaseLap()
+	board.PlaceCar(inside, 5)
+	board.PlaceCar(outside, 5)
+
+	expected := []Car{leader, behind, inside, outside}
+	if !reflect.DeepEqual(board.GetRanking(), expected) {
+		t.Errorf("GetRanking() = %v, want %v", board.GetRanking(), expected)
+	}
+}
+
+func TestBoard_OrderRacers(t *testing.T) {
+	board := createLoopBoard(10, nil, 1)
+	player := NewPlayer("Player", NewCar("red", 6), NewDiscardPile(), NewDeck(nil), NewHand())
+	leader := &testRacer{name: "Leader", car: NewCar("blue", 0)}
+	offBoard := &testRacer{name: "Off Board", car: NewCar("green", 0)}
+	board.PlaceCar(player.GetCar(), 3)
+	board.PlaceCar(leader.GetCar(), 5)
+
+	order := board.OrderRacers([]Racer{offBoard, player, leader})
+
+	expected := []string{"Leader", "Player", "Off Board"}
+	for i, racer := range order {
+		if racer.GetName() != expected[i] {
+			t.Errorf("OrderRacers()[%d] = %s, want %s", i, racer.GetName(), expected[i])
+		}
+	}
+}
+
+func TestBoard_Clone(t *testing.T) {
+	board := createLoopBoard(10, map[int]int{5: 3}, 2)
+	red := NewCar("red", 6)
+	blue := NewCar("blue", 6)
+	board.PlaceCar(red, 2)
+	board.PlaceCar(blue, 4)
+	board.SetWeather(Weather{Name: "Rain"})
+	board.SetSectorCondition(0, GetSectorConditions()[0])
+	board.SetRacerTurnOrder()
+
+	redCopy := red.Clone()
+	cars := map[Car]Car{red: redCopy}
+	clone := board.Clone(cars)
+
+	if index, err := clone.FindCar(redCopy); err != nil || index != 2 {
+		t.Errorf("FindCar(red copy) = %d, %v, want 2", index, err)
+	}
+	blueCopy, ok := cars[blue]
+	if !ok || blueCopy == blue {
+		t.Fatal("Clone() should copy cars missing from the map and add them")
+	}
+	if index, err := clone.FindCar(blueCopy); err != nil || index != 4 {
+		t.Errorf("FindCar(blue copy) = %d, %v, want 4", index, err)
+	}
+	if _, err := clone.FindCar(red); err == nil {
+		t.Error("the clone should not hold the original cars")
+	}
+	if clone.GetWeather().Name != "Rain" || clone.GetCornerLimit(5) != board.GetCornerLimit(5) {
+		t.Error("Clone() should keep the weather and corner limits")
+	}
+	if !reflect.DeepEqual(clone.GetSectors(), board.GetSectors()) {
+		t.Error("Clone() should keep the sectors")
+	}
+	order := make([]Car, 0)
+	for _, car := range board.GetRacerTurnOrder() {
+		order = append(order, cars[car])
+	}
+	if len(order) != 2 || !reflect.DeepEqual(clone.GetRacerTurnOrder(), order) {
+		t.Error("Clone() should map the turn order to the copied cars")
+	}
+
+	// The copy keeps the loop of the track
+	if to, err := clone.MoveCar(blueCopy, 7); err != nil || to != 1 || blueCopy.GetLap() != 1 {
+		t.Errorf("MoveCar() on the clone = %d, %v, lap %d", to, err, blueCopy.GetLap())
+	}
+	if index, _ := board.FindCar(blue); index != 4 || blue.GetLap() != 0 {
+		t.Error("Moving a car on the clone should not change the original board")
+	}
+}
+
+func BenchmarkBoard_Clone(b *testing.B) {
+	board := createLoopBoard(60, map[int]int{10: 3, 30: 4}, 2)
+	for i, color := range []string{"red", "blue", "green", "yellow", "orange", "black"} {
+		board.PlaceCar(NewCar(color, 6), i)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		board.Clone(make(map[Car]Car, 6))
+	}
+}
@@ -26,9 +26,13 @@ type Car interface {
 	ResetPassedCorners()
 	GetLap() int
 	IncreaseLap()
+	DecreaseLap()
 	GetGear() int
 	SetGear(int, DiscardPile) (map[Icon]int, error)
+	ResetGear()
 	GetEngine() int
+	SetEngine(int)
+	Clone() Car
 }
 
 type car struct {
@@ -95,6 +99,14 @@ func (c *car) IncreaseLap() {
 	c.lap++
 }
 
+// DecreaseLap decrements the lap counter
+// Used when a spin-out sends the car back across the finish line
+func (c *car) DecreaseLap() {
+	if c.lap > 0 {
+		c.lap--
+	}
+}
+
 // GetGear returns the current gear
 func (c *car) GetGear() int {
 	return c.gear
@@ -117,11 +129,22 @@ func (c *car) SetGear(gear int, discardPile DiscardPile) (map[Icon]int, error) {
 	return icons, nil
 }
 
+// ResetGear puts the car back in first gear without any shifting cost
+// Used when the car spins out
+func (c *car) ResetGear() {
+	c.gear = 1
+}
+
 // GetEngine returns the engine value
 func (c *car) GetEngine() int {
 	return c.engine
 }
 
+// SetEngine sets the number of heat cards in the car's engine
+func (c *car) SetEngine(engine int) {
+	c.engine = engine
+}
+
 func (c *car) calculateGearShift(gear int, discardPile DiscardPile) error {
 	noOfShifts := math.Abs(float64(gear - c.gear))
 
@@ -146,6 +169,14 @@ func (c *car) calculateGearShift(gear int, discardPile DiscardPile) error {
 }
 
 func (c *car) getCoolingIconsForGear(gear int) map[Icon]int {
+	return GearIcons(gear)
+}
+
+// GearIcons returns the icons a car gets for being in a gear
+// First gear gives 3 Cooling icons, second gear 1
+// Input: gear - the gear the car is in
+// Returns: a map of icon types to counts, empty for higher gears
+func GearIcons(gear int) map[Icon]int {
 	icons := make(map[Icon]int)
 
 	switch gear {
@@ -157,3 +188,10 @@ func (c *car) getCoolingIconsForGear(gear int) map[Icon]int {
 
 	return icons
 }
+
+// Clone copies the car with its gear, engine, lap and corners passed this turn
+func (c *car) Clone() Car {
+	clone := *c
+	clone.passedCorners = c.GetPassedCorners()
+	return &clone
+}
@@ -557,3 +557,90 @@ func BenchmarkCar_SetGear(b *testing.B) {
 		_, _ = car.SetGear(gear, discardPile)
 	}
 }
+
+func TestCar_SetEngine(t *testing.T) {
+	tests := []struct {
+		name   string
+		engine int
+	}{
+		{name: "Empty engine", engine: 0},
+		{name: "Full engine", engine: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			car := NewCar("red", 3)
+			car.SetEngine(tt.engine)
+			if car.GetEngine() != tt.engine {
+				t.Errorf("GetEngine() = %d, want %d", car.GetEngine(), tt.engine)
+			}
+		})
+	}
+}
+
+func TestCar_DecreaseLap(t *testing.T) {
+	car := NewCar("red", 3)
+	car.IncreaseLap()
+	car.IncreaseLap()
+	car.DecreaseLap()
+	if car.GetLap() != 1 {
+		t.Errorf("GetLap() = %d, want 1", car.GetLap())
+	}
+
+	car.DecreaseLap()
+	car.DecreaseLap()
+	if car.GetLap() != 0 {
+		t.Errorf("GetLap() = %d, want 0, laps never go negative", car.GetLap())
+	}
+}
+
+func TestCar_ResetGear(t *testing.T) {
+	car := NewCar("red", 3)
+	car.SetGear(2, nil)
+	car.SetGear(3, nil)
+	car.ResetGear()
+	if car.GetGear() != 1 {
+		t.Errorf("GetGear() = %d, want 1", car.GetGear())
+	}
+	if car.GetEngine() != 3 {
+		t.Errorf("GetEngine() = %d, want 3, resetting the gear is free", car.GetEngine())
+	}
+}
+
+func TestGearIcons(t *testing.T) {
+	tests := []struct {
+		gear     int
+		expected int
+	}{
+		{gear: 1, expected: 3},
+		{gear: 2, expected: 1},
+		{gear: 3, expected: 0},
+		{gear: 5, expected: 0},
+	}
+
+	for _, tt := range tests {
+		if got := GearIcons(tt.gear)[IconCooling]; got != tt.expected {
+			t.Errorf("GearIcons(%d) Cooling = %d, want %d", tt.gear, got, tt.expected)
+		}
+	}
+}
+
+func TestCar_Clone(t *testing.T) {
+	original := NewCar("red", 5)
+	original.SetSpeed(7)
+	original.AddPassedCorner(4)
+	original.IncreaseLap()
+	original.SetGear(2, nil)
+
+	clone := original.Clone()
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("Clone() = %+v, want %+v", clone, original)
+	}
+
+	clone.SetSpeed(1)
+	clone.AddPassedCorner(9)
+	clone.SetEngine(0)
+	if original.GetSpeed() != 7 || len(original.GetPassedCorners()) != 1 || original.GetEngine() != 5 {
+		t.Error("Changing the clone should not change the original car")
+	}
+}
@@ -12,6 +12,7 @@ type Card interface {
 	IsDiscardable() bool
 	IsPlayable() bool
 	IsBasic() bool
+	IsOneUse() bool
 }
 
 type card struct {
@@ -21,6 +22,7 @@ type card struct {
 	discardable bool
 	playable    bool
 	basic       bool
+	oneUse      bool
 }
 
 // NewCard creates a new card instance
@@ -35,6 +37,19 @@ func NewCard(name string, speed int, icons map[Icon]int, discardable, playable,
 	}
 }
 
+// NewOneUseCard creates a card that leaves the game once it is played or discarded
+// One-use cards can be played and discarded, but are not basic cards
+func NewOneUseCard(name string, speed int, icons map[Icon]int) Card {
+	return &card{
+		name:        name,
+		speed:       speed,
+		icons:       icons,
+		discardable: true,
+		playable:    true,
+		oneUse:      true,
+	}
+}
+
 // NewHeatCard creates a new heat card instance
 func NewHeatCard() Card {
 	return NewCard(Heat, 0, nil, false, false, false)
@@ -78,3 +93,8 @@ func (c *card) IsPlayable() bool {
 func (c *card) IsBasic() bool {
 	return c.basic
 }
+
+// IsOneUse returns whether the card leaves the game instead of going to the discard pile
+func (c *card) IsOneUse() bool {
+	return c.oneUse
+}
@@ -315,3 +315,23 @@ func BenchmarkCard_GetIcons(b *testing.B) {
 		card.GetIcons()
 	}
 }
+
+func TestNewOneUseCard(t *testing.T) {
+	card := NewOneUseCard("Sponsor", 2, map[Icon]int{IconBoost: 1})
+
+	if card.GetName() != "Sponsor" || card.GetSpeed() != 2 {
+		t.Errorf("NewOneUseCard() = %s with speed %d, want Sponsor with speed 2", card.GetName(), card.GetSpeed())
+	}
+	if !reflect.DeepEqual(card.GetIcons(), map[Icon]int{IconBoost: 1}) {
+		t.Errorf("GetIcons() = %v, want Boost 1", card.GetIcons())
+	}
+	if !card.IsOneUse() {
+		t.Error("IsOneUse() = false, want true")
+	}
+	if !card.IsPlayable() || !card.IsDiscardable() || card.IsBasic() {
+		t.Error("One-use cards should be playable, discardable and not basic")
+	}
+	if NewCard("Regular", 1, nil, true, true, true).IsOneUse() {
+		t.Error("NewCard() should not create one-use cards")
+	}
+}
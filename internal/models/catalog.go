@@ -0,0 +1,41 @@
+package models
+
+import "fmt"
+
+const (
+	// StartingEngine is the number of heat cards in a car's engine at the start of a race
+	StartingEngine = 6
+
+	// HandSize is the number of cards a player holds after replenishing
+	HandSize = 7
+
+	// StartingStressCards is the number of stress cards in a starting deck
+	StartingStressCards = 3
+)
+
+// NewSpeedCard creates a basic speed card
+// Input: speed - the speed value of the card, 1 to 4 in the base game
+// Returns: a new Card
+func NewSpeedCard(speed int) Card {
+	return NewCard(fmt.Sprintf("Speed %d", speed), speed, nil, true, true, true)
+}
+
+// NewStartingCards creates the cards every player starts the race with
+// Three of each speed card from 1 to 4, the stress cards, and the Speed 0 upgrade with one Cooling icon
+// Input: none
+// Returns: a slice of Cards, unshuffled
+func NewStartingCards() []Card {
+	cards := make([]Card, 0, 16)
+	for speed := 1; speed <= 4; speed++ {
+		for i := 0; i < 3; i++ {
+			cards = append(cards, NewSpeedCard(speed))
+		}
+	}
+
+	for i := 0; i < StartingStressCards; i++ {
+		cards = append(cards, NewStressCard())
+	}
+
+	cards = append(cards, NewCard("Speed 0", 0, map[Icon]int{IconCooling: 1}, true, true, false))
+	return cards
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// FirstCarYear is the year of the first production car; catalog cars cannot be older
+	FirstCarYear = 1886
+
+	// maxCatalogText is the longest name, brand, model or category a catalog car may have
+	maxCatalogText = 100
+)
+
+// Colors lists every car color, in the order players are given them
+var Colors = []Color{Red, Blue, Green, Yellow, Orange, Black, Gray}
+
+// CatalogCar is a car in the catalog, like a Ferrari F40, painted in one of the game's car colors
+// Picking a catalog car for a game races it in its color
+type CatalogCar struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Brand       string    `json:"brand"`
+	Model       string    `json:"model"`
+	Year        int       `json:"year"`
+	EngineSize  float64   `json:"engine_size"`
+	Horsepower  int       `json:"horsepower"`
+	TopSpeed    int       `json:"top_speed"`
+	Weight      int       `json:"weight"`
+	Category    string    `json:"category"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image_url"`
+	Color       Color     `json:"color"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ParseColor finds a car color by name, ignoring case
+// Input: name - the color name, like "red"
+// Returns: the Color, an error if there is no car color with the name
+func ParseColor(name string) (Color, error) {
+	for _, color := range Colors {
+		if strings.EqualFold(string(color), name) {
+			return color, nil
+		}
+	}
+	return "", fmt.Errorf("unknown color %q", name)
+}
+
+// Validate checks a catalog car before it is saved
+// The ID and timestamps are set by the catalog and not checked; a valid color is spelled the way the game spells it
+// Input: none
+// Returns: an error describing the first invalid field
+func (c *CatalogCar) Validate() error {
+	for _, field := range []struct{ name, value string }{{"name", c.Name}, {"brand", c.Brand}, {"model", c.Model}} {
+		if strings.TrimSpace(field.value) == "" {
+			return fmt.Errorf("%s is required", field.name)
+		}
+	}
+	for _, field := range []struct{ name, value string }{{"name", c.Name}, {"brand", c.Brand}, {"model", c.Model}, {"category", c.Category}} {
+		if len(field.value) > maxCatalogText {
+			return fmt.Errorf("%s must be at most %d characters", field.name, maxCatalogText)
+		}
+	}
+
+	if latest := time.Now().Year() + 1; c.Year < FirstCarYear || c.Year > latest {
+		return fmt.Errorf("year must be between %d and %d", FirstCarYear, latest)
+	}
+	switch {
+	case c.EngineSize < 0:
+		return errors.New("engine_size cannot be negative")
+	case c.Horsepower < 0:
+		return errors.New("horsepower cannot be negative")
+	case c.TopSpeed < 0:
+		return errors.New("top_speed cannot be negative")
+	case c.Weight < 0:
+		return errors.New("weight cannot be negative")
+	}
+
+	if c.ImageURL != "" {
+		link, err := url.Parse(c.ImageURL)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+			return errors.New("image_url must be an http or https URL")
+		}
+	}
+
+	if c.Color == "" {
+		return errors.New("color is required")
+	}
+	color, err := ParseColor(string(c.Color))
+	if err != nil {
+		return err
+	}
+	c.Color = color
+	return nil
+}
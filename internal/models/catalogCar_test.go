@@ -0,0 +1,89 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"race-cars/internal/gamestest"
+	"race-cars/internal/models"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    models.Color
+		wantErr bool
+	}{
+		{"Exact name", "Blue", models.Blue, false},
+		{"Lower case", "orange", models.Orange, false},
+		{"Gray", "GRAY", models.Gray, false},
+		{"Unknown color", "Purple", "", true},
+		{"Empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := models.ParseColor(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseColor(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseColor(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogCar_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*models.CatalogCar)
+		wantErr string
+	}{
+		{"Valid car", func(c *models.CatalogCar) {}, ""},
+		{"No image", func(c *models.CatalogCar) { c.ImageURL = "" }, ""},
+		{"Lower case color", func(c *models.CatalogCar) { c.Color = "green" }, ""},
+		{"Missing name", func(c *models.CatalogCar) { c.Name = "  " }, "name is required"},
+		{"Missing brand", func(c *models.CatalogCar) { c.Brand = "" }, "brand is required"},
+		{"Missing model", func(c *models.CatalogCar) { c.Model = "" }, "model is required"},
+		{"Long category", func(c *models.CatalogCar) { c.Category = strings.Repeat("x", 101) }, "category must be at most 100 characters"},
+		{"Too old", func(c *models.CatalogCar) { c.Year = 1885 }, "year must be between"},
+		{"Negative engine size", func(c *models.CatalogCar) { c.EngineSize = -1 }, "engine_size cannot be negative"},
+		{"Negative horsepower", func(c *models.CatalogCar) { c.Horsepower = -1 }, "horsepower cannot be negative"},
+		{"Negative top speed", func(c *models.CatalogCar) { c.TopSpeed = -1 }, "top_speed cannot be negative"},
+		{"Negative weight", func(c *models.CatalogCar) { c.Weight = -1 }, "weight cannot be negative"},
+		{"Relative image URL", func(c *models.CatalogCar) { c.ImageURL = "/f40.jpg" }, "image_url must be an http or https URL"},
+		{"Other scheme", func(c *models.CatalogCar) { c.ImageURL = "ftp://example.com/f40.jpg" }, "image_url must be an http or https URL"},
+		{"Missing color", func(c *models.CatalogCar) { c.Color = "" }, "color is required"},
+		{"Unknown color", func(c *models.CatalogCar) { c.Color = "Purple" }, `unknown color "Purple"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			car := gamestest.CatalogCar("Ferrari F40", models.Red)
+			tt.change(&car)
+			err := car.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCatalogCar_ValidateSpellsColor(t *testing.T) {
+	car := gamestest.CatalogCar("Ferrari F40", models.Red)
+	car.Color = "yellow"
+	if err := car.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if car.Color != models.Yellow {
+		t.Errorf("Color = %q, want %q", car.Color, models.Yellow)
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestNewSpeedCard(t *testing.T) {
+	for speed := 1; speed <= 4; speed++ {
+		card := NewSpeedCard(speed)
+		if card.GetSpeed() != speed {
+			t.Errorf("GetSpeed() = %d, want %d", card.GetSpeed(), speed)
+		}
+		if !card.IsBasic() || !card.IsPlayable() || !card.IsDiscardable() {
+			t.Errorf("Speed %d should be basic, playable and discardable", speed)
+		}
+	}
+}
+
+func TestNewStartingCards(t *testing.T) {
+	cards := NewStartingCards()
+
+	counts := make(map[string]int)
+	for _, card := range cards {
+		counts[card.GetName()]++
+	}
+
+	expected := map[string]int{
+		"Speed 0": 1,
+		"Speed 1": 3,
+		"Speed 2": 3,
+		"Speed 3": 3,
+		"Speed 4": 3,
+		Stress:    StartingStressCards,
+	}
+	for name, count := range expected {
+		if counts[name] != count {
+			t.Errorf("Starting cards have %d %s, want %d", counts[name], name, count)
+		}
+	}
+	if len(cards) != 16 {
+		t.Errorf("Starting cards = %d, want 16", len(cards))
+	}
+}
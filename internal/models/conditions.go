@@ -0,0 +1,93 @@
+package models
+
+// BaseSlipstream is the number of spaces a car moves when it slipstreams
+const BaseSlipstream = 2
+
+// Weather is a weather tile that changes the rules for a whole race
+// The zero value is clear weather and changes nothing
+type Weather struct {
+	Name string `json:"name"`
+
+	// StressCards is the number of extra stress cards in every starting deck
+	StressCards int `json:"stress_cards,omitempty"`
+
+	// HeatInDeck is the number of heat cards moved from the engine into the starting deck
+	HeatInDeck int `json:"heat_in_deck,omitempty"`
+
+	// HeatInDiscard is the number of heat cards moved from the engine into the discard pile
+	HeatInDiscard int `json:"heat_in_discard,omitempty"`
+
+	// CoolingModifier is added to the Cooling icons of a player who cools down
+	CoolingModifier int `json:"cooling_modifier,omitempty"`
+
+	// NoCooling removes every Cooling icon before the react phase
+	NoCooling bool `json:"no_cooling,omitempty"`
+
+	// SlipstreamModifier is added to the slipstream distance
+	SlipstreamModifier int `json:"slipstream_modifier,omitempty"`
+
+	// NoSlipstream prevents slipstreaming
+	NoSlipstream bool `json:"no_slipstream,omitempty"`
+}
+
+// RoadCondition is a road-condition token placed on a corner or a straight sector
+// The zero value is a dry road and changes nothing
+type RoadCondition struct {
+	Name string `json:"name"`
+
+	// SpeedLimitModifier is added to the speed limit of the corner the token is on
+	SpeedLimitModifier int `json:"speed_limit_modifier,omitempty"`
+
+	// ExtraHeat is the heat a car pays whenever it passes the corner the token is on
+	ExtraHeat int `json:"extra_heat,omitempty"`
+
+	// SlipstreamModifier is added to the slipstream distance of cars in the sector
+	SlipstreamModifier int `json:"slipstream_modifier,omitempty"`
+
+	// FreeBoost lets cars that start their move in the sector boost without paying heat
+	FreeBoost bool `json:"free_boost,omitempty"`
+}
+
+// Sector is a straight part of the track
+// Sector i starts after corner i and ends on the next corner's space
+type Sector struct {
+	Start     int           `json:"start"`
+	End       int           `json:"end"`
+	Condition RoadCondition `json:"condition"`
+}
+
+// GetWeathers returns the weather tiles
+// Input: none
+// Returns: a slice of every weather tile
+func GetWeathers() []Weather {
+	return []Weather{
+		{Name: "Freezing Cold", StressCards: 1},
+		{Name: "Heat Wave", HeatInDeck: 3},
+		{Name: "Overcast", HeatInDiscard: 3},
+		{Name: "Rain", NoCooling: true},
+		{Name: "Sunshine", CoolingModifier: 1},
+		{Name: "Fog", NoSlipstream: true},
+		{Name: "Tailwind", SlipstreamModifier: 1},
+	}
+}
+
+// GetCornerConditions returns the road-condition tokens that go on corners
+// Input: none
+// Returns: a slice of corner road conditions
+func GetCornerConditions() []RoadCondition {
+	return []RoadCondition{
+		{Name: "Wide Apex", SpeedLimitModifier: 1},
+		{Name: "Tight Apex", SpeedLimitModifier: -1},
+		{Name: "Overheat", ExtraHeat: 1},
+	}
+}
+
+// GetSectorConditions returns the road-condition tokens that go on straight sectors
+// Input: none
+// Returns: a slice of sector road conditions
+func GetSectorConditions() []RoadCondition {
+	return []RoadCondition{
+		{Name: "Slipstream Boost", SlipstreamModifier: 1},
+		{Name: "Free Boost", FreeBoost: true},
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestConditionCatalogs(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []RoadCondition
+	}{
+		{name: "Corner conditions", conditions: GetCornerConditions()},
+		{name: "Sector conditions", conditions: GetSectorConditions()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.conditions) == 0 {
+				t.Fatal("catalog should not be empty")
+			}
+			for _, condition := range tt.conditions {
+				if condition.Name == "" {
+					t.Errorf("road condition %v has no name", condition)
+				}
+				if condition == (RoadCondition{Name: condition.Name}) {
+					t.Errorf("road condition %s changes nothing", condition.Name)
+				}
+			}
+		})
+	}
+
+	for _, weather := range GetWeathers() {
+		if weather.Name == "" {
+			t.Errorf("weather %v has no name", weather)
+		}
+		if weather == (Weather{Name: weather.Name}) {
+			t.Errorf("weather %s changes nothing", weather.Name)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package models
 
-import "math/rand"
+import (
+	"math/rand"
+	"time"
+)
 
 // Deck is a collection of cards that can be drawn from and shuffled
 type Deck interface {
@@ -8,19 +11,39 @@ type Deck interface {
 	Shuffle()
 	AddCardsToTop(cards []Card)
 	IsEmpty() bool
+	Size() int
+	GetCards() []Card
+	Clone(rng *rand.Rand) Deck
 }
 
 // deck is an implementation of the Deck interface
+// The cards are kept as a stack with the top card last, so drawing and adding to the top
+// only move the end of the slice and never reallocate once the deck has grown to its largest size
 type deck struct {
 	cards []Card
+	rng   *rand.Rand
 }
 
 // NewDeck creates a new deck of cards
-// Input: cards - a slice of Cards
+// Input: cards - a slice of Cards, top card first
 // Returns: a new Deck
 func NewDeck(cards []Card) Deck {
 	return &deck{
-		cards: cards,
+		cards: stack(cards),
+	}
+}
+
+// NewSeededDeck creates a new deck of cards that shuffles with its own random source
+// Games use seeded decks so a race can be replayed from its seed
+// Input: cards - a slice of Cards, top card first
+//
+//	rng - the random source used by Shuffle
+//
+// Returns: a new Deck
+func NewSeededDeck(cards []Card, rng *rand.Rand) Deck {
+	return &deck{
+		cards: stack(cards),
+		rng:   rng,
 	}
 }
 
@@ -29,28 +52,38 @@ func NewDeck(cards []Card) Deck {
 // Input: none
 // Returns: a Card
 func (d *deck) DrawCard() Card {
-	if len(d.cards) == 0 {
+	last := len(d.cards) - 1
+	if last < 0 {
 		return nil
 	}
-	card := d.cards[0]
-	d.cards = d.cards[1:]
+	card := d.cards[last]
+	d.cards[last] = nil
+	d.cards = d.cards[:last]
 	return card
 }
 
 // Shuffle shuffles the deck
+// A deck without a random source gets its own, seeded from the clock, rather than using the shared math/rand source
+// Positions are counted from the top, so a seed deals the same order it did before the deck was stored as a stack
 // Input: none
 // Returns: none
 func (d *deck) Shuffle() {
-	rand.Shuffle(len(d.cards), func(i, j int) {
-		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	if d.rng == nil {
+		d.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	last := len(d.cards) - 1
+	d.rng.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[last-i], d.cards[last-j] = d.cards[last-j], d.cards[last-i]
 	})
 }
 
 // AddCardsToTop adds cards to the top of the deck
-// Input: cards - a slice of Cards
+// Input: cards - a slice of Cards, the first card ends up on top
 // Returns: none
 func (d *deck) AddCardsToTop(cards []Card) {
-	d.cards = append(cards, d.cards...)
+	for i := len(cards) - 1; i >= 0; i-- {
+		d.cards = append(d.cards, cards[i])
+	}
 }
 
 // IsEmpty checks if the deck is empty
@@ -59,3 +92,41 @@ func (d *deck) AddCardsToTop(cards []Card) {
 func (d *deck) IsEmpty() bool {
 	return len(d.cards) == 0
 }
+
+// Size returns the number of cards in the deck
+// Input: none
+// Returns: the number of cards
+func (d *deck) Size() int {
+	return len(d.cards)
+}
+
+// GetCards returns the cards in the deck
+// Input: none
+// Returns: a copy of the cards, top card first
+func (d *deck) GetCards() []Card {
+	return stack(d.cards)
+}
+
+// Clone copies the deck in its current order
+// Cards are immutable, so the copy shares them with the original
+// Input: rng - the random source the copy shuffles with, nil to keep the original's
+// Returns: a new Deck
+func (d *deck) Clone(rng *rand.Rand) Deck {
+	if rng == nil {
+		rng = d.rng
+	}
+	cards := make([]Card, len(d.cards), cap(d.cards))
+	copy(cards, d.cards)
+	return &deck{cards: cards, rng: rng}
+}
+
+// stack copies cards in reverse order, turning a top-first list into a stack with the top card last and back
+// Input: cards - the cards to copy
+// Returns: a new slice of Cards
+func stack(cards []Card) []Card {
+	result := make([]Card, len(cards))
+	for i, card := range cards {
+		result[len(cards)-1-i] = card
+	}
+	return result
+}
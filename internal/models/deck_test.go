@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math/rand"
 	"reflect"
 	"testing"
 )
@@ -601,3 +602,152 @@ func BenchmarkDeck_IsEmpty(b *testing.B) {
 		})
 	}
 }
+
+func TestNewSeededDeck_Deterministic(t *testing.T) {
+	draw := func(seed int64) []string {
+		deck := NewSeededDeck(NewStartingCards(), rand.New(rand.NewSource(seed)))
+		deck.Shuffle()
+		names := make([]string, 0)
+		for card := deck.DrawCard(); card != nil; card = deck.DrawCard() {
+			names = append(names, card.GetName())
+		}
+		return names
+	}
+
+	if !reflect.DeepEqual(draw(7), draw(7)) {
+		t.Error("Seeded decks with the same seed should shuffle the same way")
+	}
+}
+
+func TestDeck_Clone(t *testing.T) {
+	original := NewSeededDeck(createTestCards(), rand.New(rand.NewSource(1)))
+
+	clone := original.Clone(nil)
+	if !reflect.DeepEqual(clone.GetCards(), original.GetCards()) {
+		t.Fatal("Clone() should keep the order of the cards")
+	}
+
+	clone.DrawCard()
+	if len(original.GetCards()) != 5 {
+		t.Error("Drawing from the clone should not change the original deck")
+	}
+
+	a := original.Clone(rand.New(rand.NewSource(3)))
+	b := original.Clone(rand.New(rand.NewSource(3)))
+	a.Shuffle()
+	b.Shuffle()
+	if !reflect.DeepEqual(a.GetCards(), b.GetCards()) {
+		t.Error("Clones with the same seed should shuffle the same way")
+	}
+}
+
+func BenchmarkDeck_Clone(b *testing.B) {
+	deck := NewDeck(NewStartingCards())
+	rng := rand.New(rand.NewSource(1))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		deck.Clone(rng)
+	}
+}
+
+func TestDeck_Size(t *testing.T) {
+	deck := NewDeck(createTestCards())
+	if deck.Size() != 5 {
+		t.Errorf("Size() = %d, want 5", deck.Size())
+	}
+	deck.DrawCard()
+	deck.AddCardsToTop([]Card{NewHeatCard(), NewStressCard()})
+	if deck.Size() != 6 {
+		t.Errorf("Size() = %d, want 6", deck.Size())
+	}
+}
+
+func TestDeck_AddCardsToTopKeepsOrder(t *testing.T) {
+	deck := NewDeck(createTestCards())
+	deck.AddCardsToTop([]Card{NewHeatCard(), NewStressCard()})
+
+	expected := []string{Heat, Stress, "Card 1", "Card 2", "Card 3", "Card 4", "Card 5"}
+	for i, card := range deck.GetCards() {
+		if card.GetName() != expected[i] {
+			t.Errorf("GetCards()[%d] = %s, want %s", i, card.GetName(), expected[i])
+		}
+	}
+	for _, name := range expected {
+		if card := deck.DrawCard(); card.GetName() != name {
+			t.Errorf("DrawCard() = %s, want %s", card.GetName(), name)
+		}
+	}
+}
+
+func TestDeck_DrawAndRefillDoesNotAllocate(t *testing.T) {
+	deck := NewSeededDeck(NewStartingCards(), rand.New(rand.NewSource(1)))
+	drawn := make([]Card, 0, 7)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		drawn = drawn[:0]
+		for i := 0; i < 7; i++ {
+			drawn = append(drawn, deck.DrawCard())
+		}
+		deck.AddCardsToTop(drawn)
+		deck.Shuffle()
+	})
+	if allocs != 0 {
+		t.Errorf("draw and refill made %v allocations, want 0", allocs)
+	}
+}
+
+// legacyDeck is the deck as it was stored before the stack layout, kept to benchmark against
+type legacyDeck struct {
+	cards []Card
+	rng   *rand.Rand
+}
+
+func (d *legacyDeck) DrawCard() Card {
+	if len(d.cards) == 0 {
+		return nil
+	}
+	card := d.cards[0]
+	d.cards = d.cards[1:]
+	return card
+}
+
+func (d *legacyDeck) AddCardsToTop(cards []Card) {
+	d.cards = append(cards, d.cards...)
+}
+
+func (d *legacyDeck) Shuffle() {
+	d.rng.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	})
+}
+
+// BenchmarkDeck_DrawAndRefill draws a hand's worth of cards and puts them back, as a race does every round
+func BenchmarkDeck_DrawAndRefill(b *testing.B) {
+	type drawer interface {
+		DrawCard() Card
+		AddCardsToTop(cards []Card)
+		Shuffle()
+	}
+	decks := map[string]func() drawer{
+		"stack":  func() drawer { return NewSeededDeck(NewStartingCards(), rand.New(rand.NewSource(1))).(*deck) },
+		"legacy": func() drawer { return &legacyDeck{cards: NewStartingCards(), rng: rand.New(rand.NewSource(1))} },
+	}
+
+	for _, name := range []string{"stack", "legacy"} {
+		b.Run(name, func(b *testing.B) {
+			deck := decks[name]()
+			drawn := make([]Card, 0, 7)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				drawn = drawn[:0]
+				for j := 0; j < 7; j++ {
+					drawn = append(drawn, deck.DrawCard())
+				}
+				deck.AddCardsToTop(drawn)
+				if i%4 == 0 {
+					deck.Shuffle()
+				}
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package models
 
+import "errors"
+
 type DiscardPile interface {
 	AddCard(card Card)
 	ResetDeck(deck Deck)
+	GetCards() []Card
+	RemoveCard(index int) (Card, error)
+	Size() int
+	Clone() DiscardPile
 }
 
 type discardPile struct {
@@ -37,6 +43,49 @@ func (d *discardPile) ResetDeck(deck Deck) {
 		return
 	}
 	deck.AddCardsToTop(d.cards)
-	d.cards = make([]Card, 0)
+	clear(d.cards)
+	d.cards = d.cards[:0]
 	deck.Shuffle()
 }
+
+// GetCards returns the cards in the discard pile
+// Input: none
+// Returns: a copy of the cards, the most recently discarded card last
+func (d *discardPile) GetCards() []Card {
+	// Return a copy to prevent external modification
+	result := make([]Card, len(d.cards))
+	copy(result, d.cards)
+	return result
+}
+
+// RemoveCard takes a card out of the discard pile
+// Input: index - an int, the index of the card to remove
+// Returns: the removed Card, an error if the index is out of bounds
+func (d *discardPile) RemoveCard(index int) (Card, error) {
+	if index < 0 || index >= len(d.cards) {
+		return nil, errors.New("invalid card index")
+	}
+
+	card := d.cards[index]
+	last := len(d.cards) - 1
+	copy(d.cards[index:], d.cards[index+1:])
+	d.cards[last] = nil
+	d.cards = d.cards[:last]
+	return card, nil
+}
+
+// Size returns the number of cards in the discard pile
+// Input: none
+// Returns: the number of cards
+func (d *discardPile) Size() int {
+	return len(d.cards)
+}
+
+// Clone copies the discard pile
+// Input: none
+// Returns: a new DiscardPile with the same cards
+func (d *discardPile) Clone() DiscardPile {
+	cards := make([]Card, len(d.cards), cap(d.cards))
+	copy(cards, d.cards)
+	return &discardPile{cards: cards}
+}
@@ -368,3 +368,58 @@ func BenchmarkDiscardPile_ResetDeck(b *testing.B) {
 		}
 	}
 }
+
+func TestDiscardPile_GetCardsAndRemoveCard(t *testing.T) {
+	cards := createDiscardPileTestCards()
+	discardPile := NewDiscardPile()
+	for _, card := range cards {
+		discardPile.AddCard(card)
+	}
+
+	if got := discardPile.GetCards(); len(got) != len(cards) {
+		t.Fatalf("GetCards() returned %d cards, want %d", len(got), len(cards))
+	}
+
+	card, err := discardPile.RemoveCard(1)
+	if err != nil {
+		t.Fatalf("RemoveCard() error = %v", err)
+	}
+	if card != cards[1] {
+		t.Errorf("RemoveCard() = %v, want %v", card, cards[1])
+	}
+	if len(discardPile.GetCards()) != len(cards)-1 {
+		t.Errorf("GetCards() after removal = %d cards, want %d", len(discardPile.GetCards()), len(cards)-1)
+	}
+
+	for _, index := range []int{-1, len(cards)} {
+		if _, err := discardPile.RemoveCard(index); err == nil {
+			t.Errorf("RemoveCard(%d) should fail", index)
+		}
+	}
+}
+
+func TestDiscardPile_Clone(t *testing.T) {
+	original := NewDiscardPile()
+	original.AddCard(NewHeatCard())
+
+	clone := original.Clone()
+	clone.AddCard(NewStressCard())
+	if len(original.GetCards()) != 1 || len(clone.GetCards()) != 2 {
+		t.Error("Adding a card to the clone should not change the original discard pile")
+	}
+}
+
+func TestDiscardPile_Size(t *testing.T) {
+	pile := NewDiscardPile()
+	pile.AddCard(NewHeatCard())
+	pile.AddCard(NewStressCard())
+	if pile.Size() != 2 {
+		t.Errorf("Size() = %d, want 2", pile.Size())
+	}
+
+	deck := NewDeck(nil)
+	pile.ResetDeck(deck)
+	if pile.Size() != 0 || deck.Size() != 2 {
+		t.Errorf("after ResetDeck() pile = %d, deck = %d, want 0 and 2", pile.Size(), deck.Size())
+	}
+}
@@ -8,8 +8,18 @@ type Hand interface {
 	DrawCard(deck Deck)
 	DiscardCard(index int, discardPile DiscardPile) error
 	PlayCard(index int) (Card, error)
+	GetCards() []Card
+	RemoveCard(index int) (Card, error)
+	Size() int
+	GetCard(index int) Card
+	Clone() Hand
 }
 
+// handCapacity is the room a new hand starts with: a full hand plus a card drawn or earned on top
+const handCapacity = 8
+
+// hand is an implementation of the Hand interface
+// Cards are removed in place, so a hand never reallocates once it has held its largest number of cards
 type hand struct {
 	cards []Card
 }
@@ -19,7 +29,7 @@ type hand struct {
 // Returns: a new Hand
 func NewHand() Hand {
 	return &hand{
-		cards: make([]Card, 0),
+		cards: make([]Card, 0, handCapacity),
 	}
 }
 
@@ -50,6 +60,7 @@ func (h *hand) DrawCard(deck Deck) {
 }
 
 // DiscardCard discards a card from the hand
+// One-use cards leave the game instead of going to the discard pile
 // Input: index - an int, the index of the card to discard
 // Returns: an error if the card is not discardable or the index is out of bounds
 func (h *hand) DiscardCard(index int, discardPile DiscardPile) error {
@@ -69,8 +80,10 @@ func (h *hand) DiscardCard(index int, discardPile DiscardPile) error {
 		return errors.New("discard pile is nil")
 	}
 
-	discardPile.AddCard(h.cards[index])
-	h.cards = append(h.cards[:index], h.cards[index+1:]...)
+	if !h.cards[index].IsOneUse() {
+		discardPile.AddCard(h.cards[index])
+	}
+	h.remove(index)
 	return nil
 }
 
@@ -90,8 +103,66 @@ func (h *hand) PlayCard(index int) (Card, error) {
 		return nil, errors.New("card is not playable")
 	}
 
-	card := h.cards[index]
+	return h.remove(index), nil
+}
+
+// GetCards returns the cards currently in the hand
+// Input: none
+// Returns: a copy of the cards in the hand
+func (h *hand) GetCards() []Card {
+	// Return a copy to prevent external modification
+	result := make([]Card, len(h.cards))
+	copy(result, h.cards)
+	return result
+}
+
+// RemoveCard removes a card from the hand without playing or discarding it
+// Used by effects that move cards out of the hand, like Cooling returning Heat to the engine
+// Input: index - an int, the index of the card to remove
+// Returns: the removed Card, an error if the index is out of bounds
+func (h *hand) RemoveCard(index int) (Card, error) {
+	if index < 0 || index >= len(h.cards) {
+		return nil, errors.New("invalid card index")
+	}
+
+	return h.remove(index), nil
+}
+
+// Size returns the number of cards in the hand
+// Input: none
+// Returns: the number of cards
+func (h *hand) Size() int {
+	return len(h.cards)
+}
+
+// GetCard returns a card without copying the hand
+// Input: index - an int, the index of the card
+// Returns: the Card, nil if the index is out of bounds
+func (h *hand) GetCard(index int) Card {
+	if index < 0 || index >= len(h.cards) {
+		return nil
+	}
+	return h.cards[index]
+}
+
+// Clone copies the hand
+// Input: none
+// Returns: a new Hand with the same cards
+func (h *hand) Clone() Hand {
+	cards := make([]Card, len(h.cards), max(cap(h.cards), handCapacity))
+	copy(cards, h.cards)
+	return &hand{cards: cards}
+}
 
-	h.cards = append(h.cards[:index], h.cards[index+1:]...)
-	return card, nil
+// remove takes a card out of the hand, keeping the order of the others
+// The freed slot at the end is cleared so the slice does not hold on to the card
+// Input: index - an int, the index of the card, already checked
+// Returns: the removed Card
+func (h *hand) remove(index int) Card {
+	card := h.cards[index]
+	last := len(h.cards) - 1
+	copy(h.cards[index:], h.cards[index+1:])
+	h.cards[last] = nil
+	h.cards = h.cards[:last]
+	return card
 }
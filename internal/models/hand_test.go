@@ -1,6 +1,8 @@
 package models
 
 import (
+	"errors"
+	"math/rand"
 	"testing"
 )
 
@@ -785,3 +787,235 @@ func BenchmarkHand_DiscardCard(b *testing.B) {
 		hand.DiscardCard(0, discardPile)
 	}
 }
+
+func TestHand_GetCards(t *testing.T) {
+	cards := createHandTestCards()
+	hand := NewHand()
+	hand.AddCards(cards)
+
+	result := hand.GetCards()
+	if len(result) != len(cards) {
+		t.Fatalf("GetCards() returned %d cards, want %d", len(result), len(cards))
+	}
+	for i := range cards {
+		if result[i] != cards[i] {
+			t.Errorf("GetCards()[%d] = %v, want %v", i, result[i], cards[i])
+		}
+	}
+
+	// Modifying the returned slice must not change the hand
+	result[0] = nil
+	if hand.GetCards()[0] != cards[0] {
+		t.Error("GetCards() should return a copy of the hand")
+	}
+}
+
+func TestHand_RemoveCard(t *testing.T) {
+	tests := []struct {
+		name              string
+		index             int
+		expectError       bool
+		expectedRemaining int
+	}{
+		{
+			name:              "Remove first card",
+			index:             0,
+			expectError:       false,
+			expectedRemaining: 4,
+		},
+		{
+			name:              "Remove non-discardable card",
+			index:             2,
+			expectError:       false,
+			expectedRemaining: 4,
+		},
+		{
+			name:              "Negative index",
+			index:             -1,
+			expectError:       true,
+			expectedRemaining: 5,
+		},
+		{
+			name:              "Index out of bounds",
+			index:             5,
+			expectError:       true,
+			expectedRemaining: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards := createHandTestCards()
+			hand := NewHand()
+			hand.AddCards(cards)
+
+			card, err := hand.RemoveCard(tt.index)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("RemoveCard() error = %v, expectError %t", err, tt.expectError)
+			}
+			if !tt.expectError && card != cards[tt.index] {
+				t.Errorf("RemoveCard() = %v, want %v", card, cards[tt.index])
+			}
+			if len(hand.GetCards()) != tt.expectedRemaining {
+				t.Errorf("RemoveCard() left %d cards, want %d", len(hand.GetCards()), tt.expectedRemaining)
+			}
+		})
+	}
+}
+
+func TestHand_DiscardOneUseCard(t *testing.T) {
+	hand := NewHand()
+	hand.AddCards([]Card{NewOneUseCard("Sponsor", 1, nil), NewCard("Regular", 1, nil, true, true, true)})
+	discardPile := NewDiscardPile()
+
+	if err := hand.DiscardCard(0, discardPile); err != nil {
+		t.Fatalf("DiscardCard() error = %v", err)
+	}
+
+	if len(hand.GetCards()) != 1 {
+		t.Errorf("Hand has %d cards, want 1", len(hand.GetCards()))
+	}
+	if len(discardPile.GetCards()) != 0 {
+		t.Errorf("Discard pile has %d cards, want 0: one-use cards leave the game", len(discardPile.GetCards()))
+	}
+}
+
+func TestHand_Clone(t *testing.T) {
+	original := NewHand()
+	original.AddCards(createHandTestCards())
+
+	clone := original.Clone()
+	if len(clone.GetCards()) != 5 {
+		t.Fatalf("Clone() has %d cards, want 5", len(clone.GetCards()))
+	}
+
+	clone.RemoveCard(0)
+	if len(original.GetCards()) != 5 {
+		t.Error("Removing a card from the clone should not change the original hand")
+	}
+}
+
+func TestHand_SizeAndGetCard(t *testing.T) {
+	hand := NewHand()
+	hand.AddCards(createHandTestCards())
+
+	if hand.Size() != 5 {
+		t.Errorf("Size() = %d, want 5", hand.Size())
+	}
+	if card := hand.GetCard(1); card == nil || card.GetName() != "Card 2" {
+		t.Errorf("GetCard(1) = %v, want Card 2", card)
+	}
+	if hand.GetCard(-1) != nil || hand.GetCard(5) != nil {
+		t.Error("GetCard() out of bounds should return nil")
+	}
+
+	hand.RemoveCard(0)
+	if hand.Size() != 4 || hand.GetCard(0).GetName() != "Card 2" {
+		t.Error("RemoveCard() should shift the later cards down")
+	}
+}
+
+func TestHand_DrawAndPlayDoesNotAllocate(t *testing.T) {
+	deck := NewDeck(NewStartingCards())
+	hand := NewHand()
+	played := make([]Card, 0, 3)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for hand.Size() < 7 {
+			hand.DrawCard(deck)
+		}
+		played = played[:0]
+		for i := 0; i < 3; i++ {
+			card, _ := hand.PlayCard(hand.Size() - 1 - i)
+			played = append(played, card)
+		}
+		deck.AddCardsToTop(played)
+	})
+	if allocs != 0 {
+		t.Errorf("draw and play made %v allocations, want 0", allocs)
+	}
+}
+
+// baselineHand is the hand as it was stored before cards were removed in place, kept to benchmark against
+// DrawCard and PlayCard are the original ones; the original had no accessors, so GetCard and Size read its slice directly
+type baselineHand struct {
+	cards []Card
+}
+
+func (h *baselineHand) DrawCard(deck Deck) {
+	if deck == nil {
+		return
+	}
+
+	card := deck.DrawCard()
+	if card == nil {
+		return
+	}
+
+	h.cards = append(h.cards, card)
+}
+
+func (h *baselineHand) PlayCard(index int) (Card, error) {
+	if index < 0 || index >= len(h.cards) {
+		return nil, errors.New("invalid card index")
+	}
+
+	if h.cards[index] == nil {
+		return nil, errors.New("card is nil")
+	}
+
+	if !h.cards[index].IsPlayable() {
+		return nil, errors.New("card is not playable")
+	}
+
+	card := h.cards[index]
+	h.cards = append(h.cards[:index], h.cards[index+1:]...)
+	return card, nil
+}
+
+func (h *baselineHand) GetCard(index int) Card {
+	return h.cards[index]
+}
+
+func (h *baselineHand) Size() int {
+	return len(h.cards)
+}
+
+// BenchmarkHand_Round refills a hand, reads every card as a bot does and plays three of them
+// Both hands draw from and return cards to the same kind of deck, so only the way the hand stores its cards differs
+func BenchmarkHand_Round(b *testing.B) {
+	type roundHand interface {
+		DrawCard(deck Deck)
+		PlayCard(index int) (Card, error)
+		GetCard(index int) Card
+		Size() int
+	}
+	hands := map[string]func() roundHand{
+		"indexed":  func() roundHand { return NewHand() },
+		"baseline": func() roundHand { return &baselineHand{cards: make([]Card, 0)} },
+	}
+
+	for _, name := range []string{"indexed", "baseline"} {
+		b.Run(name, func(b *testing.B) {
+			deck := NewSeededDeck(NewStartingCards(), rand.New(rand.NewSource(1)))
+			hand := hands[name]()
+			played := make([]Card, 0, 3)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for hand.Size() < 7 {
+					hand.DrawCard(deck)
+				}
+				speed := 0
+				for j := 0; j < hand.Size(); j++ {
+					speed += hand.GetCard(j).GetSpeed()
+				}
+				played = played[:0]
+				for j := 0; j < 3; j++ {
+					card, _ := hand.PlayCard(0)
+					played = append(played, card)
+				}
+				deck.AddCardsToTop(played)
+			}
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
 // Icon represents the type of icon on a card
 // It is represented as an integer, but can be converted to a string
 
@@ -10,11 +16,53 @@ const (
 	IconCooling
 )
 
-var iconName = map[Icon]string{
-	IconBoost: "Boost",
-	IconCooling: "Cooling",
-}
+var (
+	iconNameMu sync.RWMutex
+	iconName   = map[Icon]string{
+		IconBoost:   "Boost",
+		IconCooling: "Cooling",
+	}
+)
 
 func (i Icon) String() string {
+	iconNameMu.RLock()
+	defer iconNameMu.RUnlock()
 	return iconName[i]
-}
\ No newline at end of file
+}
+
+// RegisterIcon gives a new icon type its display name
+// Expansions call this (usually from an init function) before using their icons
+// Input: icon - the icon type to register
+//
+//	name - the display name returned by Icon.String
+//
+// Returns: an error if the icon is already registered or the name is empty
+func RegisterIcon(icon Icon, name string) error {
+	if name == "" {
+		return fmt.Errorf("icon %d must have a name", int(icon))
+	}
+
+	iconNameMu.Lock()
+	defer iconNameMu.Unlock()
+
+	if existing, ok := iconName[icon]; ok {
+		return fmt.Errorf("icon %d is already registered as %s", int(icon), existing)
+	}
+	iconName[icon] = name
+	return nil
+}
+
+// ParseIcon finds a registered icon by its display name, ignoring case
+// Input: name - the display name, like "Cooling"
+// Returns: the Icon, an error if no icon has the name
+func ParseIcon(name string) (Icon, error) {
+	iconNameMu.RLock()
+	defer iconNameMu.RUnlock()
+
+	for icon, iconName := range iconName {
+		if strings.EqualFold(iconName, name) {
+			return icon, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown icon %q", name)
+}
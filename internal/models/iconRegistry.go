@@ -0,0 +1,226 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// IconResolver applies an icon's effect to a player during the react phase
+// Input: player - the player resolving the icon
+//
+//	count - the number of icons of this type the player accumulated
+//
+// Returns: an error if the effect cannot be applied
+type IconResolver func(player Player, count int) error
+
+// IconEffect describes how an icon type is resolved during the react phase
+// Effects are resolved in ascending Order; optional effects are only resolved when the player accepts them
+type IconEffect struct {
+	Icon      Icon
+	Name      string
+	Order     int
+	Mandatory bool
+	Resolve   IconResolver
+}
+
+// IconRegistry holds the effects for every icon type known to a game
+// The base game icons are registered by NewIconRegistry; expansions register their own icons on top
+type IconRegistry interface {
+	// Register adds an icon effect to the registry
+	// Input: effect - the effect to register
+	// Returns: an error if the effect is incomplete or the icon is already registered
+	Register(effect IconEffect) error
+
+	// GetEffect returns the effect registered for an icon
+	// Input: icon - the icon type to look up
+	// Returns: the effect and whether it was found
+	GetEffect(icon Icon) (IconEffect, bool)
+
+	// GetEffects returns all registered effects in resolution order
+	// Returns: a slice of effects sorted by Order
+	GetEffects() []IconEffect
+
+	// ResolveIcons resolves the player's accumulated icons in order and clears them
+	// Input: player - the player whose icons are resolved
+	//	accept - decides whether an optional effect is used; nil declines every optional effect
+	// Returns: an error from the first effect that fails
+	ResolveIcons(player Player, accept func(effect IconEffect, count int) bool) error
+}
+
+type iconRegistry struct {
+	effects map[Icon]IconEffect
+}
+
+// NewIconRegistry creates a new icon registry with the base game icons registered
+// Input: none
+// Returns: a new IconRegistry
+func NewIconRegistry() IconRegistry {
+	registry := &iconRegistry{
+		effects: make(map[Icon]IconEffect),
+	}
+
+	for _, effect := range baseIconEffects() {
+		// The base effects are well formed, so registration cannot fail
+		_ = registry.Register(effect)
+	}
+
+	return registry
+}
+
+// Register adds an icon effect to the registry
+// The effect name defaults to the icon's registered name
+// Input: effect - the effect to register
+// Returns: an error if the effect is incomplete or the icon is already registered
+func (r *iconRegistry) Register(effect IconEffect) error {
+	if effect.Resolve == nil {
+		return errors.New("icon effect must have a resolver")
+	}
+
+	if effect.Name == "" {
+		effect.Name = effect.Icon.String()
+	}
+	if effect.Name == "" {
+		return fmt.Errorf("icon %d has no name", int(effect.Icon))
+	}
+
+	if _, ok := r.effects[effect.Icon]; ok {
+		return fmt.Errorf("icon %s already has an effect", effect.Name)
+	}
+
+	r.effects[effect.Icon] = effect
+	return nil
+}
+
+// GetEffect returns the effect registered for an icon
+// Input: icon - the icon type to look up
+// Returns: the effect and whether it was found
+func (r *iconRegistry) GetEffect(icon Icon) (IconEffect, bool) {
+	effect, ok := r.effects[icon]
+	return effect, ok
+}
+
+// GetEffects returns all registered effects in resolution order
+// Effects with the same order are sorted by icon value so the order is stable
+// Input: none
+// Returns: a slice of effects sorted by Order
+func (r *iconRegistry) GetEffects() []IconEffect {
+	effects := make([]IconEffect, 0, len(r.effects))
+	for _, effect := range r.effects {
+		effects = append(effects, effect)
+	}
+
+	sort.Slice(effects, func(i, j int) bool {
+		if effects[i].Order != effects[j].Order {
+			return effects[i].Order < effects[j].Order
+		}
+		return effects[i].Icon < effects[j].Icon
+	})
+
+	return effects
+}
+
+// ResolveIcons resolves the player's accumulated icons in order and clears them
+// Mandatory effects always resolve, optional effects resolve only when accept returns true
+// Icons without a registered effect are left on the player
+// Input: player - the player whose icons are resolved
+//
+//	accept - decides whether an optional effect is used; nil declines every optional effect
+//
+// Returns: an error from the first effect that fails
+func (r *iconRegistry) ResolveIcons(player Player, accept func(effect IconEffect, count int) bool) error {
+	if player == nil {
+		return errors.New("player is nil")
+	}
+
+	for _, effect := range r.GetEffects() {
+		count := player.ClearIcon(effect.Icon)
+		if count <= 0 {
+			continue
+		}
+
+		if !effect.Mandatory && (accept == nil || !accept(effect, count)) {
+			continue
+		}
+
+		if err := effect.Resolve(player, count); err != nil {
+			return fmt.Errorf("resolving %s: %w", effect.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// baseIconEffects returns the effects for the icons of the base game
+// Input: none
+// Returns: a slice of IconEffects
+func baseIconEffects() []IconEffect {
+	return []IconEffect{
+		{
+			Icon:      IconCooling,
+			Order:     10,
+			Mandatory: false,
+			Resolve:   resolveCooling,
+		},
+		{
+			Icon:      IconBoost,
+			Order:     20,
+			Mandatory: false,
+			Resolve:   resolveBoost,
+		},
+	}
+}
+
+// resolveCooling moves up to count Heat cards from the player's hand back into the car's engine
+// Input: player - the player cooling down
+//
+//	count - the number of Cooling icons
+//
+// Returns: an error if a card cannot be removed from the hand
+func resolveCooling(player Player, count int) error {
+	for i := 0; i < count; i++ {
+		index := findCardByName(player.GetHand().GetCards(), Heat)
+		if index < 0 {
+			return nil
+		}
+
+		if _, err := player.GetHand().RemoveCard(index); err != nil {
+			return err
+		}
+		player.GetCar().SetEngine(player.GetCar().GetEngine() + 1)
+	}
+
+	return nil
+}
+
+// resolveBoost flips cards from the player's deck until a basic card is found for each Boost icon
+// The speed of every basic card found is added to the car's speed
+// Input: player - the player boosting
+//
+//	count - the number of Boost icons
+//
+// Returns: none, boosting always succeeds
+func resolveBoost(player Player, count int) error {
+	car := player.GetCar()
+	for i := 0; i < count; i++ {
+		car.SetSpeed(car.GetSpeed() + flipUntilBasic(player.GetDeck(), player.GetDiscardPile()))
+	}
+
+	return nil
+}
+
+// findCardByName returns the index of the first card with the given name
+// Input: cards - the cards to search
+//
+//	name - the card name to look for
+//
+// Returns: the index of the card, -1 if it is not found
+func findCardByName(cards []Card, name string) int {
+	for i, card := range cards {
+		if card != nil && card.GetName() == name {
+			return i
+		}
+	}
+
+	return -1
+}
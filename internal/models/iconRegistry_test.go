@@ -0,0 +1,248 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+// Helper function to create a player for icon registry tests
+func createIconTestPlayer(handCards []Card, deckCards []Card, engine int) Player {
+	hand := NewHand()
+	hand.AddCards(handCards)
+	return NewPlayer("TestPlayer", NewCar("red", engine), NewDiscardPile(), NewDeck(deckCards), hand)
+}
+
+func TestNewIconRegistry(t *testing.T) {
+	registry := NewIconRegistry()
+
+	tests := []struct {
+		name      string
+		icon      Icon
+		wantName  string
+		mandatory bool
+	}{
+		{
+			name:     "Cooling is registered",
+			icon:     IconCooling,
+			wantName: "Cooling",
+		},
+		{
+			name:     "Boost is registered",
+			icon:     IconBoost,
+			wantName: "Boost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			effect, ok := registry.GetEffect(tt.icon)
+			if !ok {
+				t.Fatalf("GetEffect(%v) not found", tt.icon)
+			}
+			if effect.Name != tt.wantName {
+				t.Errorf("effect.Name = %s, want %s", effect.Name, tt.wantName)
+			}
+			if effect.Mandatory != tt.mandatory {
+				t.Errorf("effect.Mandatory = %t, want %t", effect.Mandatory, tt.mandatory)
+			}
+		})
+	}
+}
+
+func TestIconRegistry_Register(t *testing.T) {
+	noop := func(player Player, count int) error { return nil }
+
+	tests := []struct {
+		name    string
+		effect  IconEffect
+		wantErr bool
+	}{
+		{
+			name:    "Register new icon with name",
+			effect:  IconEffect{Icon: Icon(500), Name: "Custom", Resolve: noop},
+			wantErr: false,
+		},
+		{
+			name:    "Register without resolver",
+			effect:  IconEffect{Icon: Icon(501), Name: "Custom"},
+			wantErr: true,
+		},
+		{
+			name:    "Register unnamed unknown icon",
+			effect:  IconEffect{Icon: Icon(502), Resolve: noop},
+			wantErr: true,
+		},
+		{
+			name:    "Register already registered icon",
+			effect:  IconEffect{Icon: IconBoost, Resolve: noop},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewIconRegistry()
+			err := registry.Register(tt.effect)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Register() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIconRegistry_GetEffects_Order(t *testing.T) {
+	registry := NewIconRegistry()
+	noop := func(player Player, count int) error { return nil }
+
+	registry.Register(IconEffect{Icon: Icon(600), Name: "First", Order: 1, Resolve: noop})
+	registry.Register(IconEffect{Icon: Icon(601), Name: "Last", Order: 100, Resolve: noop})
+	registry.Register(IconEffect{Icon: Icon(602), Name: "Tied", Order: 10, Resolve: noop})
+
+	expected := []string{"First", "Cooling", "Tied", "Boost", "Last"}
+	effects := registry.GetEffects()
+	if len(effects) != len(expected) {
+		t.Fatalf("GetEffects() returned %d effects, want %d", len(effects), len(expected))
+	}
+	for i, name := range expected {
+		if effects[i].Name != name {
+			t.Errorf("GetEffects()[%d] = %s, want %s", i, effects[i].Name, name)
+		}
+	}
+}
+
+func TestIconRegistry_ResolveIcons(t *testing.T) {
+	t.Run("Optional icons are skipped when declined", func(t *testing.T) {
+		player := createIconTestPlayer([]Card{NewHeatCard()}, nil, 0)
+		player.AddIcons(map[Icon]int{IconCooling: 1})
+
+		if err := NewIconRegistry().ResolveIcons(player, nil); err != nil {
+			t.Fatalf("ResolveIcons() error = %v", err)
+		}
+		if player.GetCar().GetEngine() != 0 {
+			t.Errorf("Engine = %d, want 0", player.GetCar().GetEngine())
+		}
+		if player.GetIcons()[IconCooling] != 0 {
+			t.Errorf("Cooling icons = %d, want 0 after resolution", player.GetIcons()[IconCooling])
+		}
+	})
+
+	t.Run("Cooling returns heat cards to the engine", func(t *testing.T) {
+		speedCard := NewCard("Speed 2", 2, nil, true, true, true)
+		player := createIconTestPlayer([]Card{NewHeatCard(), speedCard, NewHeatCard()}, nil, 1)
+		player.AddIcons(map[Icon]int{IconCooling: 3})
+
+		accept := func(effect IconEffect, count int) bool { return true }
+		if err := NewIconRegistry().ResolveIcons(player, accept); err != nil {
+			t.Fatalf("ResolveIcons() error = %v", err)
+		}
+		if player.GetCar().GetEngine() != 3 {
+			t.Errorf("Engine = %d, want 3", player.GetCar().GetEngine())
+		}
+		cards := player.GetHand().GetCards()
+		if len(cards) != 1 || cards[0] != speedCard {
+			t.Errorf("Hand = %v, want only the speed card", cards)
+		}
+	})
+
+	t.Run("Boost adds the speed of the first basic card", func(t *testing.T) {
+		deckCards := []Card{NewStressCard(), NewCard("Speed 3", 3, nil, true, true, true)}
+		player := createIconTestPlayer(nil, deckCards, 0)
+		player.GetCar().SetSpeed(2)
+		player.AddIcons(map[Icon]int{IconBoost: 1})
+
+		accept := func(effect IconEffect, count int) bool { return effect.Icon == IconBoost }
+		if err := NewIconRegistry().ResolveIcons(player, accept); err != nil {
+			t.Fatalf("ResolveIcons() error = %v", err)
+		}
+		if player.GetCar().GetSpeed() != 5 {
+			t.Errorf("Speed = %d, want 5", player.GetCar().GetSpeed())
+		}
+	})
+
+	t.Run("Mandatory icons resolve without accept", func(t *testing.T) {
+		registry := NewIconRegistry()
+		resolved := 0
+		registry.Register(IconEffect{
+			Icon:      Icon(700),
+			Name:      "Forced",
+			Mandatory: true,
+			Resolve: func(player Player, count int) error {
+				resolved += count
+				return nil
+			},
+		})
+
+		player := createIconTestPlayer(nil, nil, 0)
+		player.AddIcons(map[Icon]int{Icon(700): 2})
+		if err := registry.ResolveIcons(player, nil); err != nil {
+			t.Fatalf("ResolveIcons() error = %v", err)
+		}
+		if resolved != 2 {
+			t.Errorf("Resolved %d icons, want 2", resolved)
+		}
+	})
+
+	t.Run("Resolver errors are returned", func(t *testing.T) {
+		registry := NewIconRegistry()
+		registry.Register(IconEffect{
+			Icon:      Icon(701),
+			Name:      "Broken",
+			Mandatory: true,
+			Resolve: func(player Player, count int) error {
+				return errors.New("broken")
+			},
+		})
+
+		player := createIconTestPlayer(nil, nil, 0)
+		player.AddIcons(map[Icon]int{Icon(701): 1})
+		if err := registry.ResolveIcons(player, nil); err == nil {
+			t.Error("ResolveIcons() should return the resolver error")
+		}
+	})
+
+	t.Run("Nil player", func(t *testing.T) {
+		if err := NewIconRegistry().ResolveIcons(nil, nil); err == nil {
+			t.Error("ResolveIcons() should fail for a nil player")
+		}
+	})
+}
+
+func TestRegisterIcon(t *testing.T) {
+	tests := []struct {
+		name     string
+		icon     Icon
+		iconName string
+		wantErr  bool
+	}{
+		{
+			name:     "Register new icon",
+			icon:     Icon(800),
+			iconName: "Expansion",
+			wantErr:  false,
+		},
+		{
+			name:     "Register base icon again",
+			icon:     IconBoost,
+			iconName: "Boost",
+			wantErr:  true,
+		},
+		{
+			name:     "Register with empty name",
+			icon:     Icon(801),
+			iconName: "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterIcon(tt.icon, tt.iconName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegisterIcon() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.icon.String() != tt.iconName {
+				t.Errorf("Icon.String() = %s, want %s", tt.icon.String(), tt.iconName)
+			}
+		})
+	}
+}
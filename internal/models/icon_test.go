@@ -69,6 +69,48 @@ func TestIcon_String(t *testing.T) {
 	}
 }
 
+func TestParseIcon(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Icon
+		wantErr  bool
+	}{
+		{
+			name:     "Display name",
+			input:    "Boost",
+			expected: IconBoost,
+		},
+		{
+			name:     "Lower case name",
+			input:    "cooling",
+			expected: IconCooling,
+		},
+		{
+			name:    "Unknown name",
+			input:   "Turbo",
+			wantErr: true,
+		},
+		{
+			name:    "Empty name",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseIcon(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIcon(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && result != tt.expected {
+				t.Errorf("ParseIcon(%q) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestIconTypeConversion(t *testing.T) {
 	tests := []struct {
 		name     string
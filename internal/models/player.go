@@ -1,5 +1,10 @@
 package models
 
+import (
+	"errors"
+	"math/rand"
+)
+
 // Player represents a player in the racing game
 // A player has a name, car, deck, hand, discard pile, and manages played cards and icons
 type Player interface {
@@ -42,15 +47,39 @@ type Player interface {
 	// Returns: none
 	AddIcons(icons map[Icon]int)
 
+	// ClearIcon removes all accumulated icons of the given type
+	// Input: icon - the icon type to clear
+	// Returns: the number of icons that were cleared
+	ClearIcon(icon Icon) int
+
 	// PlayCard plays a card from the player's hand and adds it to played cards
 	// Input: index - the index of the card in the hand to play
 	// Returns: an error if the card cannot be played
 	PlayCard(index int) error
 
+	// GetPlayedCards returns the cards played this round
+	// Returns: a copy of the played cards in the order they were played
+	GetPlayedCards() []Card
+
+	// RemovePlayedCard takes a card back out of the played cards
+	// Input: index - the index of the card in the played cards
+	// Returns: the removed card, an error if the index is out of bounds
+	RemovePlayedCard(index int) (Card, error)
+
 	// ResolvePlayedCards processes all played cards, calculating speed and adding icons
 	// Also handles special cards like Stress cards
 	// Returns: none
 	ResolvePlayedCards()
+
+	// DiscardPlayedCards moves the cards played this round to the discard pile
+	// One-use cards leave the game instead
+	// Returns: none
+	DiscardPlayedCards()
+
+	// Clone copies the player with its car, deck, hand, discard pile, played cards and icons
+	// Input: rng - the random source the copied deck shuffles with, nil to keep the original's
+	// Returns: a new Player that shares no mutable state with the original
+	Clone(rng *rand.Rand) Player
 }
 
 type player struct {
@@ -144,6 +173,15 @@ func (p *player) GetIcons() map[Icon]int {
 	return p.icons
 }
 
+// ClearIcon removes all accumulated icons of the given type
+// Input: icon - the icon type to clear
+// Returns: the number of icons that were cleared
+func (p *player) ClearIcon(icon Icon) int {
+	count := p.icons[icon]
+	delete(p.icons, icon)
+	return count
+}
+
 // PlayCard plays a card from the player's hand and adds it to played cards
 // Input: index - the index of the card in the hand to play
 // Returns: an error if the card cannot be played
@@ -158,6 +196,33 @@ func (p *player) PlayCard(index int) error {
 	return nil
 }
 
+// GetPlayedCards returns the cards played this round
+// Input: none
+// Returns: a copy of the played cards in the order they were played
+func (p *player) GetPlayedCards() []Card {
+	// Return a copy to prevent external modification
+	result := make([]Card, len(p.playedCards))
+	copy(result, p.playedCards)
+	return result
+}
+
+// RemovePlayedCard takes a card back out of the played cards
+// Used by effects that send a played card somewhere other than the discard pile
+// Input: index - the index of the card in the played cards
+// Returns: the removed card, an error if the index is out of bounds
+func (p *player) RemovePlayedCard(index int) (Card, error) {
+	if index < 0 || index >= len(p.playedCards) {
+		return nil, errors.New("invalid played card index")
+	}
+
+	card := p.playedCards[index]
+	last := len(p.playedCards) - 1
+	copy(p.playedCards[index:], p.playedCards[index+1:])
+	p.playedCards[last] = nil
+	p.playedCards = p.playedCards[:last]
+	return card, nil
+}
+
 // AddIcons adds icons to the player's accumulated icon count
 // Input: icons - a map of icon types to counts to add
 // Returns: none
@@ -185,27 +250,73 @@ func (p *player) ResolvePlayedCards() {
 	p.car.SetSpeed(speed)
 }
 
+// DiscardPlayedCards moves the cards played this round to the discard pile, one-use cards leave the game
+// Input: none
+// Returns: none
+func (p *player) DiscardPlayedCards() {
+	for _, card := range p.playedCards {
+		if !card.IsOneUse() {
+			p.discardPile.AddCard(card)
+		}
+	}
+	clear(p.playedCards)
+	p.playedCards = p.playedCards[:0]
+}
+
 // resolveStressCard handles the special Stress card effect
 // Draws cards until a basic card is found, discarding non-basic cards
 // Input: none
 // Returns: the speed value of the basic card found
 func (p *player) resolveStressCard() int {
-	isBasic := false
-	speed := 0
+	return flipUntilBasic(p.deck, p.discardPile)
+}
 
-	for !isBasic {
-		if p.deck.IsEmpty() {
-			p.discardPile.ResetDeck(p.deck)
+// flipUntilBasic flips cards from the deck until a basic card is found
+// Every flipped card goes to the discard pile, which is shuffled back in once when the deck runs out
+// Used by Stress cards and the Boost icon
+// Input: deck - the deck to flip from
+//
+//	discardPile - the discard pile that receives the flipped cards
+//
+// Returns: the speed value of the basic card found, 0 if there is none left to flip
+func flipUntilBasic(deck Deck, discardPile DiscardPile) int {
+	reshuffled := false
+	for {
+		if deck.IsEmpty() {
+			if reshuffled {
+				return 0
+			}
+			discardPile.ResetDeck(deck)
+			reshuffled = true
 		}
 
-		card := p.deck.DrawCard()
-		if card.IsBasic() {
-			isBasic = true
-			speed = card.GetSpeed()
+		card := deck.DrawCard()
+		if card == nil {
+			return 0
 		}
 
-		p.discardPile.AddCard(card)
+		discardPile.AddCard(card)
+		if card.IsBasic() {
+			return card.GetSpeed()
+		}
 	}
+}
 
-	return speed
+// Clone copies the player with its car, deck, hand, discard pile, played cards and icons
+// Input: rng - the random source the copied deck shuffles with, nil to keep the original's
+// Returns: a new Player that shares no mutable state with the original
+func (p *player) Clone(rng *rand.Rand) Player {
+	icons := make(map[Icon]int, len(p.icons))
+	for icon, count := range p.icons {
+		icons[icon] = count
+	}
+	return &player{
+		name:        p.name,
+		car:         p.car.Clone(),
+		discardPile: p.discardPile.Clone(),
+		deck:        p.deck.Clone(rng),
+		hand:        p.hand.Clone(),
+		playedCards: p.GetPlayedCards(),
+		icons:       icons,
+	}
 }
@@ -598,3 +598,137 @@ func BenchmarkPlayer_PlayCard(b *testing.B) {
 		player.PlayCard(0)
 	}
 }
+
+func TestPlayer_ClearIcon(t *testing.T) {
+	player := NewPlayer("TestPlayer", NewCar("red", 3), NewDiscardPile(), NewDeck([]Card{}), NewHand())
+	player.AddIcons(map[Icon]int{IconBoost: 2, IconCooling: 1})
+
+	if cleared := player.ClearIcon(IconBoost); cleared != 2 {
+		t.Errorf("ClearIcon(IconBoost) = %d, want 2", cleared)
+	}
+	if cleared := player.ClearIcon(IconBoost); cleared != 0 {
+		t.Errorf("ClearIcon(IconBoost) second call = %d, want 0", cleared)
+	}
+	if player.GetIcons()[IconCooling] != 1 {
+		t.Errorf("Cooling icons = %d, want 1", player.GetIcons()[IconCooling])
+	}
+}
+
+func TestPlayer_ResolvePlayedCards_StressWithoutBasicCards(t *testing.T) {
+	// A deck with no basic cards must not loop forever
+	deck := NewDeck([]Card{NewHeatCard(), NewHeatCard()})
+	hand := NewHand()
+	hand.AddCards([]Card{NewStressCard()})
+	player := NewPlayer("TestPlayer", NewCar("red", 3), NewDiscardPile(), deck, hand)
+
+	if err := player.PlayCard(0); err != nil {
+		t.Fatalf("PlayCard() error = %v", err)
+	}
+	player.ResolvePlayedCards()
+
+	if player.GetCar().GetSpeed() != 0 {
+		t.Errorf("Speed = %d, want 0", player.GetCar().GetSpeed())
+	}
+}
+
+func TestPlayer_GetPlayedCardsAndRemovePlayedCard(t *testing.T) {
+	cards := createPlayerTestCards()
+	hand := NewHand()
+	hand.AddCards(cards)
+	player := NewPlayer("TestPlayer", NewCar("red", 3), NewDiscardPile(), NewDeck([]Card{}), hand)
+
+	player.PlayCard(0)
+	player.PlayCard(0)
+
+	played := player.GetPlayedCards()
+	if len(played) != 2 || played[0] != cards[0] || played[1] != cards[1] {
+		t.Fatalf("GetPlayedCards() = %v, want the first two cards", played)
+	}
+
+	card, err := player.RemovePlayedCard(0)
+	if err != nil {
+		t.Fatalf("RemovePlayedCard() error = %v", err)
+	}
+	if card != cards[0] {
+		t.Errorf("RemovePlayedCard() = %v, want %v", card, cards[0])
+	}
+	if len(player.GetPlayedCards()) != 1 {
+		t.Errorf("GetPlayedCards() after removal = %d cards, want 1", len(player.GetPlayedCards()))
+	}
+	if _, err := player.RemovePlayedCard(5); err == nil {
+		t.Error("RemovePlayedCard() with an invalid index should fail")
+	}
+}
+
+func TestPlayer_DiscardPlayedCards(t *testing.T) {
+	cards := createPlayerTestCards()
+	hand := NewHand()
+	hand.AddCards(cards)
+	discardPile := NewDiscardPile()
+	player := NewPlayer("TestPlayer", NewCar("red", 3), discardPile, NewDeck([]Card{}), hand)
+
+	player.PlayCard(0)
+	player.PlayCard(0)
+	player.DiscardPlayedCards()
+
+	if len(player.GetPlayedCards()) != 0 {
+		t.Errorf("GetPlayedCards() = %d cards, want 0", len(player.GetPlayedCards()))
+	}
+	if len(discardPile.GetCards()) != 2 {
+		t.Errorf("Discard pile has %d cards, want 2", len(discardPile.GetCards()))
+	}
+}
+
+func TestPlayer_DiscardPlayedCards_OneUse(t *testing.T) {
+	hand := NewHand()
+	hand.AddCards([]Card{NewOneUseCard("Sponsor", 2, nil), NewCard("Regular", 1, nil, true, true, true)})
+	discardPile := NewDiscardPile()
+	player := NewPlayer("TestPlayer", NewCar("red", 3), discardPile, NewDeck([]Card{}), hand)
+
+	player.PlayCard(0)
+	player.PlayCard(0)
+	player.DiscardPlayedCards()
+
+	cards := discardPile.GetCards()
+	if len(cards) != 1 || cards[0].GetName() != "Regular" {
+		t.Errorf("Discard pile = %d cards, want only the regular card", len(cards))
+	}
+}
+
+func TestPlayer_Clone(t *testing.T) {
+	hand := NewHand()
+	hand.AddCards(createPlayerTestCards())
+	original := NewPlayer("Player", NewCar("red", 6), NewDiscardPile(), NewDeck(createPlayerTestCards()), hand)
+	original.PlayCard(0)
+	original.AddIcons(map[Icon]int{IconCooling: 2})
+
+	clone := original.Clone(nil)
+	if clone.GetName() != "Player" || clone.GetCar() == original.GetCar() {
+		t.Fatal("Clone() should copy the name and the car")
+	}
+
+	clone.PlayCard(0)
+	clone.DiscardCard(0)
+	clone.DrawCard(clone.GetDeck())
+	clone.ClearIcon(IconCooling)
+	clone.GetCar().SetSpeed(9)
+
+	if len(original.GetHand().GetCards()) != 4 {
+		t.Errorf("original hand has %d cards, want 4", len(original.GetHand().GetCards()))
+	}
+	if len(original.GetPlayedCards()) != 1 {
+		t.Errorf("original played cards = %d, want 1", len(original.GetPlayedCards()))
+	}
+	if len(original.GetDiscardPile().GetCards()) != 0 {
+		t.Error("original discard pile should stay empty")
+	}
+	if original.GetDeck().IsEmpty() || len(original.GetDeck().GetCards()) != 5 {
+		t.Error("original deck should keep its cards")
+	}
+	if original.GetIcons()[IconCooling] != 2 {
+		t.Error("original icons should not change")
+	}
+	if original.GetCar().GetSpeed() != 0 {
+		t.Error("original car should not change")
+	}
+}
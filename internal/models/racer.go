@@ -0,0 +1,13 @@
+package models
+
+// Racer is anything that drives a car in a race
+// A Player races with a hand and a deck; automated drivers only need a name and a car
+type Racer interface {
+	// GetName returns the racer's name
+	// Returns: the racer's name as a string
+	GetName() string
+
+	// GetCar returns the car the racer drives
+	// Returns: the racer's car instance
+	GetCar() Car
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+)
+
+// testRacer is a Racer without a hand or a deck
+type testRacer struct {
+	name string
+	car  Car
+}
+
+func (r *testRacer) GetName() string {
+	return r.name
+}
+
+func (r *testRacer) GetCar() Car {
+	return r.car
+}
+
+func TestRacer_InterfaceCompliance(t *testing.T) {
+	var _ Racer = NewPlayer("Player", NewCar("red", 6), NewDiscardPile(), NewDeck(nil), NewHand())
+	var _ Racer = &testRacer{name: "Legend", car: NewCar("blue", 0)}
+}
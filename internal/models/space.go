@@ -12,14 +12,19 @@ type Space interface {
 	IsOccupied() bool
 	GetCorner() int
 	IsFinishLine() bool
+	SetNext(Space)
+	SetPrevious(Space)
+	GetRoadCondition() RoadCondition
+	SetRoadCondition(RoadCondition)
 }
 
 type space struct {
-	cars       []Car
-	next       Space
-	previous   Space
-	corner     int
-	finishLine bool
+	cars          []Car
+	next          Space
+	previous      Space
+	corner        int
+	finishLine    bool
+	roadCondition RoadCondition
 }
 
 func NewSpace(next Space, previous Space, corner int, finishLine bool) Space {
@@ -83,3 +88,24 @@ func (s *space) GetCorner() int {
 func (s *space) IsFinishLine() bool {
 	return s.finishLine
 }
+
+// SetNext links the space that follows this one on the track
+func (s *space) SetNext(next Space) {
+	s.next = next
+}
+
+// SetPrevious links the space that comes before this one on the track
+func (s *space) SetPrevious(previous Space) {
+	s.previous = previous
+}
+
+// GetRoadCondition returns the road-condition token on the space
+// Only corner spaces carry road conditions; other spaces return a dry road
+func (s *space) GetRoadCondition() RoadCondition {
+	return s.roadCondition
+}
+
+// SetRoadCondition places a road-condition token on the space
+func (s *space) SetRoadCondition(condition RoadCondition) {
+	s.roadCondition = condition
+}
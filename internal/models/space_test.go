@@ -576,3 +576,31 @@ func BenchmarkSpace_IsFull(b *testing.B) {
 		space.IsFull()
 	}
 }
+
+func TestSpace_Links(t *testing.T) {
+	first := NewSpace(nil, nil, 0, true)
+	second := NewSpace(nil, nil, 0, false)
+
+	first.SetNext(second)
+	second.SetPrevious(first)
+
+	if first.GetNext() != second {
+		t.Error("SetNext() did not link the next space")
+	}
+	if second.GetPrevious() != first {
+		t.Error("SetPrevious() did not link the previous space")
+	}
+}
+
+func TestSpace_RoadCondition(t *testing.T) {
+	space := NewSpace(nil, nil, 3, false)
+	if space.GetRoadCondition() != (RoadCondition{}) {
+		t.Errorf("GetRoadCondition() = %v, want a dry road", space.GetRoadCondition())
+	}
+
+	condition := GetCornerConditions()[0]
+	space.SetRoadCondition(condition)
+	if space.GetRoadCondition() != condition {
+		t.Errorf("GetRoadCondition() = %v, want %v", space.GetRoadCondition(), condition)
+	}
+}
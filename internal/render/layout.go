@@ -0,0 +1,91 @@
+package render
+
+import (
+	"math"
+
+	"race-cars/internal/tracks"
+)
+
+// Point is a position in layout units, where consecutive spaces are 1 apart
+// Y grows downwards, as on a screen
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Layout places the spaces of a track on a plane so the track can be drawn as a loop
+type Layout struct {
+	// Spaces are the centers of the spaces, space 0 being the finish line
+	Spaces []Point `json:"spaces"`
+
+	// Headings are the directions of travel at each space, in radians counterclockwise from pointing right
+	Headings []float64 `json:"headings"`
+
+	// Width and Height are the size of the box holding every space, which starts at 0, 0
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// minLoopCorners is the fewest corners that make a polygon; tracks with fewer are laid out as a circle
+const minLoopCorners = 3
+
+// NewLayout lays a track out as a loop shaped by its corners
+// The cars leave the finish line heading right along the bottom and turn left by the same angle at every corner,
+// so the straights between corners become the sides of a polygon; any gap left by straights of different lengths
+// is spread evenly over every space to close the loop
+// Input: track - the track to lay out
+// Returns: the Layout, empty for a track without spaces
+func NewLayout(track tracks.Track) Layout {
+	n := track.Length
+	if n <= 0 {
+		return Layout{}
+	}
+
+	// step i runs from space i to space i + 1
+	steps := make([]Point, n)
+	corner := 0
+	for i := range steps {
+		var heading float64
+		if len(track.Corners) < minLoopCorners {
+			heading = 2 * math.Pi * float64(i) / float64(n)
+		} else {
+			for corner < len(track.Corners) && track.Corners[corner].Space <= i {
+				corner++
+			}
+			heading = 2 * math.Pi * float64(corner) / float64(len(track.Corners))
+		}
+		steps[i] = Point{X: math.Cos(heading), Y: -math.Sin(heading)}
+	}
+
+	var gap Point
+	for _, step := range steps {
+		gap.X += step.X
+		gap.Y += step.Y
+	}
+	for i := range steps {
+		steps[i].X -= gap.X / float64(n)
+		steps[i].Y -= gap.Y / float64(n)
+	}
+
+	layout := Layout{Spaces: make([]Point, n), Headings: make([]float64, n)}
+	for i := 1; i < n; i++ {
+		layout.Spaces[i] = Point{X: layout.Spaces[i-1].X + steps[i-1].X, Y: layout.Spaces[i-1].Y + steps[i-1].Y}
+	}
+	for i := range layout.Headings {
+		in, out := steps[(i+n-1)%n], steps[i]
+		layout.Headings[i] = math.Atan2(-(in.Y + out.Y), in.X+out.X)
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range layout.Spaces {
+		minX, maxX = min(minX, p.X), max(maxX, p.X)
+		minY, maxY = min(minY, p.Y), max(maxY, p.Y)
+	}
+	for i := range layout.Spaces {
+		layout.Spaces[i].X -= minX
+		layout.Spaces[i].Y -= minY
+	}
+	layout.Width, layout.Height = maxX-minX, maxY-minY
+	return layout
+}
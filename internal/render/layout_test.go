@@ -0,0 +1,65 @@
+package render
+
+import (
+	"math"
+	"testing"
+
+	"race-cars/internal/tracks"
+)
+
+func TestNewLayout_Square(t *testing.T) {
+	// Four corners on a 16-space track make a square with sides of 4 and the finish line in the middle of the bottom
+	track := tracks.Track{Name: "Square", Length: 16, Laps: 1, Corners: []tracks.Corner{
+		{Space: 2, SpeedLimit: 3}, {Space: 6, SpeedLimit: 3}, {Space: 10, SpeedLimit: 3}, {Space: 14, SpeedLimit: 3},
+	}}
+	layout := NewLayout(track)
+
+	if len(layout.Spaces) != 16 || len(layout.Headings) != 16 {
+		t.Fatalf("layout has %d spaces and %d headings, want 16", len(layout.Spaces), len(layout.Headings))
+	}
+	if !near(layout.Width, 4) || !near(layout.Height, 4) {
+		t.Errorf("layout is %.2f by %.2f, want 4 by 4", layout.Width, layout.Height)
+	}
+
+	want := map[int]Point{0: {X: 2, Y: 4}, 2: {X: 4, Y: 4}, 6: {X: 4, Y: 0}, 10: {X: 0, Y: 0}, 14: {X: 0, Y: 4}}
+	for space, point := range want {
+		if got := layout.Spaces[space]; !near(got.X, point.X) || !near(got.Y, point.Y) {
+			t.Errorf("space %d = %+v, want %+v", space, got, point)
+		}
+	}
+
+	// Straights head along the sides and corners head diagonally between them
+	headings := map[int]float64{0: 0, 4: math.Pi / 2, 2: math.Pi / 4, 8: math.Pi, 12: -math.Pi / 2}
+	for space, heading := range headings {
+		if got := layout.Headings[space]; !near(math.Remainder(got-heading, 2*math.Pi), 0) {
+			t.Errorf("space %d heading = %.2f, want %.2f", space, got, heading)
+		}
+	}
+}
+
+func TestNewLayout_Closes(t *testing.T) {
+	tracksToTest := append(tracks.GetTracks(), tracks.Track{Name: "Oval", Length: 20, Laps: 1, Corners: []tracks.Corner{{Space: 5, SpeedLimit: 3}}})
+	for _, track := range tracksToTest {
+		t.Run(track.Name, func(t *testing.T) {
+			layout := NewLayout(track)
+			for i := range layout.Spaces {
+				p, q := layout.Spaces[i], layout.Spaces[(i+1)%len(layout.Spaces)]
+				if distance := math.Hypot(q.X-p.X, q.Y-p.Y); distance < 0.5 || distance > 1.5 {
+					t.Errorf("spaces %d and %d are %.2f apart, want about 1", i, (i+1)%len(layout.Spaces), distance)
+				}
+				if p.X < -1e-9 || p.Y < -1e-9 || p.X > layout.Width+1e-9 || p.Y > layout.Height+1e-9 {
+					t.Errorf("space %d at %+v is outside the layout", i, p)
+				}
+			}
+		})
+	}
+
+	if layout := NewLayout(tracks.Track{}); len(layout.Spaces) != 0 {
+		t.Error("NewLayout() of an empty track should have no spaces")
+	}
+}
+
+// Helper function to compare floats with a tolerance
+func near(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,133 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+const (
+	// spacePixels is the distance between consecutive spaces in an SVG
+	spacePixels = 28.0
+
+	// svgMargin leaves room around the loop for the corner labels
+	svgMargin = 48.0
+
+	// trackWidth is the width of the road, wide enough for both lanes
+	trackWidth = 26.0
+
+	// laneOffset is how far each lane is from the middle of the road
+	laneOffset = 6.5
+
+	// carRadius is the size of a car
+	carRadius = 5.5
+
+	// labelOffset is how far a corner's speed limit is drawn outside the road
+	labelOffset = 26.0
+
+	// titleHeight is the band above the loop holding the track name
+	titleHeight = 32.0
+)
+
+// svgColors maps car colors to fill colors
+var svgColors = map[string]string{
+	string(models.Red):    "#d62728",
+	string(models.Blue):   "#1f77b4",
+	string(models.Green):  "#2ca02c",
+	string(models.Yellow): "#e6c200",
+	string(models.Orange): "#ff7f0e",
+	string(models.Black):  "#222222",
+	string(models.Gray):   "#8c8c8c",
+}
+
+// WriteSVG draws a board as an SVG image, for the web
+// The road follows the layout of the track with both lanes marked; the finish line is a black bar, corners are red bars
+// with their speed limit outside the road, and cars are dots in their color that name themselves on hover
+// Input: w - where the image is written
+//
+//	track - the track the board was built from, which shapes the loop
+//	board - the board with the cars on it
+//
+// Returns: an error if the board does not match the track or writing fails
+func WriteSVG(w io.Writer, track tracks.Track, board models.Board) error {
+	spaces := board.GetSpaces()
+	if len(spaces) != track.Length {
+		return fmt.Errorf("board has %d spaces, track %s has %d", len(spaces), track.Name, track.Length)
+	}
+
+	layout := NewLayout(track)
+	width := layout.Width*spacePixels + 2*svgMargin
+	height := layout.Height*spacePixels + 2*svgMargin + titleHeight
+	at := func(i int, offset float64) (float64, float64) {
+		// Positive offsets are to the left of the direction of travel, the inside of the loop
+		p, heading := layout.Spaces[i], layout.Headings[i]
+		return svgMargin + p.X*spacePixels - math.Sin(heading)*offset, svgMargin + titleHeight + p.Y*spacePixels - math.Cos(heading)*offset
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`+"\n", width, height, width, height)
+	fmt.Fprintf(&svg, "<title>%s</title>\n", html.EscapeString(track.Name))
+	svg.WriteString(`<rect width="100%" height="100%" fill="#f4f1ea"/>` + "\n")
+	fmt.Fprintf(&svg, `<text x="12" y="24" font-family="sans-serif" font-size="16" font-weight="bold">%s, %s</text>`+"\n",
+		html.EscapeString(track.Name), countLaps(board.GetNumberOfLaps()))
+
+	points := make([]string, len(spaces))
+	for i := range spaces {
+		x, y := at(i, 0)
+		points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+	fmt.Fprintf(&svg, `<polygon points="%s" fill="none" stroke="#7f7f7f" stroke-width="%.0f" stroke-linejoin="round"/>`+"\n",
+		strings.Join(points, " "), trackWidth)
+
+	for i, space := range spaces {
+		switch {
+		case space.IsFinishLine():
+			writeBar(&svg, at, i, "#000000")
+		case space.GetCorner() > 0:
+			writeBar(&svg, at, i, "#c0392b")
+			x, y := at(i, -labelOffset)
+			fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="10" fill="#ffffff" stroke="#c0392b" stroke-width="2"/>`+"\n", x, y)
+			fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" font-family="sans-serif" font-size="12" font-weight="bold" text-anchor="middle" dominant-baseline="central">%d</text>`+"\n",
+				x, y, board.GetCornerLimit(i))
+		}
+
+		cars := space.GetCars()
+		for lane, offset := range []float64{laneOffset, -laneOffset} {
+			x, y := at(i, offset)
+			if lane >= len(cars) {
+				fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="1.5" fill="#d9d9d9"/>`+"\n", x, y)
+				continue
+			}
+			color := cars[lane].GetColor()
+			fill, ok := svgColors[color]
+			if !ok {
+				fill = "#ffffff"
+			}
+			fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s" stroke="#000000" stroke-width="1"><title>%s, space %d, lap %d</title></circle>`+"\n",
+				x, y, carRadius, fill, html.EscapeString(color), i, cars[lane].GetLap())
+		}
+	}
+
+	svg.WriteString("</svg>\n")
+	_, err := io.WriteString(w, svg.String())
+	return err
+}
+
+// writeBar draws a bar across the road at a space
+// Input: svg - the image being written
+//
+//	at - finds the point at an offset from a space
+//	index - the index of the space
+//	color - the color of the bar
+//
+// Returns: none
+func writeBar(svg *strings.Builder, at func(int, float64) (float64, float64), index int, color string) {
+	x1, y1 := at(index, trackWidth/2)
+	x2, y2 := at(index, -trackWidth/2)
+	fmt.Fprintf(svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="4"/>`+"\n", x1, y1, x2, y2, color)
+}
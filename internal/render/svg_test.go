@@ -0,0 +1,54 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"race-cars/internal/tracks"
+)
+
+func TestWriteSVG(t *testing.T) {
+	track, board := createTestBoard(t)
+	track.Name = "Square & Co"
+
+	var out bytes.Buffer
+	if err := WriteSVG(&out, track, board); err != nil {
+		t.Fatalf("WriteSVG() error = %v", err)
+	}
+
+	// The image is well-formed XML
+	decoder := xml.NewDecoder(bytes.NewReader(out.Bytes()))
+	counts := make(map[string]int)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err.Error() != "EOF" {
+				t.Fatalf("WriteSVG() wrote invalid XML: %v", err)
+			}
+			break
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			counts[start.Name.Local]++
+		}
+	}
+
+	// One bar for the finish line and one per corner, a label per corner, and a dot per lane of every space
+	if counts["line"] != 5 {
+		t.Errorf("WriteSVG() drew %d bars, want 5", counts["line"])
+	}
+	if counts["circle"] != 4+16*2 {
+		t.Errorf("WriteSVG() drew %d circles, want %d", counts["circle"], 4+16*2)
+	}
+	for _, want := range []string{`fill="#d62728"`, `fill="#222222"`, "<title>Red, space 1, lap 0</title>", "Square &amp; Co, 1 lap"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("WriteSVG() is missing %s", want)
+		}
+	}
+
+	other, _ := tracks.GetTrack("USA")
+	if err := WriteSVG(&out, other, board); err == nil {
+		t.Error("WriteSVG() with a board from another track should fail")
+	}
+}
@@ -0,0 +1,218 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+const (
+	reset = "\033[0m"
+	bold  = "\033[1m"
+	dim   = "\033[2m"
+
+	// minTextScale is the smallest number of character cells per layout unit
+	minTextScale = 1.0
+
+	// maxTextScale stops growing a text layout whose loop comes too close to itself
+	maxTextScale = 4.0
+
+	// textScaleStep is how much a text layout grows when two spaces land in the same cell
+	textScaleStep = 0.25
+)
+
+// ansiColors maps car colors to ANSI colors; black cars are dark gray so they show on dark terminals
+var ansiColors = map[string]string{
+	string(models.Red):    "\033[31m",
+	string(models.Blue):   "\033[34m",
+	string(models.Green):  "\033[32m",
+	string(models.Yellow): "\033[33m",
+	string(models.Orange): "\033[38;5;208m",
+	string(models.Black):  "\033[90m",
+	string(models.Gray):   "\033[37m",
+}
+
+// carLetters gives every car color its own letter; black is K so it does not clash with blue
+var carLetters = map[string]string{
+	string(models.Red):    "R",
+	string(models.Blue):   "B",
+	string(models.Green):  "G",
+	string(models.Yellow): "Y",
+	string(models.Orange): "O",
+	string(models.Black):  "K",
+	string(models.Gray):   "A",
+}
+
+// cell is the character cell a space is drawn in
+type cell struct {
+	row, col int
+}
+
+// WriteANSI draws a board as a loop of text cells in ANSI colors, for terminals
+// Each space is two characters, one per lane: a car's letter, || on the finish line, the speed limit on a corner, or dots
+// Input: w - where the board is drawn
+//
+//	track - the track the board was built from, which shapes the loop
+//	board - the board with the cars on it
+//
+// Returns: an error if the board does not match the track or writing fails
+func WriteANSI(w io.Writer, track tracks.Track, board models.Board) error {
+	return writeText(w, track, board, true)
+}
+
+// WriteText draws a board like WriteANSI but without colors, for bug reports and logs
+// Input: w - where the board is drawn
+//
+//	track - the track the board was built from, which shapes the loop
+//	board - the board with the cars on it
+//
+// Returns: an error if the board does not match the track or writing fails
+func WriteText(w io.Writer, track tracks.Track, board models.Board) error {
+	return writeText(w, track, board, false)
+}
+
+// Paint colors text in a car's color with ANSI codes
+// Input: color - the car color
+//
+//	text - the text to color
+//
+// Returns: the text wrapped in ANSI codes, unchanged for unknown colors
+func Paint(color string, text string) string {
+	code, ok := ansiColors[color]
+	if !ok {
+		return text
+	}
+	return code + text + reset
+}
+
+// GetCarLetter returns the letter a car is drawn with
+// Input: color - the car color
+// Returns: the letter, the color's initial for colors without a letter of their own
+func GetCarLetter(color string) string {
+	if letter, ok := carLetters[color]; ok {
+		return letter
+	}
+	if color == "" {
+		return "?"
+	}
+	return strings.ToUpper(color[:1])
+}
+
+// writeText draws a board as text
+// Input: w - where the board is drawn
+//
+//	track - the track the board was built from
+//	board - the board with the cars on it
+//	ansi - whether to use ANSI colors
+//
+// Returns: an error if the board does not match the track or writing fails
+func writeText(w io.Writer, track tracks.Track, board models.Board, ansi bool) error {
+	spaces := board.GetSpaces()
+	if len(spaces) != track.Length {
+		return fmt.Errorf("board has %d spaces, track %s has %d", len(spaces), track.Name, track.Length)
+	}
+
+	cells, rows, cols := rasterize(NewLayout(track))
+	grid := make([][]string, rows)
+	for row := range grid {
+		grid[row] = make([]string, cols)
+		for col := range grid[row] {
+			grid[row][col] = "  "
+		}
+	}
+	for i, space := range spaces {
+		grid[cells[i].row][cells[i].col] = drawSpace(board, i, space, ansi)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s, %s\n", track.Name, countLaps(board.GetNumberOfLaps()))
+	for _, line := range grid {
+		text.WriteString(strings.TrimRight(strings.Join(line, ""), " ") + "\n")
+	}
+	text.WriteString("Cars race counterclockwise from the finish line ||; numbers are corner speed limits\n")
+	for _, car := range board.GetRanking() {
+		space, _ := board.FindCar(car)
+		letter := GetCarLetter(car.GetColor())
+		if ansi {
+			letter = Paint(car.GetColor(), bold+letter)
+		}
+		fmt.Fprintf(&text, "%s %s  space %d  lap %d\n", letter, car.GetColor(), space, car.GetLap())
+	}
+
+	_, err := io.WriteString(w, text.String())
+	return err
+}
+
+// drawSpace draws one space as two characters
+// Input: board - the board
+//
+//	index - the index of the space
+//	space - the space
+//	ansi - whether to use ANSI colors
+//
+// Returns: the characters
+func drawSpace(board models.Board, index int, space models.Space, ansi bool) string {
+	style := func(code string, text string) string {
+		if !ansi {
+			return text
+		}
+		return code + text + reset
+	}
+
+	if cars := space.GetCars(); len(cars) > 0 {
+		lanes := ""
+		for lane := 0; lane < 2; lane++ {
+			switch {
+			case lane >= len(cars):
+				lanes += style(dim, ".")
+			case ansi:
+				lanes += Paint(cars[lane].GetColor(), bold+GetCarLetter(cars[lane].GetColor()))
+			default:
+				lanes += GetCarLetter(cars[lane].GetColor())
+			}
+		}
+		return lanes
+	}
+
+	switch {
+	case space.IsFinishLine():
+		return style(bold, "||")
+	case space.GetCorner() > 0:
+		limit := fmt.Sprint(board.GetCornerLimit(index))
+		return style(bold, limit) + strings.Repeat(" ", max(2-len(limit), 0))
+	default:
+		return style(dim, "..")
+	}
+}
+
+// rasterize puts every space of a layout in its own character cell, growing the layout until no two spaces share one
+// A cell is two characters wide and one line high, which is about square on a terminal
+// Input: layout - the layout
+// Returns: the cell of every space, the number of rows and the number of columns
+func rasterize(layout Layout) ([]cell, int, int) {
+	cells := make([]cell, len(layout.Spaces))
+	for scale := minTextScale; ; scale += textScaleStep {
+		used := make(map[cell]bool, len(cells))
+		for i, p := range layout.Spaces {
+			cells[i] = cell{row: int(math.Round(p.Y * scale)), col: int(math.Round(p.X * scale))}
+			used[cells[i]] = true
+		}
+		if len(used) == len(cells) || scale >= maxTextScale {
+			return cells, int(math.Round(layout.Height*scale)) + 1, int(math.Round(layout.Width*scale)) + 1
+		}
+	}
+}
+
+// countLaps formats a number of laps, like "1 lap" or "3 laps"
+// Input: laps - the number of laps
+// Returns: the phrase
+func countLaps(laps int) string {
+	if laps == 1 {
+		return "1 lap"
+	}
+	return fmt.Sprintf("%d laps", laps)
+}
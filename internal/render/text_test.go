@@ -0,0 +1,78 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+func TestWriteText(t *testing.T) {
+	track, board := createTestBoard(t)
+
+	var out bytes.Buffer
+	if err := WriteText(&out, track, board); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"Square, 1 lap",
+		"3 ......3",
+		"..      ..",
+		"..      ..",
+		"..      ..",
+		"3 ..||RK3",
+		"Cars race counterclockwise from the finish line ||; numbers are corner speed limits",
+		"R Red  space 1  lap 0",
+		"K Black  space 1  lap 0",
+		"",
+	}, "\n")
+	if out.String() != want {
+		t.Errorf("WriteText() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestWriteANSI(t *testing.T) {
+	track, board := createTestBoard(t)
+
+	var out bytes.Buffer
+	if err := WriteANSI(&out, track, board); err != nil {
+		t.Fatalf("WriteANSI() error = %v", err)
+	}
+	if !strings.Contains(out.String(), Paint(string(models.Red), bold+"R")) || !strings.Contains(out.String(), Paint(string(models.Black), bold+"K")) {
+		t.Errorf("WriteANSI() should color the cars:\n%q", out.String())
+	}
+
+	other, _ := tracks.GetTrack("USA")
+	if err := WriteANSI(&out, other, board); err == nil {
+		t.Error("WriteANSI() with a board from another track should fail")
+	}
+}
+
+func TestGetCarLetter(t *testing.T) {
+	tests := map[string]string{"Red": "R", "Blue": "B", "Black": "K", "Purple": "P", "": "?"}
+	for color, want := range tests {
+		if got := GetCarLetter(color); got != want {
+			t.Errorf("GetCarLetter(%q) = %q, want %q", color, got, want)
+		}
+	}
+}
+
+// Helper function to create a square track with two cars side by side just past the finish line
+func createTestBoard(t *testing.T) (tracks.Track, models.Board) {
+	t.Helper()
+	track := tracks.Track{Name: "Square", Length: 16, Laps: 1, Corners: []tracks.Corner{
+		{Space: 2, SpeedLimit: 3}, {Space: 6, SpeedLimit: 3}, {Space: 10, SpeedLimit: 3}, {Space: 14, SpeedLimit: 3},
+	}}
+	board, err := track.NewBoard(0)
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	for _, color := range []models.Color{models.Red, models.Black} {
+		if err := board.PlaceCar(models.NewCar(string(color), 6), 1); err != nil {
+			t.Fatalf("PlaceCar() error = %v", err)
+		}
+	}
+	return track, board
+}
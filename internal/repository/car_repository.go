@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"race-cars/internal/models"
+)
+
+// ErrCarNotFound is returned when there is no car with an ID in the catalog
+var ErrCarNotFound = errors.New("car not found")
+
+// carColumns are the columns of the cars table in the order scanCar reads them
+const carColumns = `id, name, brand, model, year, engine_size, horsepower, top_speed, weight,
+	category, description, image_url, color, created_at, updated_at`
+
+// CarRepository stores the car catalog
+type CarRepository interface {
+	// GetAll returns the cars in the catalog in the order they were added
+	// Input: color - only return cars in this color, or every car when empty
+	// Returns: the cars, an error if they cannot be read
+	GetAll(color models.Color) ([]models.CatalogCar, error)
+
+	// GetByID returns a car from the catalog
+	// Input: id - the car ID
+	// Returns: the car, ErrCarNotFound if there is no car with the ID
+	GetByID(id int) (models.CatalogCar, error)
+
+	// Create adds a car to the catalog
+	// Input: car - the car to add; its ID and timestamps are set
+	// Returns: an error if the car cannot be saved
+	Create(car *models.CatalogCar) error
+
+	// Update replaces every field of a car in the catalog
+	// Input: car - the car with the ID to update; its timestamps are set
+	// Returns: ErrCarNotFound if there is no car with the ID, an error if the car cannot be saved
+	Update(car *models.CatalogCar) error
+
+	// Delete removes a car from the catalog
+	// Input: id - the car ID
+	// Returns: ErrCarNotFound if there is no car with the ID, an error if the car cannot be removed
+	Delete(id int) error
+}
+
+// sqlCarRepository keeps the car catalog in a SQL database
+type sqlCarRepository struct {
+	sqlDB
+}
+
+// NewPostgresCarRepository creates a car repository backed by Postgres
+// Input: db - the database, migrated with the migrations package
+// Returns: the CarRepository
+func NewPostgresCarRepository(db *sql.DB) CarRepository {
+	return &sqlCarRepository{sqlDB{db: db}}
+}
+
+// NewSQLiteCarRepository creates a car repository backed by a SQLite file
+// Input: db - the database, migrated with the migrations package
+// Returns: the CarRepository
+func NewSQLiteCarRepository(db *sql.DB) CarRepository {
+	return &sqlCarRepository{sqlDB{db: db, sqlite: true}}
+}
+
+// GetAll returns the cars in the catalog in the order they were added
+func (r *sqlCarRepository) GetAll(color models.Color) ([]models.CatalogCar, error) {
+	rows, err := r.db.Query(r.bind(`SELECT `+carColumns+` FROM cars WHERE $1 = '' OR color = $1 ORDER BY id`), string(color))
+	if err != nil {
+		return nil, fmt.Errorf("error querying cars: %w", err)
+	}
+	defer rows.Close()
+
+	cars := make([]models.CatalogCar, 0)
+	for rows.Next() {
+		car, err := scanCar(rows)
+		if err != nil {
+			return nil, err
+		}
+		cars = append(cars, car)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading cars: %w", err)
+	}
+	return cars, nil
+}
+
+// GetByID returns a car from the catalog
+func (r *sqlCarRepository) GetByID(id int) (models.CatalogCar, error) {
+	car, err := scanCar(r.db.QueryRow(r.bind(`SELECT `+carColumns+` FROM cars WHERE id = $1`), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CatalogCar{}, ErrCarNotFound
+	}
+	return car, err
+}
+
+// Create adds a car to the catalog
+func (r *sqlCarRepository) Create(car *models.CatalogCar) error {
+	err := r.db.QueryRow(r.bind(`
+		INSERT INTO cars (name, brand, model, year, engine_size, horsepower, top_speed, weight,
+			category, description, image_url, color, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13)
+		RETURNING id, created_at, updated_at`),
+		car.Name, car.Brand, car.Model, car.Year, car.EngineSize, car.Horsepower, car.TopSpeed, car.Weight,
+		car.Category, car.Description, car.ImageURL, string(car.Color), time.Now().UTC(),
+	).Scan(&car.ID, &car.CreatedAt, &car.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating car: %w", err)
+	}
+	return nil
+}
+
+// Update replaces every field of a car in the catalog
+func (r *sqlCarRepository) Update(car *models.CatalogCar) error {
+	err := r.db.QueryRow(r.bind(`
+		UPDATE cars SET name = $2, brand = $3, model = $4, year = $5, engine_size = $6, horsepower = $7,
+			top_speed = $8, weight = $9, category = $10, description = $11, image_url = $12, color = $13,
+			updated_at = $14
+		WHERE id = $1
+		RETURNING created_at, updated_at`),
+		car.ID, car.Name, car.Brand, car.Model, car.Year, car.EngineSize, car.Horsepower, car.TopSpeed, car.Weight,
+		car.Category, car.Description, car.ImageURL, string(car.Color), time.Now().UTC(),
+	).Scan(&car.CreatedAt, &car.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCarNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error updating car: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a car from the catalog
+func (r *sqlCarRepository) Delete(id int) error {
+	result, err := r.db.Exec(r.bind(`DELETE FROM cars WHERE id = $1`), id)
+	if err != nil {
+		return fmt.Errorf("error deleting car: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error deleting car: %w", err)
+	}
+	if deleted == 0 {
+		return ErrCarNotFound
+	}
+	return nil
+}
+
+// scanCar reads a car from a row of carColumns
+// Input: row - the row
+// Returns: the car, sql.ErrNoRows if there is no row, an error if reading fails
+func scanCar(row rowScanner) (models.CatalogCar, error) {
+	var car models.CatalogCar
+	var color string
+	err := row.Scan(&car.ID, &car.Name, &car.Brand, &car.Model, &car.Year, &car.EngineSize, &car.Horsepower,
+		&car.TopSpeed, &car.Weight, &car.Category, &car.Description, &car.ImageURL, &color, &car.CreatedAt, &car.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CatalogCar{}, err
+	}
+	if err != nil {
+		return models.CatalogCar{}, fmt.Errorf("error reading car: %w", err)
+	}
+	car.Color = models.Color(color)
+	return car, nil
+}
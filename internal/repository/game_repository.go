@@ -2,17 +2,312 @@ package repository
 
 import (
 	"database/sql"
-	"race-cars/internal/config"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+	"race-cars/internal/models"
 )
 
-// CarRepository handles database operations for cars
-type GameRepository struct {
-	db *sql.DB
+// GameRepository stores games: their tables, seats with hashed tokens, the moves played and the events they caused,
+// so the manager can rebuild races after a restart
+type GameRepository interface {
+	games.Store
+
+	// GetEvents returns the event log of a game's race
+	// Input: id - the game ID
+	//	after - only return events with a greater sequence number, 0 for every event
+	// Returns: the events in order, an error if they cannot be read
+	GetEvents(id string, after int) ([]engine.Event, error)
+}
+
+// sqlGameRepository keeps games in a SQL database, with settings, actions and events as JSON
+type sqlGameRepository struct {
+	sqlDB
+}
+
+// NewPostgresGameRepository creates a game repository backed by Postgres
+// Input: db - the database, migrated with the migrations package
+// Returns: the GameRepository
+func NewPostgresGameRepository(db *sql.DB) GameRepository {
+	return &sqlGameRepository{sqlDB{db: db}}
+}
+
+// NewSQLiteGameRepository creates a game repository backed by a SQLite file
+// Input: db - the database, migrated with the migrations package
+// Returns: the GameRepository
+func NewSQLiteGameRepository(db *sql.DB) GameRepository {
+	return &sqlGameRepository{sqlDB{db: db, sqlite: true}}
+}
+
+// Create saves a new game waiting for players
+func (r *sqlGameRepository) Create(table games.Table) error {
+	settings, err := json.Marshal(table.Settings)
+	if err != nil {
+		return fmt.Errorf("error encoding game settings: %w", err)
+	}
+	_, err = r.db.Exec(r.bind(`INSERT INTO games (id, settings, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $4)`),
+		table.ID, string(settings), string(table.Status), table.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating game: %w", err)
+	}
+	return nil
 }
 
-// NewGameRepository creates a new game repository
-func NewGameRepository() *GameRepository {
-	return &GameRepository{
-		db: config.DB,
+// AddSeat saves a player joining a game
+func (r *sqlGameRepository) AddSeat(id string, index int, seat games.Seat, tokenHash string) error {
+	_, err := r.db.Exec(r.bind(`INSERT INTO game_seats (game_id, seat, name, color, token_hash) VALUES ($1, $2, $3, $4, $5)`),
+		id, index, seat.Name, string(seat.Color), tokenHash)
+	if err != nil {
+		return fmt.Errorf("error adding seat: %w", err)
+	}
+	return nil
+}
+
+// Start saves a race starting and the events it started with in one transaction
+func (r *sqlGameRepository) Start(id string, events []engine.Event) error {
+	return r.inTransaction(func(tx *sql.Tx) error {
+		if err := r.updateStatus(tx, id, games.StatusRacing); err != nil {
+			return err
+		}
+		return r.insertEvents(tx, id, events)
+	})
+}
+
+// AddMove saves a move, the events it caused and the game's status after it in one transaction
+func (r *sqlGameRepository) AddMove(id string, move games.Move, events []engine.Event, status games.Status) error {
+	action, err := json.Marshal(move.Action)
+	if err != nil {
+		return fmt.Errorf("error encoding action: %w", err)
+	}
+	return r.inTransaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(r.bind(`INSERT INTO game_moves (game_id, sequence, seat, action, created_at) VALUES ($1, $2, $3, $4, $5)`),
+			id, move.Sequence, move.Seat, string(action), time.Now().UTC()); err != nil {
+			return fmt.Errorf("error adding move: %w", err)
+		}
+		if err := r.insertEvents(tx, id, events); err != nil {
+			return err
+		}
+		return r.updateStatus(tx, id, status)
+	})
+}
+
+// List returns every saved game, oldest first
+func (r *sqlGameRepository) List() ([]games.Table, error) {
+	rows, err := r.db.Query(`SELECT id, settings, status, created_at FROM games ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying games: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make([]games.Table, 0)
+	for rows.Next() {
+		table, err := scanTable(rows)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading games: %w", err)
+	}
+
+	for i := range tables {
+		if tables[i].Seats, _, err = r.getSeats(tables[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return tables, nil
+}
+
+// Get returns everything saved about a game
+func (r *sqlGameRepository) Get(id string) (games.Record, error) {
+	table, err := scanTable(r.db.QueryRow(r.bind(`SELECT id, settings, status, created_at FROM games WHERE id = $1`), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return games.Record{}, games.ErrGameNotFound
+	}
+	if err != nil {
+		return games.Record{}, err
+	}
+
+	record := games.Record{Table: table}
+	if record.Table.Seats, record.TokenHashes, err = r.getSeats(id); err != nil {
+		return games.Record{}, err
+	}
+	if record.Moves, err = r.getMoves(id); err != nil {
+		return games.Record{}, err
+	}
+	if record.Events, err = r.GetEvents(id, 0); err != nil {
+		return games.Record{}, err
+	}
+	return record, nil
+}
+
+// GetEvents returns the event log of a game's race
+func (r *sqlGameRepository) GetEvents(id string, after int) ([]engine.Event, error) {
+	rows, err := r.db.Query(r.bind(`SELECT event FROM game_events WHERE game_id = $1 AND sequence > $2 ORDER BY sequence`), id, after)
+	if err != nil {
+		return nil, fmt.Errorf("error querying events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]engine.Event, 0)
+	for rows.Next() {
+		var data []byte
+		var event engine.Event
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("error reading event: %w", err)
+		}
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("error decoding event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading events: %w", err)
+	}
+	return events, nil
+}
+
+// getSeats returns the seats of a game
+// Input: id - the game ID
+// Returns: the seats and the hashes of their tokens in seat order, an error if the query fails
+func (r *sqlGameRepository) getSeats(id string) ([]games.Seat, []string, error) {
+	rows, err := r.db.Query(r.bind(`SELECT name, color, token_hash FROM game_seats WHERE game_id = $1 ORDER BY seat`), id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error querying seats: %w", err)
+	}
+	defer rows.Close()
+
+	seats := make([]games.Seat, 0)
+	hashes := make([]string, 0)
+	for rows.Next() {
+		var seat games.Seat
+		var color, hash string
+		if err := rows.Scan(&seat.Name, &color, &hash); err != nil {
+			return nil, nil, fmt.Errorf("error reading seat: %w", err)
+		}
+		seat.Color = models.Color(color)
+		seats = append(seats, seat)
+		hashes = append(hashes, hash)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error reading seats: %w", err)
+	}
+	return seats, hashes, nil
+}
+
+// getMoves returns the moves played in a game
+// Input: id - the game ID
+// Returns: the moves in order, an error if the query fails
+func (r *sqlGameRepository) getMoves(id string) ([]games.Move, error) {
+	rows, err := r.db.Query(r.bind(`SELECT sequence, seat, action FROM game_moves WHERE game_id = $1 ORDER BY sequence`), id)
+	if err != nil {
+		return nil, fmt.Errorf("error querying moves: %w", err)
+	}
+	defer rows.Close()
+
+	moves := make([]games.Move, 0)
+	for rows.Next() {
+		var move games.Move
+		var action []byte
+		if err := rows.Scan(&move.Sequence, &move.Seat, &action); err != nil {
+			return nil, fmt.Errorf("error reading move: %w", err)
+		}
+		if err := json.Unmarshal(action, &move.Action); err != nil {
+			return nil, fmt.Errorf("error decoding move %d: %w", move.Sequence, err)
+		}
+		moves = append(moves, move)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading moves: %w", err)
+	}
+	return moves, nil
+}
+
+// inTransaction runs statements in a transaction, committing them only if all succeed
+// Input: run - runs the statements
+// Returns: the error run returns, an error if the transaction cannot begin or commit
+func (r *sqlGameRepository) inTransaction(run func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	if err := run(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// updateStatus sets a game's status
+// Input: tx - the transaction
+//
+//	id - the game ID
+//	status - the new status
+//
+// Returns: ErrGameNotFound if there is no game with the ID, an error if the update fails
+func (r *sqlGameRepository) updateStatus(tx *sql.Tx, id string, status games.Status) error {
+	result, err := tx.Exec(r.bind(`UPDATE games SET status = $2, updated_at = $3 WHERE id = $1`), id, string(status), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("error updating game: %w", err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error updating game: %w", err)
+	}
+	if updated == 0 {
+		return games.ErrGameNotFound
+	}
+	return nil
+}
+
+// insertEvents appends events to a game's event log
+// Input: tx - the transaction
+//
+//	id - the game ID
+//	events - the events
+//
+// Returns: an error if an insert fails
+func (r *sqlGameRepository) insertEvents(tx *sql.Tx, id string, events []engine.Event) error {
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("error encoding event: %w", err)
+		}
+		if _, err := tx.Exec(r.bind(`INSERT INTO game_events (game_id, sequence, round, type, event, created_at) VALUES ($1, $2, $3, $4, $5, $6)`),
+			id, event.Sequence, event.Round, string(event.Type), string(data), time.Now().UTC()); err != nil {
+			return fmt.Errorf("error adding event: %w", err)
+		}
+	}
+	return nil
+}
+
+// scanTable reads a game's header from a row of id, settings, status and created_at; seats are read separately
+// Input: row - the row
+// Returns: the Table, sql.ErrNoRows if there is no row, an error if reading fails
+func scanTable(row rowScanner) (games.Table, error) {
+	var table games.Table
+	var settings []byte
+	var status string
+	err := row.Scan(&table.ID, &settings, &status, &table.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return games.Table{}, err
+	}
+	if err != nil {
+		return games.Table{}, fmt.Errorf("error reading game: %w", err)
+	}
+	if err := json.Unmarshal(settings, &table.Settings); err != nil {
+		return games.Table{}, fmt.Errorf("error decoding settings of game %s: %w", table.ID, err)
 	}
+	table.Status = games.Status(status)
+	table.CreatedAt = table.CreatedAt.UTC()
+	table.Seats = make([]games.Seat, 0)
+	return table, nil
 }
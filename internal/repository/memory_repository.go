@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/games"
+	"race-cars/internal/models"
+)
+
+// memoryCarRepository keeps the car catalog in memory, for tests and running without a database
+type memoryCarRepository struct {
+	mu     sync.RWMutex
+	cars   map[int]models.CatalogCar
+	nextID int
+}
+
+// NewMemoryCarRepository creates an empty car repository that keeps the catalog in memory
+// Input: none
+// Returns: the CarRepository
+func NewMemoryCarRepository() CarRepository {
+	return &memoryCarRepository{
+		cars:   make(map[int]models.CatalogCar),
+		nextID: 1,
+	}
+}
+
+// GetAll returns the cars in the catalog in the order they were added
+func (r *memoryCarRepository) GetAll(color models.Color) ([]models.CatalogCar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cars := make([]models.CatalogCar, 0, len(r.cars))
+	for _, car := range r.cars {
+		if color == "" || car.Color == color {
+			cars = append(cars, car)
+		}
+	}
+	sort.Slice(cars, func(i, j int) bool { return cars[i].ID < cars[j].ID })
+	return cars, nil
+}
+
+// GetByID returns a car from the catalog
+func (r *memoryCarRepository) GetByID(id int) (models.CatalogCar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	car, ok := r.cars[id]
+	if !ok {
+		return models.CatalogCar{}, ErrCarNotFound
+	}
+	return car, nil
+}
+
+// Create adds a car to the catalog
+func (r *memoryCarRepository) Create(car *models.CatalogCar) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	car.ID = r.nextID
+	car.CreatedAt = time.Now().UTC()
+	car.UpdatedAt = car.CreatedAt
+	r.nextID++
+	r.cars[car.ID] = *car
+	return nil
+}
+
+// Update replaces every field of a car in the catalog
+func (r *memoryCarRepository) Update(car *models.CatalogCar) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.cars[car.ID]
+	if !ok {
+		return ErrCarNotFound
+	}
+	car.CreatedAt = old.CreatedAt
+	car.UpdatedAt = time.Now().UTC()
+	r.cars[car.ID] = *car
+	return nil
+}
+
+// Delete removes a car from the catalog
+func (r *memoryCarRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.cars[id]; !ok {
+		return ErrCarNotFound
+	}
+	delete(r.cars, id)
+	return nil
+}
+
+// memoryGameRepository keeps games in memory, for tests and running without a database; games end with the process
+type memoryGameRepository struct {
+	mu      sync.RWMutex
+	records map[string]*games.Record
+	ids     []string
+}
+
+// NewMemoryGameRepository creates an empty game repository that keeps games in memory
+// Input: none
+// Returns: the GameRepository
+func NewMemoryGameRepository() GameRepository {
+	return &memoryGameRepository{
+		records: make(map[string]*games.Record),
+	}
+}
+
+// Create saves a new game waiting for players
+func (r *memoryGameRepository) Create(table games.Table) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.records[table.ID]; ok {
+		return fmt.Errorf("error creating game: game %s exists", table.ID)
+	}
+	table.Seats = append(make([]games.Seat, 0, len(table.Seats)), table.Seats...)
+	table.Settings.Modules = append([]string(nil), table.Settings.Modules...)
+	r.records[table.ID] = &games.Record{Table: table, TokenHashes: make([]string, 0)}
+	r.ids = append(r.ids, table.ID)
+	return nil
+}
+
+// AddSeat saves a player joining a game
+func (r *memoryGameRepository) AddSeat(id string, index int, seat games.Seat, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[id]
+	if !ok {
+		return games.ErrGameNotFound
+	}
+	if index != len(record.Table.Seats) {
+		return fmt.Errorf("error adding seat: seat %d is not the next one", index)
+	}
+	record.Table.Seats = append(record.Table.Seats, seat)
+	record.TokenHashes = append(record.TokenHashes, tokenHash)
+	return nil
+}
+
+// Start saves a race starting and the events it started with
+func (r *memoryGameRepository) Start(id string, events []engine.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[id]
+	if !ok {
+		return games.ErrGameNotFound
+	}
+	record.Table.Status = games.StatusRacing
+	record.Events = append(record.Events, events...)
+	return nil
+}
+
+// AddMove saves a move, the events it caused and the game's status after it
+func (r *memoryGameRepository) AddMove(id string, move games.Move, events []engine.Event, status games.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.records[id]
+	if !ok {
+		return games.ErrGameNotFound
+	}
+	if move.Sequence != len(record.Moves)+1 {
+		return fmt.Errorf("error adding move: move %d is not the next one", move.Sequence)
+	}
+	record.Moves = append(record.Moves, move)
+	record.Events = append(record.Events, events...)
+	record.Table.Status = status
+	return nil
+}
+
+// List returns every saved game, oldest first
+func (r *memoryGameRepository) List() ([]games.Table, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tables := make([]games.Table, 0, len(r.ids))
+	for _, id := range r.ids {
+		tables = append(tables, copyRecord(r.records[id]).Table)
+	}
+	return tables, nil
+}
+
+// Get returns everything saved about a game
+func (r *memoryGameRepository) Get(id string) (games.Record, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, ok := r.records[id]
+	if !ok {
+		return games.Record{}, games.ErrGameNotFound
+	}
+	return copyRecord(record), nil
+}
+
+// GetEvents returns the event log of a game's race
+func (r *memoryGameRepository) GetEvents(id string, after int) ([]engine.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make([]engine.Event, 0)
+	if record, ok := r.records[id]; ok {
+		for _, event := range record.Events {
+			if event.Sequence > after {
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
+
+// copyRecord copies a saved game so callers cannot change the repository's
+// Input: record - the record
+// Returns: the copy
+func copyRecord(record *games.Record) games.Record {
+	copied := *record
+	copied.Table.Seats = append(make([]games.Seat, 0, len(record.Table.Seats)), record.Table.Seats...)
+	copied.Table.Settings.Modules = append([]string(nil), record.Table.Settings.Modules...)
+	copied.TokenHashes = append(make([]string, 0, len(record.TokenHashes)), record.TokenHashes...)
+	copied.Moves = append(make([]games.Move, 0, len(record.Moves)), record.Moves...)
+	copied.Events = append(make([]engine.Event, 0, len(record.Events)), record.Events...)
+	return copied
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"race-cars/internal/games"
+	"race-cars/internal/gamestest"
+	"race-cars/internal/models"
+)
+
+func TestMemoryCarRepository(t *testing.T) {
+	testCarRepository(t, NewMemoryCarRepository())
+}
+
+func TestMemoryGameRepository(t *testing.T) {
+	testGameRepository(t, NewMemoryGameRepository())
+}
+
+func TestMemoryCarRepository_KeepsCopies(t *testing.T) {
+	repo := NewMemoryCarRepository()
+	car := gamestest.CatalogCar("Ferrari F40", models.Red)
+	repo.Create(&car)
+
+	car.Name = "Changed"
+	if got, _ := repo.GetByID(car.ID); got.Name != "Ferrari F40" {
+		t.Errorf("GetByID() name = %q, want the saved car unchanged by its caller", got.Name)
+	}
+}
+
+func TestMemoryGameRepository_RejectsOutOfOrder(t *testing.T) {
+	repo := NewMemoryGameRepository()
+	if err := repo.Create(games.Table{ID: "game", Status: games.StatusWaiting, Seats: []games.Seat{}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		save func() error
+		want error
+	}{
+		{"Duplicate game", func() error { return repo.Create(games.Table{ID: "game"}) }, nil},
+		{"Seat skipped", func() error { return repo.AddSeat("game", 1, games.Seat{Name: "Ada"}, "hash") }, nil},
+		{"Move skipped", func() error { return repo.AddMove("game", games.Move{Sequence: 2}, nil, games.StatusRacing) }, nil},
+		{"Seat in missing game", func() error { return repo.AddSeat("missing", 0, games.Seat{Name: "Ada"}, "hash") }, games.ErrGameNotFound},
+		{"Start of missing game", func() error { return repo.Start("missing", nil) }, games.ErrGameNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.save()
+			if err == nil {
+				t.Fatal("error = nil, want an error")
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"race-cars/internal/config"
+)
+
+// Repositories are the stores the API keeps its data in
+type Repositories struct {
+	Cars  CarRepository
+	Games GameRepository
+}
+
+// New creates the repositories for a database driver
+// Input: driver - config.DriverPostgres, config.DriverSQLite or config.DriverMemory
+//
+//	db - the open database, unused by the memory driver
+//
+// Returns: the Repositories, an error if the driver is unknown or has no database
+func New(driver string, db *sql.DB) (Repositories, error) {
+	switch driver {
+	case config.DriverMemory:
+		return Repositories{Cars: NewMemoryCarRepository(), Games: NewMemoryGameRepository()}, nil
+	case config.DriverPostgres, config.DriverSQLite:
+		if db == nil {
+			return Repositories{}, fmt.Errorf("driver %q needs a database", driver)
+		}
+		if driver == config.DriverSQLite {
+			return Repositories{Cars: NewSQLiteCarRepository(db), Games: NewSQLiteGameRepository(db)}, nil
+		}
+		return Repositories{Cars: NewPostgresCarRepository(db), Games: NewPostgresGameRepository(db)}, nil
+	}
+	return Repositories{}, fmt.Errorf("unknown database driver %q", driver)
+}
+
+// sqlDB is a database with the SQL dialect its queries are written in
+// Queries are written for Postgres, numbering parameters like $1; SQLite gets them as ?1, which it binds the same way
+type sqlDB struct {
+	db     *sql.DB
+	sqlite bool
+}
+
+// bind rewrites a query for the database's dialect
+// Input: query - the query written for Postgres
+// Returns: the query to run
+func (d sqlDB) bind(query string) string {
+	if d.sqlite {
+		return strings.ReplaceAll(query, "$", "?")
+	}
+	return query
+}
+
+// rowScanner is what the scan functions read from, a single row or the current row of many
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
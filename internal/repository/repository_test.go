@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"race-cars/internal/config"
+	"race-cars/internal/games"
+	"race-cars/internal/gamestest"
+	"race-cars/internal/models"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr bool
+	}{
+		{"Memory without database", config.DriverMemory, false},
+		{"Postgres without database", config.DriverPostgres, true},
+		{"SQLite without database", config.DriverSQLite, true},
+		{"Unknown driver", "mysql", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := New(tt.driver, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (repos.Cars == nil || repos.Games == nil) {
+				t.Error("New() left a repository nil")
+			}
+		})
+	}
+}
+
+func TestSQLDB_Bind(t *testing.T) {
+	query := `SELECT id FROM cars WHERE id = $1 AND color = $2`
+	if got := (sqlDB{}).bind(query); got != query {
+		t.Errorf("Postgres bind() = %q, want the query unchanged", got)
+	}
+	if got, want := (sqlDB{sqlite: true}).bind(query), `SELECT id FROM cars WHERE id = ?1 AND color = ?2`; got != want {
+		t.Errorf("SQLite bind() = %q, want %q", got, want)
+	}
+}
+
+// Helper function to check a car repository adds, finds, filters, updates and removes cars
+func testCarRepository(t *testing.T, repo CarRepository) {
+	t.Helper()
+	red, yellow := gamestest.CatalogCar("Ferrari F40", models.Red), gamestest.CatalogCar("Ferrari 348", models.Yellow)
+	for _, car := range []*models.CatalogCar{&red, &yellow} {
+		if err := repo.Create(car); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if car.ID == 0 || car.CreatedAt.IsZero() || !car.UpdatedAt.Equal(car.CreatedAt) {
+			t.Fatalf("created car = %+v, want an ID and matching timestamps", car)
+		}
+	}
+
+	all, err := repo.GetAll("")
+	if err != nil || len(all) != 2 || all[0].ID != red.ID || all[1].ID != yellow.ID {
+		t.Fatalf("GetAll() = %+v, %v, want both cars in the order they were added", all, err)
+	}
+	if filtered, err := repo.GetAll(models.Yellow); err != nil || len(filtered) != 1 || filtered[0].Name != yellow.Name {
+		t.Errorf("GetAll(Yellow) = %+v, %v, want the yellow car", filtered, err)
+	}
+	if filtered, err := repo.GetAll(models.Blue); err != nil || filtered == nil || len(filtered) != 0 {
+		t.Errorf("GetAll(Blue) = %+v, %v, want no cars", filtered, err)
+	}
+
+	got, err := repo.GetByID(red.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Name != red.Name || got.EngineSize != red.EngineSize || got.Color != red.Color || !got.CreatedAt.Equal(red.CreatedAt) {
+		t.Errorf("GetByID() = %+v, want %+v", got, red)
+	}
+
+	updated := gamestest.CatalogCar("Ferrari F40 LM", models.Red)
+	updated.ID = red.ID
+	if err := repo.Update(&updated); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got, _ := repo.GetByID(red.ID); got.Name != updated.Name || !got.CreatedAt.Equal(red.CreatedAt) {
+		t.Errorf("car after Update() = %+v, want the new name and the creation time kept", got)
+	}
+
+	if err := repo.Delete(red.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetByID(red.ID); !errors.Is(err, ErrCarNotFound) {
+		t.Errorf("GetByID() after Delete() error = %v, want ErrCarNotFound", err)
+	}
+
+	missing := gamestest.CatalogCar("Ferrari Enzo", models.Red)
+	missing.ID = 999
+	if err := repo.Update(&missing); !errors.Is(err, ErrCarNotFound) {
+		t.Errorf("Update() of a missing car error = %v, want ErrCarNotFound", err)
+	}
+	if err := repo.Delete(missing.ID); !errors.Is(err, ErrCarNotFound) {
+		t.Errorf("Delete() of a missing car error = %v, want ErrCarNotFound", err)
+	}
+}
+
+// Helper function to check a game repository saves races so a new manager carries them on
+func testGameRepository(t *testing.T, repo GameRepository) {
+	t.Helper()
+	manager, err := games.LoadManager(repo)
+	if err != nil {
+		t.Fatalf("LoadManager() error = %v", err)
+	}
+
+	waiting, err := manager.Create(games.Settings{Track: "USA", Seats: 2})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, _, err := manager.Join(waiting.ID, games.Seat{Name: "Ada"}); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	racing, err := manager.Create(games.Settings{Track: "USA", Laps: 1, Seats: 2, Modules: []string{"garage"}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	tokens := make([]string, 0, 2)
+	for _, name := range []string{"Ada", "Grace"} {
+		_, token, err := manager.Join(racing.ID, games.Seat{Name: name})
+		if err != nil {
+			t.Fatalf("Join() error = %v", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := manager.Start(racing.ID, tokens[0]); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	gamestest.PlayMoves(t, manager, racing.ID, tokens, 10)
+
+	tables, err := repo.List()
+	if err != nil || len(tables) != 2 || tables[0].ID != waiting.ID || tables[1].ID != racing.ID {
+		t.Fatalf("List() = %+v, %v, want both games, oldest first", tables, err)
+	}
+	if _, err := repo.Get("missing"); !errors.Is(err, games.ErrGameNotFound) {
+		t.Errorf("Get() of a missing game error = %v, want ErrGameNotFound", err)
+	}
+
+	record, err := repo.Get(racing.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(record.Moves) != 10 || len(record.TokenHashes) != 2 || record.Table.Status != games.StatusRacing {
+		t.Errorf("Get() = %d moves, %d token hashes and status %s, want 10, 2 and racing",
+			len(record.Moves), len(record.TokenHashes), record.Table.Status)
+	}
+
+	played := gamestest.EventsOf(t, manager, racing.ID)
+	if events, err := repo.GetEvents(racing.ID, 0); err != nil || !reflect.DeepEqual(events, played) {
+		t.Errorf("GetEvents() = %d events, %v, want the %d played", len(events), err, len(played))
+	}
+	if events, err := repo.GetEvents(racing.ID, 3); err != nil || len(events) != len(played)-3 || events[0].Sequence != 4 {
+		t.Errorf("GetEvents() after 3 = %d events, %v, want the rest from 4", len(events), err)
+	}
+
+	restarted, err := games.LoadManager(repo)
+	if err != nil {
+		t.Fatalf("LoadManager() after restart error = %v", err)
+	}
+	if table, err := restarted.GetTable(waiting.ID); err != nil || table.Status != games.StatusWaiting || len(table.Seats) != 1 {
+		t.Errorf("waiting game after restart = %+v, %v, want it waiting with one seat", table, err)
+	}
+	if events := gamestest.EventsOf(t, restarted, racing.ID); !reflect.DeepEqual(events, played) {
+		t.Fatalf("race after restart has %d events, want the %d played", len(events), len(played))
+	}
+	if seat, err := restarted.Authorize(racing.ID, tokens[1]); err != nil || seat != 1 {
+		t.Errorf("Authorize() after restart = %d, %v, want seat 1", seat, err)
+	}
+	gamestest.PlayMoves(t, restarted, racing.ID, tokens, 5)
+}
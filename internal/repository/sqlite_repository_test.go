@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"race-cars/migrations"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Helper function to open a migrated SQLite database in a temporary file
+func createSQLiteDB(t *testing.T) *sql.DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "race_cars.db")
+	db, err := sql.Open("sqlite3", "file:"+path+"?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	if err := migrations.Up(migrations.SQLite, db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	return db
+}
+
+func TestSQLiteCarRepository(t *testing.T) {
+	testCarRepository(t, NewSQLiteCarRepository(createSQLiteDB(t)))
+}
+
+func TestSQLiteGameRepository(t *testing.T) {
+	testGameRepository(t, NewSQLiteGameRepository(createSQLiteDB(t)))
+}
+
+func TestSQLiteMigrations_UpAndDown(t *testing.T) {
+	db := createSQLiteDB(t)
+	if err := migrations.Up(migrations.SQLite, db); err != nil {
+		t.Fatalf("Up() on a migrated database error = %v", err)
+	}
+
+	migrator, err := migrations.New(migrations.SQLite, db)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := migrator.Down(); err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	migrator.Close()
+
+	// Closing the migrator leaves the database open for the server
+	var tables int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('cars', 'games', 'users')`).Scan(&tables); err != nil {
+		t.Fatalf("query after Close() error = %v", err)
+	}
+	if tables != 0 {
+		t.Errorf("%d tables left after Down(), want 0", tables)
+	}
+}
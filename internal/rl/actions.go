@@ -0,0 +1,94 @@
+package rl
+
+import (
+	"race-cars/internal/engine"
+	"race-cars/internal/garage"
+)
+
+const (
+	// HandSlots is the number of hand positions an action can pick cards from: a full hand, a spin-out's two Stress
+	// cards and a sponsor card. Cards further down the hand cannot be picked until the hand shrinks
+	HandSlots = 10
+
+	// cardSets is the number of selections of hand slots, one bit per slot
+	cardSets = 1 << HandSlots
+
+	// maxGear is the highest gear a plan can shift to
+	maxGear = 5
+
+	// DraftSlots covers the largest draft market: one upgrade per car on a full grid plus the extras
+	DraftSlots = engine.MaxSeats + garage.MarketExtra
+
+	// PlanActions starts the plans: the plan in gear g playing the hand slots in bitmask m is PlanActions + (g-1)*1024 + m
+	PlanActions = 0
+
+	// DiscardActions starts the discards: discarding the hand slots in bitmask m is DiscardActions + m
+	DiscardActions = PlanActions + maxGear*cardSets
+
+	// ReactActions starts the reactions: ReactActions + (2*boost + icons)*1024 + m, where boost and icons are 0 or 1,
+	// icons accepts the optional icons and m is the bitmask of the hand slots played directly
+	ReactActions = DiscardActions + cardSets
+
+	// SlipstreamActions starts the slipstream choices: SlipstreamActions declines and SlipstreamActions + 1 takes it
+	SlipstreamActions = ReactActions + 4*cardSets
+
+	// DraftActions starts the draft picks: picking market card i is DraftActions + i
+	DraftActions = SlipstreamActions + 2
+
+	// ActionSpaceSize is the number of discrete actions
+	ActionSpaceSize = DraftActions + DraftSlots
+)
+
+// encodeAction finds the discrete action of an engine action
+// Every index means the same decision whatever else is legal, so an agent can learn what each one does
+// Input: action - a legal action of the learning seat
+// Returns: the index, false if the action picks a card past the last hand or market slot
+func encodeAction(action engine.Action) (int, bool) {
+	switch action.Type {
+	case engine.ActionPlan:
+		cards, ok := cardSet(action.Cards)
+		if !ok || action.Gear < 1 || action.Gear > maxGear {
+			return 0, false
+		}
+		return PlanActions + (action.Gear-1)*cardSets + cards, true
+	case engine.ActionDiscard:
+		cards, ok := cardSet(action.Cards)
+		return DiscardActions + cards, ok
+	case engine.ActionReact:
+		cards, ok := cardSet(action.DirectPlay)
+		choice := 0
+		if action.Boost {
+			choice += 2
+		}
+		if len(action.Icons) > 0 {
+			choice++
+		}
+		return ReactActions + choice*cardSets + cards, ok
+	case engine.ActionSlipstream:
+		if action.Slipstream {
+			return SlipstreamActions + 1, true
+		}
+		return SlipstreamActions, true
+	case engine.ActionDraft:
+		if len(action.Cards) != 1 || action.Cards[0] < 0 || action.Cards[0] >= DraftSlots {
+			return 0, false
+		}
+		return DraftActions + action.Cards[0], true
+	default:
+		return 0, false
+	}
+}
+
+// cardSet turns hand indexes into a bitmask of hand slots
+// Input: cards - the hand indexes
+// Returns: the bitmask, false if an index is past the last hand slot
+func cardSet(cards []int) (int, bool) {
+	set := 0
+	for _, index := range cards {
+		if index < 0 || index >= HandSlots {
+			return 0, false
+		}
+		set |= 1 << index
+	}
+	return set, true
+}
@@ -0,0 +1,42 @@
+package rl
+
+import (
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+func TestEncodeAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		action engine.Action
+		want   int
+		wantOK bool
+	}{
+		{name: "Plan in first gear", action: engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{0}}, want: 1, wantOK: true},
+		{name: "Plan in second gear", action: engine.Action{Type: engine.ActionPlan, Gear: 2, Cards: []int{3, 0}}, want: cardSets + 9, wantOK: true},
+		{name: "Plan past the hand slots", action: engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{HandSlots}}},
+		{name: "Discard nothing", action: engine.Action{Type: engine.ActionDiscard}, want: DiscardActions, wantOK: true},
+		{name: "Discard the last slot", action: engine.Action{Type: engine.ActionDiscard, Cards: []int{HandSlots - 1}}, want: DiscardActions + cardSets/2, wantOK: true},
+		{name: "React without choices", action: engine.Action{Type: engine.ActionReact}, want: ReactActions, wantOK: true},
+		{name: "React with icons", action: engine.Action{Type: engine.ActionReact, Icons: []models.Icon{models.IconCooling}}, want: ReactActions + cardSets, wantOK: true},
+		{name: "Boost and play directly", action: engine.Action{Type: engine.ActionReact, Boost: true, DirectPlay: []int{1}}, want: ReactActions + 2*cardSets + 2, wantOK: true},
+		{name: "Decline the slipstream", action: engine.Action{Type: engine.ActionSlipstream}, want: SlipstreamActions, wantOK: true},
+		{name: "Take the slipstream", action: engine.Action{Type: engine.ActionSlipstream, Slipstream: true}, want: SlipstreamActions + 1, wantOK: true},
+		{name: "Draft pick", action: engine.Action{Type: engine.ActionDraft, Cards: []int{2}}, want: DraftActions + 2, wantOK: true},
+		{name: "Draft pick past the market slots", action: engine.Action{Type: engine.ActionDraft, Cards: []int{DraftSlots}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := encodeAction(tt.action)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("encodeAction() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if ok && (got < 0 || got >= ActionSpaceSize) {
+				t.Errorf("encodeAction() = %d, outside the action space of %d", got, ActionSpaceSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package rl
+
+import (
+	"errors"
+	"fmt"
+
+	"race-cars/internal/ai"
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+// FinishReward is the reward for winning the race; lower places get a share of it
+const FinishReward = 1.0
+
+// Config describes the races an environment runs
+type Config struct {
+	// Race is the race to run; its seats and seed are set by the environment
+	Race engine.Config `json:"race"`
+
+	// Opponents names the bot on every other seat, like "hard" or "mcts:100"; the learning seat comes first
+	Opponents []string `json:"opponents"`
+
+	// Upgrades are Garage upgrades added to every starting deck
+	Upgrades []string `json:"upgrades,omitempty"`
+}
+
+// Info describes the state of the race alongside a step
+type Info struct {
+	Round int          `json:"round"`
+	Phase engine.Phase `json:"phase"`
+
+	// Position is the learning seat's race position, leader first
+	Position int `json:"position"`
+
+	// Actions are the legal actions by their discrete index
+	Actions map[int]engine.Action `json:"actions"`
+
+	// Results are the final standings once the race is done
+	Results []engine.Result `json:"results,omitempty"`
+}
+
+// StepResult is what the environment returns after every step
+type StepResult struct {
+	Observation Observation `json:"observation"`
+
+	// Mask marks the discrete actions that are legal for the next step
+	// Of identical cards only the first in the hand can be picked, so equivalent choices are marked once
+	Mask   []bool  `json:"mask"`
+	Reward float64 `json:"reward"`
+	Done   bool    `json:"done"`
+	Info   Info    `json:"info"`
+}
+
+// Env runs races for one learning seat, stepping through every decision that seat makes
+// Other seats are played by bots between steps, so a step always ends at the learner's next decision or the end of the race
+type Env interface {
+	// Reset starts a new race
+	// Input: seed - the seed for the race and the opponents' bots
+	// Returns: the first StepResult, with a zero reward, an error if the race cannot be set up
+	Reset(seed int64) (StepResult, error)
+
+	// Step plays a discrete action for the learning seat and lets the opponents play until the learner decides again
+	// The reward is the progress made since the last step in laps, plus a share of FinishReward by position when the race ends
+	// Input: action - the index of a legal action in the layout starting at PlanActions, see the Mask
+	// Returns: the StepResult, an error if the action is not legal or there is no race in progress
+	Step(action int) (StepResult, error)
+
+	// GetObservationSize returns the length of every observation
+	// Returns: ObservationSize
+	GetObservationSize() int
+
+	// GetActionSpaceSize returns the number of discrete actions
+	// Returns: ActionSpaceSize
+	GetActionSpaceSize() int
+}
+
+type env struct {
+	config   Config
+	game     engine.Game
+	bots     []ai.Bot
+	legal    map[int]engine.Action
+	progress float64
+}
+
+// NewEnv creates an environment with the learning seat first and a bot on every other seat
+// Input: config - the races to run
+// Returns: a new Env, an error if the opponents are invalid
+func NewEnv(config Config) (Env, error) {
+	if len(config.Opponents)+1 > engine.MaxSeats {
+		return nil, fmt.Errorf("environment supports at most %d opponents", engine.MaxSeats-1)
+	}
+	for _, name := range config.Opponents {
+		if _, err := ai.NewBot(name, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	return &env{config: config}, nil
+}
+
+// Reset starts a new race
+// Input: seed - the seed for the race and the opponents' bots
+// Returns: the first StepResult, with a zero reward, an error if the race cannot be set up
+func (e *env) Reset(seed int64) (StepResult, error) {
+	colors := []models.Color{models.Red, models.Blue, models.Green, models.Yellow, models.Orange, models.Black}
+
+	race := e.config.Race
+	race.Seed = seed
+	race.Seats = []engine.Seat{{Name: "Agent", Color: colors[0], Upgrades: e.config.Upgrades}}
+	e.bots = make([]ai.Bot, 0, len(e.config.Opponents))
+	for i, name := range e.config.Opponents {
+		bot, err := ai.NewBot(name, seed*engine.MaxSeats+int64(i+1))
+		if err != nil {
+			return StepResult{}, err
+		}
+		e.bots = append(e.bots, bot)
+		race.Seats = append(race.Seats, engine.Seat{
+			Name:     fmt.Sprintf("Seat %d", i+2),
+			Color:    colors[i+1],
+			Upgrades: e.config.Upgrades,
+		})
+	}
+
+	game, err := engine.NewGame(race)
+	if err != nil {
+		return StepResult{}, err
+	}
+	e.game = game
+	e.progress = e.covered()
+
+	if err := e.advance(); err != nil {
+		return StepResult{}, err
+	}
+	return e.result(0), nil
+}
+
+// Step plays a discrete action for the learning seat and lets the opponents play until the learner decides again
+// Input: action - the index of a legal action in the layout starting at PlanActions, see the Mask
+// Returns: the StepResult, an error if the action is not legal or there is no race in progress
+func (e *env) Step(action int) (StepResult, error) {
+	if e.game == nil {
+		return StepResult{}, errors.New("call Reset before Step")
+	}
+	if e.game.IsFinished() {
+		return StepResult{}, errors.New("race is finished, call Reset")
+	}
+	legal, ok := e.legal[action]
+	if !ok {
+		return StepResult{}, fmt.Errorf("action %d is not legal in the %s phase", action, e.game.GetPhase())
+	}
+
+	if err := e.game.Submit(0, legal); err != nil {
+		return StepResult{}, err
+	}
+	if err := e.advance(); err != nil {
+		return StepResult{}, err
+	}
+
+	covered := e.covered()
+	reward := covered - e.progress
+	e.progress = covered
+	if e.game.IsFinished() {
+		reward += e.finishReward()
+	}
+	return e.result(reward), nil
+}
+
+// GetObservationSize returns the length of every observation
+// Input: none
+// Returns: ObservationSize
+func (e *env) GetObservationSize() int {
+	return ObservationSize
+}
+
+// GetActionSpaceSize returns the number of discrete actions
+// Input: none
+// Returns: ActionSpaceSize
+func (e *env) GetActionSpaceSize() int {
+	return ActionSpaceSize
+}
+
+// advance lets the bots play until the learning seat has a decision to make or the race is over,
+// then indexes the learner's legal actions; the first of several actions with the same index is kept
+// Input: none
+// Returns: an error if a bot fails, the race stops making progress or no legal action fits the action space
+func (e *env) advance() error {
+	for !e.game.IsFinished() && !e.game.IsWaitingFor(0) {
+		waiting := false
+		for i, bot := range e.bots {
+			seat := i + 1
+			if !e.game.IsWaitingFor(seat) {
+				continue
+			}
+			waiting = true
+
+			action, err := bot.Choose(e.game, seat)
+			if err != nil {
+				return err
+			}
+			if err := e.game.Submit(seat, action); err != nil {
+				return fmt.Errorf("%s chose an illegal action for seat %d: %w", bot.GetName(), seat, err)
+			}
+		}
+		if !waiting {
+			return errors.New("game is not waiting for any seat")
+		}
+	}
+
+	e.legal = make(map[int]engine.Action)
+	for _, action := range e.game.LegalActions(0) {
+		index, ok := encodeAction(action)
+		if _, seen := e.legal[index]; ok && !seen {
+			e.legal[index] = action
+		}
+	}
+	if len(e.legal) == 0 && !e.game.IsFinished() {
+		return fmt.Errorf("no legal action of the %s phase fits the action space", e.game.GetPhase())
+	}
+	return nil
+}
+
+// result builds the StepResult for the current state
+// Input: reward - the reward of the step
+// Returns: the StepResult
+func (e *env) result(reward float64) StepResult {
+	mask := make([]bool, ActionSpaceSize)
+	for index := range e.legal {
+		mask[index] = true
+	}
+
+	return StepResult{
+		Observation: encode(e.game, 0),
+		Mask:        mask,
+		Reward:      reward,
+		Done:        e.game.IsFinished(),
+		Info: Info{
+			Round:    e.game.GetRound(),
+			Phase:    e.game.GetPhase(),
+			Position: e.position(),
+			Actions:  e.legal,
+			Results:  e.game.GetResults(),
+		},
+	}
+}
+
+// covered returns how far the learning seat's car has gone, in laps
+// Input: none
+// Returns: the laps covered, capped at the race distance
+func (e *env) covered() float64 {
+	board := e.game.GetBoard()
+	car := e.game.GetPlayers()[0].GetCar()
+	space, _ := board.FindCar(car)
+	covered := float64(car.GetLap()) + float64(space)/float64(len(board.GetSpaces()))
+	return min(covered, float64(board.GetNumberOfLaps()))
+}
+
+// position returns the learning seat's race position
+// Input: none
+// Returns: the final position once the race is over, otherwise the position on the board
+func (e *env) position() int {
+	for _, result := range e.game.GetResults() {
+		if result.Seat == 0 {
+			return result.Position
+		}
+	}
+	car := e.game.GetPlayers()[0].GetCar()
+	for i, ranked := range e.game.GetBoard().GetRanking() {
+		if ranked == car {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// finishReward shares out FinishReward by the learning seat's final position
+// Input: none
+// Returns: FinishReward for the winner down to 0 for the last car
+func (e *env) finishReward() float64 {
+	cars := len(e.game.GetRacers())
+	if cars == 1 {
+		return FinishReward
+	}
+	return FinishReward * float64(cars-e.position()) / float64(cars-1)
+}
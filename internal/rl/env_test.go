@@ -0,0 +1,214 @@
+package rl
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+const (
+	// progressSlot is the index of the race progress in an observation, after the hand, phase, gear, engine and speed
+	progressSlot = 9 + 6 + 5 + 2
+
+	// handSlotsStart is the index of the first hand slot in an observation, the last part of it
+	handSlotsStart = ObservationSize - HandSlots*cardKinds
+)
+
+// Helper function to create a one-lap environment against the given opponents
+func createTestEnv(t *testing.T, opponents ...string) Env {
+	track, err := tracks.GetTrack("USA")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+	env, err := NewEnv(Config{Race: engine.Config{Track: track, Laps: 1}, Opponents: opponents})
+	if err != nil {
+		t.Fatalf("NewEnv() error = %v", err)
+	}
+	return env
+}
+
+// Helper function to find the lowest legal action of a step
+func firstLegal(step StepResult) int {
+	for index, legal := range step.Mask {
+		if legal {
+			return index
+		}
+	}
+	return -1
+}
+
+// Helper function to play a race to the end, always taking the first legal action
+func playFirstAction(t *testing.T, env Env, seed int64) ([]StepResult, float64) {
+	step, err := env.Reset(seed)
+	if err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	steps := []StepResult{step}
+	total := 0.0
+	for !step.Done {
+		if step, err = env.Step(firstLegal(step)); err != nil {
+			t.Fatalf("Step() error = %v", err)
+		}
+		steps = append(steps, step)
+		total += step.Reward
+		if len(steps) > 10000 {
+			t.Fatal("race did not finish")
+		}
+	}
+	return steps, total
+}
+
+func TestNewEnv(t *testing.T) {
+	tests := []struct {
+		name      string
+		opponents []string
+		wantErr   bool
+	}{
+		{name: "Heuristic opponents", opponents: []string{"hard", "easy"}},
+		{name: "Solo race", opponents: nil},
+		{name: "Full grid", opponents: []string{"easy", "easy", "easy", "easy", "easy"}},
+		{name: "Too many opponents", opponents: []string{"easy", "easy", "easy", "easy", "easy", "easy"}, wantErr: true},
+		{name: "Unknown opponent", opponents: []string{"random"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEnv(Config{Opponents: tt.opponents})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnv_Reset(t *testing.T) {
+	env := createTestEnv(t, "hard", "normal")
+	step, err := env.Reset(1)
+	if err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	if len(step.Observation) != env.GetObservationSize() {
+		t.Errorf("observation has %d values, want %d", len(step.Observation), env.GetObservationSize())
+	}
+	if len(step.Mask) != env.GetActionSpaceSize() || env.GetActionSpaceSize() != ActionSpaceSize {
+		t.Errorf("mask has %d slots, want %d", len(step.Mask), ActionSpaceSize)
+	}
+	if step.Done || step.Reward != 0 || step.Info.Round != 1 || step.Info.Phase != engine.PhasePlanning {
+		t.Errorf("Reset() = %+v, want the first planning decision with no reward", step.Info)
+	}
+	if len(step.Info.Actions) == 0 {
+		t.Fatal("Reset() offers no legal actions")
+	}
+	for index, legal := range step.Mask {
+		action, listed := step.Info.Actions[index]
+		if legal != listed {
+			t.Fatalf("mask slot %d = %v, listed %v", index, legal, listed)
+		}
+		if listed && (index >= DiscardActions || action.Type != engine.ActionPlan) {
+			t.Errorf("planning offers %+v at %d, want plans below %d", action, index, DiscardActions)
+		}
+	}
+}
+
+func TestEnv_Step(t *testing.T) {
+	env := createTestEnv(t, "hard", "normal")
+	steps, total := playFirstAction(t, env, 3)
+
+	last := steps[len(steps)-1]
+	if len(last.Info.Results) != 3 || last.Info.Phase != engine.PhaseFinished {
+		t.Fatalf("last step = %+v, want the results of a finished race", last.Info)
+	}
+	for i, step := range steps[:len(steps)-1] {
+		if step.Done || len(step.Info.Actions) == 0 {
+			t.Fatalf("step %d = %+v, want a decision for the learning seat", i, step.Info)
+		}
+		if len(step.Observation) != ObservationSize {
+			t.Fatalf("step %d observation has %d values", i, len(step.Observation))
+		}
+	}
+
+	// Progress rewards add up to the race distance, and the place bonus is shared out by position
+	covered := last.Observation[progressSlot] - steps[0].Observation[progressSlot]
+	want := covered + FinishReward*float64(3-last.Info.Position)/2
+	if diff := total - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("total reward = %v, want %v for position %d", total, want, last.Info.Position)
+	}
+
+	if _, err := env.Step(firstLegal(steps[0])); err == nil {
+		t.Error("Step() after the race finished should fail")
+	}
+}
+
+func TestEnv_Step_InvalidAction(t *testing.T) {
+	env := createTestEnv(t, "easy")
+	if _, err := env.Step(0); err == nil {
+		t.Error("Step() before Reset() should fail")
+	}
+
+	step, err := env.Reset(1)
+	if err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	// A draft pick and a plan with no cards are never legal while planning
+	for _, action := range []int{-1, PlanActions, DraftActions, env.GetActionSpaceSize()} {
+		if _, err := env.Step(action); err == nil {
+			t.Errorf("Step(%d) should fail with %d legal actions", action, len(step.Info.Actions))
+		}
+	}
+	if _, err := env.Step(firstLegal(step)); err != nil {
+		t.Errorf("Step(%d) after invalid actions error = %v", firstLegal(step), err)
+	}
+}
+
+func TestEnv_Deterministic(t *testing.T) {
+	first, firstTotal := playFirstAction(t, createTestEnv(t, "hard", "easy"), 7)
+	second, secondTotal := playFirstAction(t, createTestEnv(t, "hard", "easy"), 7)
+
+	if !reflect.DeepEqual(first, second) || firstTotal != secondTotal {
+		t.Error("the same seed and actions should play the same race")
+	}
+}
+
+func TestEnv_OversizedHand(t *testing.T) {
+	track, err := tracks.GetTrack("USA")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+	created, err := NewEnv(Config{Race: engine.Config{Track: track, Laps: 1}})
+	if err != nil {
+		t.Fatalf("NewEnv() error = %v", err)
+	}
+	if _, err := created.Reset(1); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	e := created.(*env)
+	hand := e.game.GetPlayers()[0].GetHand()
+	for hand.Size() > 0 {
+		hand.RemoveCard(0)
+	}
+	for i := 0; i < 15; i++ {
+		hand.AddCards([]models.Card{models.NewCard(fmt.Sprintf("Card %d", i), 1, nil, true, true, false)})
+	}
+	if err := e.advance(); err != nil {
+		t.Fatalf("advance() error = %v", err)
+	}
+
+	// Ten different cards in the hand slots in gears 1 to 3 make 10 + 45 + 120 plans; the last five cannot be picked
+	step := e.result(0)
+	if len(step.Info.Actions) != 175 {
+		t.Errorf("got %d actions, want 175", len(step.Info.Actions))
+	}
+	for index, action := range step.Info.Actions {
+		for _, card := range action.Cards {
+			if card >= HandSlots {
+				t.Fatalf("action %d = %+v picks a card past the hand slots", index, action)
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package rl
+
+import (
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+const (
+	// maxCardSpeed is the highest speed with its own slot in the hand encoding, faster cards share it
+	maxCardSpeed = 5
+
+	// cardKinds is the number of kinds a card is encoded as: a speed up to maxCardSpeed, Upgrade, Stress or Heat
+	cardKinds = maxCardSpeed + 1 + 3
+
+	// cornersAhead is the number of upcoming corners in an observation
+	cornersAhead = 3
+
+	// otherCars is the number of other cars in an observation, the grid holds at most six
+	otherCars = engine.MaxSeats - 1
+
+	// maxSpeed scales the car's speed into the observation
+	maxSpeed = 20.0
+
+	// maxSpeedLimit scales corner speed limits into the observation
+	maxSpeedLimit = 10.0
+)
+
+// phases lists the phases in the order of their one-hot slots, ObservationSize counts six of them
+var phases = []engine.Phase{
+	engine.PhaseDraft,
+	engine.PhasePlanning,
+	engine.PhaseReact,
+	engine.PhaseSlipstream,
+	engine.PhaseDiscard,
+	engine.PhaseFinished,
+}
+
+// ObservationSize is the length of every observation vector:
+// hand card counts by speed plus Upgrade, Stress and Heat slots, the phase and gear one-hot,
+// engine and speed, race progress, rank and round, the upcoming corners, the gap to every other car
+// and the kind of card in every hand slot the actions pick from, one-hot
+const ObservationSize = cardKinds + 6 + 5 + 2 + 3 + 2*cornersAhead + otherCars + HandSlots*cardKinds
+
+// Observation is what the learning seat can see, encoded as a fixed-size vector of values around 0 to 1
+// Opponents' hands and the order of any deck are never part of it
+type Observation []float64
+
+// encode builds the observation of a seat
+// Input: game - the game being played
+//
+//	seat - the learning seat
+//
+// Returns: the Observation, always ObservationSize long
+func encode(game engine.Game, seat int) Observation {
+	obs := make(Observation, 0, ObservationSize)
+	player := game.GetPlayers()[seat]
+	car := player.GetCar()
+	board := game.GetBoard()
+	length := len(board.GetSpaces())
+
+	// Hand: how many cards of every kind, as a share of a full hand
+	hand := make([]float64, cardKinds)
+	for _, card := range player.GetHand().GetCards() {
+		hand[cardKind(card)]++
+	}
+	for _, count := range hand {
+		obs = append(obs, count/models.HandSize)
+	}
+
+	for _, phase := range phases {
+		obs = append(obs, oneHot(game.GetPhase() == phase))
+	}
+	for gear := 1; gear <= 5; gear++ {
+		obs = append(obs, oneHot(car.GetGear() == gear))
+	}
+
+	obs = append(obs,
+		float64(car.GetEngine())/models.StartingEngine,
+		float64(car.GetSpeed())/maxSpeed,
+	)
+
+	// Progress through the race, rank among the cars and how far the round limit is
+	space, _ := board.FindCar(car)
+	laps := board.GetNumberOfLaps()
+	covered := float64(car.GetLap()) + float64(space)/float64(length)
+	rank := 0
+	ranking := board.GetRanking()
+	for i, ranked := range ranking {
+		if ranked == car {
+			rank = i
+		}
+	}
+	obs = append(obs,
+		min(covered/float64(laps), 1),
+		float64(rank)/float64(max(len(ranking)-1, 1)),
+		float64(game.GetRound())/float64(game.GetConfig().MaxRounds),
+	)
+
+	// Upcoming corners: the distance to each and its speed limit with road conditions
+	found := 0
+	for distance := 1; distance <= length && found < cornersAhead; distance++ {
+		ahead := (space + distance) % length
+		if limit := board.GetCornerLimit(ahead); limit > 0 {
+			obs = append(obs, float64(distance)/float64(length), float64(limit)/maxSpeedLimit)
+			found++
+		}
+	}
+	for ; found < cornersAhead; found++ {
+		obs = append(obs, 1, 1)
+	}
+
+	// Other cars: how far ahead (positive) or behind (negative) each one is, in laps
+	others := 0
+	for _, racer := range game.GetRacers() {
+		other := racer.GetCar()
+		if other == car || others == otherCars {
+			continue
+		}
+		otherSpace, _ := board.FindCar(other)
+		otherCovered := float64(other.GetLap()) + float64(otherSpace)/float64(length)
+		obs = append(obs, otherCovered-covered)
+		others++
+	}
+	for ; others < otherCars; others++ {
+		obs = append(obs, 0)
+	}
+
+	// Hand slots: the kind of card at every position an action can pick, all zero for an empty slot
+	for slot := 0; slot < HandSlots; slot++ {
+		kinds := make([]float64, cardKinds)
+		if card := player.GetHand().GetCard(slot); card != nil {
+			kinds[cardKind(card)] = 1
+		}
+		obs = append(obs, kinds...)
+	}
+
+	return obs
+}
+
+// cardKind sorts a card into its slot of the hand encoding
+// Input: card - the card
+// Returns: the speed up to maxCardSpeed for basic cards, then Upgrade, Stress and Heat
+func cardKind(card models.Card) int {
+	switch {
+	case card.GetName() == models.Heat:
+		return maxCardSpeed + 3
+	case card.GetName() == models.Stress:
+		return maxCardSpeed + 2
+	case !card.IsBasic():
+		return maxCardSpeed + 1
+	default:
+		return min(max(card.GetSpeed(), 0), maxCardSpeed)
+	}
+}
+
+// oneHot converts a flag into a one-hot slot value
+// Input: set - whether the slot is set
+// Returns: 1 or 0
+func oneHot(set bool) float64 {
+	if set {
+		return 1
+	}
+	return 0
+}
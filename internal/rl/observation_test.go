@@ -0,0 +1,83 @@
+package rl
+
+import (
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+func TestEncode(t *testing.T) {
+	track, err := tracks.GetTrack("USA")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+	game, err := engine.NewGame(engine.Config{
+		Track: track,
+		Laps:  1,
+		Seed:  1,
+		Seats: []engine.Seat{{Name: "Agent", Color: models.Red}, {Name: "Beside", Color: models.Blue}, {Name: "Behind", Color: models.Green}},
+	})
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+
+	obs := encode(game, 0)
+	if len(obs) != ObservationSize {
+		t.Fatalf("encode() has %d values, want %d", len(obs), ObservationSize)
+	}
+
+	hand := 0.0
+	for _, value := range obs[:9] {
+		hand += value
+	}
+	if hand < 0.999 || hand > 1.001 {
+		t.Errorf("hand slots add up to %v, want a full hand of 1", hand)
+	}
+	if obs[9+1] != 1 {
+		t.Errorf("planning phase slot = %v, want 1", obs[9+1])
+	}
+	if obs[9+6] != 1 {
+		t.Errorf("first gear slot = %v, want 1", obs[9+6])
+	}
+	if obs[20] != 1 {
+		t.Errorf("engine slot = %v, want a full engine of 1", obs[20])
+	}
+	if obs[progressSlot] <= 0 || obs[progressSlot] >= 1 {
+		t.Errorf("progress slot = %v, want a grid position inside the first lap", obs[progressSlot])
+	}
+
+	for i := 0; i < cornersAhead; i++ {
+		distance, limit := obs[progressSlot+3+2*i], obs[progressSlot+4+2*i]
+		if distance <= 0 || distance > 1 || limit <= 0 || limit > 1 {
+			t.Errorf("corner %d = (%v, %v), want a distance and limit in (0, 1]", i, distance, limit)
+		}
+	}
+
+	gaps := obs[handSlotsStart-otherCars : handSlotsStart]
+	if gaps[0] != 0 || gaps[1] >= 0 {
+		t.Errorf("gaps = %v, want the car beside level and the car on the next row behind", gaps[:2])
+	}
+	for i, gap := range gaps[2:] {
+		if gap != 0 {
+			t.Errorf("empty car slot %d = %v, want 0", i+2, gap)
+		}
+	}
+
+	// Every card in the hand has one kind in its slot, and the slots past the hand are empty
+	cards := game.GetPlayers()[0].GetHand()
+	for slot := 0; slot < HandSlots; slot++ {
+		kinds := obs[handSlotsStart+slot*cardKinds : handSlotsStart+(slot+1)*cardKinds]
+		set := 0.0
+		for _, value := range kinds {
+			set += value
+		}
+		if card := cards.GetCard(slot); card != nil && (set != 1 || kinds[cardKind(card)] != 1) {
+			t.Errorf("hand slot %d = %v, want %s as kind %d", slot, kinds, card.GetName(), cardKind(card))
+		}
+		if cards.GetCard(slot) == nil && set != 0 {
+			t.Errorf("empty hand slot %d = %v, want all 0", slot, kinds)
+		}
+	}
+}
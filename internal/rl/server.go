@@ -0,0 +1,83 @@
+package rl
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Request is one line of the piped protocol
+type Request struct {
+	// Command is "reset", "step" or "spec"
+	Command string `json:"command"`
+	Seed    int64  `json:"seed,omitempty"`
+	Action  int    `json:"action,omitempty"`
+}
+
+// Response answers one Request
+type Response struct {
+	*StepResult
+
+	// ObservationSize and ActionSpaceSize answer a "spec" request
+	ObservationSize int `json:"observation_size,omitempty"`
+	ActionSpaceSize int `json:"action_space_size,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// Serve drives an environment over a line-based JSON protocol, so agents in other languages can train through pipes
+// Every line read is a Request and every line written is a Response; errors are reported in the Response and
+// do not stop the loop
+// Input: env - the environment to drive
+//
+//	r - where requests are read from, like standard input
+//	w - where responses are written to, like standard output
+//
+// Returns: an error if reading or writing fails, nil once r is exhausted
+func Serve(env Env, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := encoder.Encode(handle(env, scanner.Bytes())); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// handle runs one request against the environment
+// Input: env - the environment to drive
+//
+//	line - the JSON request
+//
+// Returns: the Response, with Error set if the request failed
+func handle(env Env, line []byte) Response {
+	var request Request
+	if err := json.Unmarshal(line, &request); err != nil {
+		return Response{Error: fmt.Sprintf("invalid request: %v", err)}
+	}
+
+	var result StepResult
+	var err error
+	switch request.Command {
+	case "spec":
+		return Response{ObservationSize: env.GetObservationSize(), ActionSpaceSize: env.GetActionSpaceSize()}
+	case "reset":
+		result, err = env.Reset(request.Seed)
+	case "step":
+		result, err = env.Step(request.Action)
+	default:
+		return Response{Error: fmt.Sprintf("unknown command %q", request.Command)}
+	}
+
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+	return Response{StepResult: &result}
+}
@@ -0,0 +1,54 @@
+package rl
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestServe(t *testing.T) {
+	env := createTestEnv(t, "easy")
+
+	// Action 1 plans first gear playing the first card in the hand
+	input := strings.Join([]string{
+		`{"command":"spec"}`,
+		`{"command":"step","action":0}`,
+		`{"command":"reset","seed":2}`,
+		``,
+		`{"command":"step","action":1}`,
+		`{"command":"step","action":-1}`,
+		`{"command":"jump"}`,
+		`not json`,
+	}, "\n")
+
+	var output bytes.Buffer
+	if err := Serve(env, strings.NewReader(input), &output); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Serve() wrote %d lines, want 7:\n%s", len(lines), output.String())
+	}
+	responses := make([]Response, len(lines))
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &responses[i]); err != nil {
+			t.Fatalf("line %d is not JSON: %v", i, err)
+		}
+	}
+
+	if responses[0].ObservationSize != ObservationSize || responses[0].ActionSpaceSize != ActionSpaceSize {
+		t.Errorf("spec = %+v", responses[0])
+	}
+	for _, i := range []int{1, 4, 5, 6} {
+		if responses[i].Error == "" || responses[i].StepResult != nil {
+			t.Errorf("response %d = %s, want an error", i, lines[i])
+		}
+	}
+	for _, i := range []int{2, 3} {
+		if responses[i].Error != "" || responses[i].StepResult == nil || len(responses[i].Observation) != ObservationSize {
+			t.Errorf("response %d = %s, want a step result", i, lines[i])
+		}
+	}
+}
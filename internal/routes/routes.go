@@ -1,20 +1,51 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
+	"race-cars/internal/config"
+	"race-cars/internal/games"
 	"race-cars/internal/handlers"
+	"race-cars/internal/repository"
 
 	"github.com/gorilla/mux"
 )
 
 // SetupRoutes configures all the routes for the application
-func SetupRoutes(router *mux.Router) {
+// Games saved in the repositories are loaded first, so races carry on after a restart
+func SetupRoutes(router *mux.Router, repos repository.Repositories) error {
 	// Create handlers
-	carHandler := handlers.NewCarHandler()
+	carHandler := handlers.NewCarHandler(repos.Cars)
+	gameManager, err := games.LoadManager(repos.Games)
+	if err != nil {
+		return fmt.Errorf("error loading games: %w", err)
+	}
+	spectatorDelay := config.GetEnvDuration("SPECTATOR_DELAY", 0)
+	gameHandler := handlers.NewGameHandler(gameManager, repos.Cars, spectatorDelay)
+	boardHandler := handlers.NewBoardHandler(gameManager, spectatorDelay)
 
 	// API routes
 	api := router.PathPrefix("/api").Subrouter()
 
+	// Car catalog endpoints
+	carHandler.RegisterRoutes(api)
+
+	// Game lifecycle endpoints
+	gameHandler.RegisterRoutes(api)
+	boardHandler.RegisterRoutes(api)
+
+	// Real-time game channel for players and spectators
+	handlers.NewSocketHandler(gameManager, spectatorDelay).RegisterRoutes(api)
+
+	// Read-only spectator feed, held back by SPECTATOR_DELAY so players cannot watch it to cheat;
+	// while it is set the live game state, socket and board need a seat token
+	handlers.NewEventHandler(gameManager, spectatorDelay).RegisterRoutes(api)
+
+	// Move hints for teaching games, off unless HINTS_ENABLED is set; like every endpoint acting for a seat they need its token
+	if config.GetEnvBool("HINTS_ENABLED", false) {
+		handlers.NewHintHandler(gameManager).RegisterRoutes(api)
+	}
+
 	// Health check endpoint
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -32,8 +63,11 @@ func SetupRoutes(router *mux.Router) {
 			"description": "A RESTful API for managing race cars",
 			"endpoints": {
 				"cars": "/api/cars",
+				"games": "/api/games",
 				"health": "/health"
 			}
 		}`))
 	}).Methods("GET")
-} 
\ No newline at end of file
+
+	return nil
+}
\ No newline at end of file
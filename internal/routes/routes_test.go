@@ -0,0 +1,88 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"race-cars/internal/config"
+	"race-cars/internal/repository"
+
+	"github.com/gorilla/mux"
+)
+
+// Helper function to create the application's router over repositories kept in memory
+func createRouter(t *testing.T) *mux.Router {
+	repos, err := repository.New(config.DriverMemory, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	router := mux.NewRouter()
+	if err := SetupRoutes(router, repos); err != nil {
+		t.Fatalf("SetupRoutes() error = %v", err)
+	}
+	return router
+}
+
+// Helper function to send a request to a router, with a seat token when one is given
+func serve(router *mux.Router, method string, path string, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestSetupRoutes_SeatEndpointsNeedToken(t *testing.T) {
+	t.Setenv("HINTS_ENABLED", "true")
+	router := createRouter(t)
+
+	recorder := serve(router, "POST", "/api/games", "", `{"track": "USA", "laps": 1, "seats": 2}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("POST /api/games = %d, want %d", recorder.Code, http.StatusCreated)
+	}
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&created); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	id := created.Data.ID
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   string
+		status int
+	}{
+		{"Start without token", "POST", "/api/games/" + id + "/start", "", "", http.StatusUnauthorized},
+		{"Action without token", "POST", "/api/games/" + id + "/actions", "", `{"type": "plan"}`, http.StatusUnauthorized},
+		{"Hints without token", "GET", "/api/games/" + id + "/hints", "", "", http.StatusUnauthorized},
+		{"Hints with a made-up token", "GET", "/api/games/" + id + "/hints", "guess", "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(router, tt.method, tt.path, tt.token, tt.body).Code; got != tt.status {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, got, tt.status)
+			}
+		})
+	}
+}
+
+func TestSetupRoutes_HintsOffByDefault(t *testing.T) {
+	t.Setenv("HINTS_ENABLED", "false")
+	router := createRouter(t)
+
+	if got := serve(router, "GET", "/api/games/any/hints", "", "").Code; got != http.StatusNotFound {
+		t.Errorf("GET hints with HINTS_ENABLED=false = %d, want %d", got, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,121 @@
+package simulation
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ResultWriter streams race results to a file as they are finished
+type ResultWriter interface {
+	// Write adds the result of one race
+	// Input: result - the race to write
+	// Returns: an error if writing fails
+	Write(result GameResult) error
+
+	// Flush writes out anything still buffered
+	// Returns: an error if writing fails
+	Flush() error
+}
+
+// csvHeader names the columns of a CSV result file, one row per seat per race
+var csvHeader = []string{"seed", "rounds", "winner", "seat", "bot", "position", "finish_round", "spin_outs", "heat_used"}
+
+type jsonlWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+// NewResultWriter creates a writer for a file format
+// Input: format - "jsonl" or "csv"
+//
+//	w - where the results are written
+//
+// Returns: a new ResultWriter, an error if the format is unknown
+func NewResultWriter(format string, w io.Writer) (ResultWriter, error) {
+	switch strings.ToLower(format) {
+	case "jsonl":
+		return NewJSONLWriter(w), nil
+	case "csv":
+		return NewCSVWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown result format %q", format)
+	}
+}
+
+// NewJSONLWriter creates a writer that puts every race on its own line as a JSON object
+// Input: w - where the results are written
+// Returns: a new ResultWriter
+func NewJSONLWriter(w io.Writer) ResultWriter {
+	buffer := bufio.NewWriter(w)
+	return &jsonlWriter{buffer: buffer, encoder: json.NewEncoder(buffer)}
+}
+
+// Write adds the result of one race as a line of JSON
+// Input: result - the race to write
+// Returns: an error if writing fails
+func (j *jsonlWriter) Write(result GameResult) error {
+	return j.encoder.Encode(result)
+}
+
+// Flush writes out anything still buffered
+// Input: none
+// Returns: an error if writing fails
+func (j *jsonlWriter) Flush() error {
+	return j.buffer.Flush()
+}
+
+// NewCSVWriter creates a writer that puts every seat of every race on its own row
+// Card usage is left out; use JSONL to keep it
+// Input: w - where the results are written
+// Returns: a new ResultWriter
+func NewCSVWriter(w io.Writer) ResultWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+// Write adds one row per seat of a race, after the header for the first race
+// Input: result - the race to write
+// Returns: an error if writing fails
+func (c *csvWriter) Write(result GameResult) error {
+	if !c.header {
+		if err := c.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		c.header = true
+	}
+
+	for _, seat := range result.Seats {
+		row := []string{
+			strconv.FormatInt(result.Seed, 10),
+			strconv.Itoa(result.Rounds),
+			strconv.Itoa(result.Winner),
+			strconv.Itoa(seat.Seat),
+			seat.Bot,
+			strconv.Itoa(seat.Position),
+			strconv.Itoa(seat.FinishRound),
+			strconv.Itoa(seat.SpinOuts),
+			strconv.Itoa(seat.HeatUsed),
+		}
+		if err := c.writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes out anything still buffered
+// Input: none
+// Returns: an error if writing fails
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
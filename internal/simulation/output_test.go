@@ -0,0 +1,77 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewResultWriter(t *testing.T) {
+	for _, format := range []string{"jsonl", "CSV"} {
+		if _, err := NewResultWriter(format, &bytes.Buffer{}); err != nil {
+			t.Errorf("NewResultWriter(%s) error = %v", format, err)
+		}
+	}
+	if _, err := NewResultWriter("xml", &bytes.Buffer{}); err == nil {
+		t.Error("NewResultWriter() with an unknown format should fail")
+	}
+}
+
+func TestJSONLWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := NewJSONLWriter(&buffer)
+	results := createTestResults()
+	for _, result := range results {
+		if err := writer.Write(result); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != len(results) {
+		t.Fatalf("wrote %d lines, want %d", len(lines), len(results))
+	}
+	for i, line := range lines {
+		var decoded GameResult
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("line %d is not JSON: %v", i, err)
+		}
+		if !reflect.DeepEqual(decoded, results[i]) {
+			t.Errorf("line %d = %+v, want %+v", i, decoded, results[i])
+		}
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := NewCSVWriter(&buffer)
+	for _, result := range createTestResults() {
+		if err := writer.Write(result); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&buffer).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v", err)
+	}
+	if len(rows) != 1+4 {
+		t.Fatalf("wrote %d rows, want a header and 4 seats", len(rows))
+	}
+	if !reflect.DeepEqual(rows[0], csvHeader) {
+		t.Errorf("header = %v, want %v", rows[0], csvHeader)
+	}
+	expected := []string{"2", "0", "1", "1", "easy", "1", "14", "0", "8"}
+	if !reflect.DeepEqual(rows[4], expected) {
+		t.Errorf("last row = %v, want %v", rows[4], expected)
+	}
+}
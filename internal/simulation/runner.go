@@ -0,0 +1,172 @@
+package simulation
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// DefaultShardSize is the number of consecutive seeds a worker takes at once
+const DefaultShardSize = 64
+
+// RunOptions sets how a simulation is spread over goroutines
+// Neither option changes the results: every race depends only on its seed, and results are written in seed order
+type RunOptions struct {
+	// Workers is the number of goroutines running races, 0 uses every CPU
+	Workers int `json:"workers,omitempty"`
+
+	// ShardSize is the number of consecutive seeds handed to a worker at once, 0 uses DefaultShardSize
+	ShardSize int `json:"shard_size,omitempty"`
+}
+
+// shard is a range of consecutive races handed to one worker
+type shard struct {
+	index int
+	first int
+	count int
+}
+
+// shardResult is the outcome of a shard, or the error that stopped it
+type shardResult struct {
+	index   int
+	results []GameResult
+	err     error
+}
+
+// RunParallel plays every race of a simulation on a pool of workers
+// Seeds are split into shards of consecutive races; finished shards are put back in order before they are
+// counted and written, so the Stats and the output are the same for any number of workers
+// At most two shards per worker are in flight, so memory does not grow with the number of games
+// Input: config - the simulation config
+//
+//	options - the number of workers and the shard size
+//	out - where every race result is streamed, nil to only collect Stats
+//
+// Returns: the Stats of every race, an error if the config is invalid, a race fails or writing fails
+func RunParallel(config Config, options RunOptions, out ResultWriter) (Stats, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if options.Workers < 0 || options.ShardSize < 0 {
+		return nil, errors.New("workers and shard size cannot be negative")
+	}
+	if options.Workers == 0 {
+		options.Workers = runtime.GOMAXPROCS(0)
+	}
+	if options.ShardSize == 0 {
+		options.ShardSize = DefaultShardSize
+	}
+
+	shards := (config.Games + options.ShardSize - 1) / options.ShardSize
+	jobs := make(chan shard)
+	results := make(chan shardResult)
+	window := make(chan struct{}, 2*options.Workers)
+	stop := make(chan struct{})
+
+	// The dispatcher hands out shards in order, waiting for room in the window before each one
+	go func() {
+		defer close(jobs)
+		for i := 0; i < shards; i++ {
+			select {
+			case window <- struct{}{}:
+			case <-stop:
+				return
+			}
+			first := i * options.ShardSize
+			select {
+			case jobs <- shard{index: i, first: first, count: min(options.ShardSize, config.Games-first)}:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < options.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results <- runShard(config, job)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	stats := NewStats(config.Bots)
+	pending := make(map[int]shardResult)
+	next := 0
+	var failure error
+	for result := range results {
+		if failure != nil {
+			continue
+		}
+		pending[result.index] = result
+
+		for done, ok := pending[next]; ok; done, ok = pending[next] {
+			delete(pending, next)
+			next++
+			<-window
+
+			if done.err == nil {
+				done.err = record(stats, out, done.results)
+			}
+			if done.err != nil {
+				failure = done.err
+				close(stop)
+				break
+			}
+		}
+	}
+	if failure != nil {
+		return nil, failure
+	}
+
+	if out != nil {
+		if err := out.Flush(); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// runShard plays the races of one shard
+// Input: config - the simulation config
+//
+//	job - the shard to play
+//
+// Returns: the results in seed order, or the first error
+func runShard(config Config, job shard) shardResult {
+	results := make([]GameResult, 0, job.count)
+	for i := job.first; i < job.first+job.count; i++ {
+		result, err := RunGame(config, config.Seed+int64(i))
+		if err != nil {
+			return shardResult{index: job.index, err: err}
+		}
+		results = append(results, result)
+	}
+	return shardResult{index: job.index, results: results}
+}
+
+// record counts the results of a shard and writes them out
+// Input: stats - the Stats to add to
+//
+//	out - where results are written, nil to skip writing
+//	results - the results of the shard
+//
+// Returns: an error if writing fails
+func record(stats Stats, out ResultWriter, results []GameResult) error {
+	for _, result := range results {
+		stats.Add(result)
+		if out == nil {
+			continue
+		}
+		if err := out.Write(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
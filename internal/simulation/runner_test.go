@@ -0,0 +1,84 @@
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"race-cars/internal/tracks"
+)
+
+// failingWriter fails after a number of writes
+type failingWriter struct {
+	left int
+}
+
+func (f *failingWriter) Write(result GameResult) error {
+	if f.left == 0 {
+		return errors.New("disk full")
+	}
+	f.left--
+	return nil
+}
+
+func (f *failingWriter) Flush() error {
+	return nil
+}
+
+func TestRunParallel_SameResultsForAnyWorkerCount(t *testing.T) {
+	config := createTestConfig(t, "hard", "normal", "easy")
+	config.Games = 20
+
+	run := func(options RunOptions) (Summary, string) {
+		var buffer bytes.Buffer
+		stats, err := RunParallel(config, options, NewJSONLWriter(&buffer))
+		if err != nil {
+			t.Fatalf("RunParallel(%+v) error = %v", options, err)
+		}
+		return stats.GetSummary(), buffer.String()
+	}
+
+	summary, output := run(RunOptions{Workers: 1})
+	for _, options := range []RunOptions{{Workers: 4}, {Workers: 8, ShardSize: 1}, {Workers: 3, ShardSize: 7}} {
+		other, otherOutput := run(options)
+		if !reflect.DeepEqual(summary, other) {
+			t.Errorf("%+v: summary differs from a single worker", options)
+		}
+		if output != otherOutput {
+			t.Errorf("%+v: output differs from a single worker", options)
+		}
+	}
+	if summary.Games != 20 {
+		t.Errorf("Games = %d, want 20", summary.Games)
+	}
+}
+
+func TestRunParallel_Errors(t *testing.T) {
+	config := createTestConfig(t, "hard")
+
+	if _, err := RunParallel(config, RunOptions{Workers: -1}, nil); err == nil {
+		t.Error("RunParallel() with negative workers should fail")
+	}
+
+	config.Games = 10
+	if _, err := RunParallel(config, RunOptions{Workers: 4, ShardSize: 2}, &failingWriter{left: 3}); err == nil {
+		t.Error("RunParallel() should return the writer's error")
+	}
+
+	config.Upgrades = []string{"Rocket"}
+	if _, err := RunParallel(config, RunOptions{Workers: 4, ShardSize: 1}, nil); err == nil {
+		t.Error("RunParallel() should return the error of a failed race")
+	}
+}
+
+func BenchmarkRunParallel(b *testing.B) {
+	track, _ := tracks.GetTrack("USA")
+	config := Config{Track: track, Bots: []string{"hard", "hard", "hard"}, Seed: 1, Games: 32}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := RunParallel(config, RunOptions{}, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+
+	"race-cars/internal/ai"
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// Config describes a batch of simulated races
+// Every race is run on the same track with the same bots; only the seed changes
+type Config struct {
+	Track tracks.Track `json:"track"`
+
+	// Laps is the number of laps to race, 0 uses the track's default
+	Laps int `json:"laps,omitempty"`
+
+	// Bots names the bot on every seat, like "hard" or "mcts:100"
+	Bots []string `json:"bots"`
+
+	Modules []string `json:"modules,omitempty"`
+
+	// Upgrades are Garage upgrades added to every seat's starting deck, used to try out cards
+	Upgrades []string `json:"upgrades,omitempty"`
+
+	// Seed is the seed of the first race, race i uses Seed + i
+	Seed int64 `json:"seed"`
+
+	// Games is the number of races to run
+	Games int `json:"games"`
+
+	// MaxRounds ends a race that has not finished after this many rounds, 0 uses the engine's default
+	MaxRounds int `json:"max_rounds,omitempty"`
+}
+
+// SeatResult is how one seat did in a simulated race
+type SeatResult struct {
+	Seat     int    `json:"seat"`
+	Bot      string `json:"bot"`
+	Position int    `json:"position"`
+
+	// FinishRound is the round the car crossed the finish line, 0 if it did not finish
+	FinishRound int `json:"finish_round"`
+
+	SpinOuts int `json:"spin_outs"`
+
+	// HeatUsed is the number of heat cards that left the engine, whether for shifting, boosting, cards or corners
+	HeatUsed int `json:"heat_used"`
+}
+
+// GameResult is the outcome of one simulated race
+type GameResult struct {
+	Seed   int64        `json:"seed"`
+	Rounds int          `json:"rounds"`
+	Winner int          `json:"winner"`
+	Seats  []SeatResult `json:"seats"`
+
+	// Cards counts the cards revealed by every seat, by name
+	Cards map[string]int `json:"cards"`
+}
+
+// Validate checks a simulation config before any race is run
+// Input: none
+// Returns: an error if the track, bots or number of games are invalid
+func (c Config) Validate() error {
+	if c.Games < 1 {
+		return errors.New("simulation needs at least 1 game")
+	}
+	if len(c.Bots) == 0 || len(c.Bots) > engine.MaxSeats {
+		return fmt.Errorf("simulation needs between 1 and %d bots", engine.MaxSeats)
+	}
+	for _, name := range c.Bots {
+		if _, err := ai.NewBot(name, 0); err != nil {
+			return err
+		}
+	}
+	return c.Track.Validate()
+}
+
+// RunGame plays one race with bots on every seat and collects its statistics
+// The engine and every bot are seeded from the race seed, so a seed always gives the same result
+// Input: config - the simulation config
+//
+//	seed - the seed of this race
+//
+// Returns: the GameResult, an error if the race cannot be set up or a bot fails
+func RunGame(config Config, seed int64) (GameResult, error) {
+	colors := []models.Color{models.Red, models.Blue, models.Green, models.Yellow, models.Orange, models.Black}
+	gameConfig := engine.Config{
+		Track:     config.Track,
+		Laps:      config.Laps,
+		Seed:      seed,
+		Modules:   config.Modules,
+		MaxRounds: config.MaxRounds,
+	}
+
+	bots := make([]ai.Bot, len(config.Bots))
+	for i, name := range config.Bots {
+		bot, err := ai.NewBot(name, seed*engine.MaxSeats+int64(i))
+		if err != nil {
+			return GameResult{}, err
+		}
+		bots[i] = bot
+		gameConfig.Seats = append(gameConfig.Seats, engine.Seat{
+			Name:     fmt.Sprintf("Seat %d", i+1),
+			Color:    colors[i],
+			Upgrades: config.Upgrades,
+		})
+	}
+
+	game, err := engine.NewGame(gameConfig)
+	if err != nil {
+		return GameResult{}, err
+	}
+	engines := make([]int, len(bots))
+	for i, player := range game.GetPlayers() {
+		engines[i] = player.GetCar().GetEngine()
+	}
+
+	if err := ai.PlayOut(game, bots); err != nil {
+		return GameResult{}, fmt.Errorf("seed %d: %w", seed, err)
+	}
+
+	return collect(game, seed, config.Bots, engines), nil
+}
+
+// collect reads the statistics of a finished race from its results and event log
+// Heat used is worked out from the engine: what is missing at the end plus what was cooled back in
+// Input: game - the finished race
+//
+//	seed - the seed of the race
+//	bots - the bot names by seat
+//	engines - the engine of every seat at the start
+//
+// Returns: the GameResult
+func collect(game engine.Game, seed int64, bots []string, engines []int) GameResult {
+	result := GameResult{
+		Seed:   seed,
+		Rounds: game.GetRound(),
+		Seats:  make([]SeatResult, len(bots)),
+		Cards:  make(map[string]int),
+	}
+
+	for i, player := range game.GetPlayers() {
+		result.Seats[i] = SeatResult{
+			Seat:     i,
+			Bot:      bots[i],
+			HeatUsed: engines[i] - player.GetCar().GetEngine(),
+		}
+	}
+	for _, standing := range game.GetResults() {
+		if standing.Seat >= len(bots) {
+			continue
+		}
+		result.Seats[standing.Seat].Position = standing.Position
+		result.Seats[standing.Seat].FinishRound = standing.Round
+	}
+	result.Winner = game.GetResults()[0].Seat
+
+	for _, event := range game.GetEvents() {
+		if event.Seat < 0 || event.Seat >= len(bots) {
+			continue
+		}
+		switch event.Type {
+		case engine.EventSpunOut:
+			result.Seats[event.Seat].SpinOuts++
+		case engine.EventCooled:
+			result.Seats[event.Seat].HeatUsed += event.Value
+		case engine.EventCardsRevealed:
+			for _, name := range event.Cards {
+				result.Cards[name]++
+			}
+		}
+	}
+	return result
+}
+
+// Run plays every race of a simulation one after the other
+// Input: config - the simulation config
+// Returns: the Stats of every race, an error if the config is invalid or a race fails
+func Run(config Config) (Stats, error) {
+	return RunParallel(config, RunOptions{Workers: 1}, nil)
+}
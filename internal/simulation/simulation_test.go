@@ -0,0 +1,107 @@
+package simulation
+
+import (
+	"reflect"
+	"testing"
+
+	"race-cars/internal/tracks"
+)
+
+// Helper function to create a short simulation on a built-in track
+func createTestConfig(t *testing.T, bots ...string) Config {
+	track, err := tracks.GetTrack("USA")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+	return Config{Track: track, Laps: 1, Bots: bots, Seed: 1, Games: 3}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(config *Config)
+		wantErr bool
+	}{
+		{name: "Valid config", change: func(config *Config) {}},
+		{name: "No games", change: func(config *Config) { config.Games = 0 }, wantErr: true},
+		{name: "No bots", change: func(config *Config) { config.Bots = nil }, wantErr: true},
+		{name: "Too many bots", change: func(config *Config) { config.Bots = make([]string, 7) }, wantErr: true},
+		{name: "Unknown bot", change: func(config *Config) { config.Bots = []string{"hard", "random"} }, wantErr: true},
+		{name: "Invalid track", change: func(config *Config) { config.Track = tracks.Track{Name: "Empty"} }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t, "hard", "easy")
+			tt.change(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunGame(t *testing.T) {
+	config := createTestConfig(t, "hard", "normal", "easy")
+	result, err := RunGame(config, 4)
+	if err != nil {
+		t.Fatalf("RunGame() error = %v", err)
+	}
+
+	if result.Seed != 4 || result.Rounds == 0 || len(result.Seats) != 3 {
+		t.Fatalf("RunGame() = %+v, want 3 seats of a finished race with seed 4", result)
+	}
+	positions := make(map[int]bool)
+	for i, seat := range result.Seats {
+		if seat.Seat != i || seat.Bot != config.Bots[i] {
+			t.Errorf("seat %d = %+v", i, seat)
+		}
+		if seat.HeatUsed < 0 || seat.SpinOuts < 0 {
+			t.Errorf("seat %d has negative counts: %+v", i, seat)
+		}
+		positions[seat.Position] = true
+		if seat.Position == 1 && result.Winner != i {
+			t.Errorf("Winner = %d, want seat %d in first place", result.Winner, i)
+		}
+	}
+	if len(positions) != 3 {
+		t.Errorf("positions = %v, want 1 to 3", positions)
+	}
+	if len(result.Cards) == 0 {
+		t.Error("RunGame() should count the revealed cards")
+	}
+
+	again, _ := RunGame(config, 4)
+	if !reflect.DeepEqual(result, again) {
+		t.Error("RunGame() with the same seed should give the same result")
+	}
+}
+
+func TestRunGame_Upgrades(t *testing.T) {
+	config := createTestConfig(t, "hard")
+	config.Upgrades = []string{"Brakes", "Brakes", "Brakes"}
+	if _, err := RunGame(config, 1); err != nil {
+		t.Fatalf("RunGame() error = %v", err)
+	}
+
+	config.Upgrades = []string{"Rocket"}
+	if _, err := RunGame(config, 1); err == nil {
+		t.Error("RunGame() with an unknown upgrade should fail")
+	}
+}
+
+func TestRun(t *testing.T) {
+	config := createTestConfig(t, "hard", "easy")
+	stats, err := Run(config)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if stats.GetGames() != 3 {
+		t.Errorf("GetGames() = %d, want 3", stats.GetGames())
+	}
+
+	config.Games = 0
+	if _, err := Run(config); err == nil {
+		t.Error("Run() with no games should fail")
+	}
+}
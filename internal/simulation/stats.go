@@ -0,0 +1,212 @@
+package simulation
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// SeatSummary is how one seat did over a simulation
+type SeatSummary struct {
+	Seat         int     `json:"seat"`
+	Bot          string  `json:"bot"`
+	Wins         int     `json:"wins"`
+	WinRate      float64 `json:"win_rate"`
+	MeanPosition float64 `json:"mean_position"`
+
+	// MeanFinishRound averages the races the seat finished, 0 if it never did
+	MeanFinishRound float64 `json:"mean_finish_round"`
+
+	// Unfinished counts the races the seat did not finish before the round limit
+	Unfinished      int     `json:"unfinished"`
+	SpinOutsPerGame float64 `json:"spin_outs_per_game"`
+	HeatPerGame     float64 `json:"heat_per_game"`
+}
+
+// CardUsage is how often a card was revealed over a simulation
+type CardUsage struct {
+	Name    string  `json:"name"`
+	Count   int     `json:"count"`
+	PerGame float64 `json:"per_game"`
+}
+
+// Summary is the aggregate statistics of a simulation
+type Summary struct {
+	Games int `json:"games"`
+
+	// MeanFinishRound averages the finishing round of every car that finished
+	MeanFinishRound float64       `json:"mean_finish_round"`
+	SpinOutsPerGame float64       `json:"spin_outs_per_game"`
+	HeatPerGame     float64       `json:"heat_per_game"`
+	Seats           []SeatSummary `json:"seats"`
+
+	// Cards lists every revealed card, most used first
+	Cards []CardUsage `json:"cards"`
+}
+
+// Stats collects the results of simulated races
+// Only counts are kept, so the summary does not depend on the order races are added in
+type Stats interface {
+	// Add counts the result of one race
+	// Input: result - the race to add
+	Add(result GameResult)
+
+	// GetGames returns the number of races added
+	// Returns: the number of races
+	GetGames() int
+
+	// GetSummary works out the averages of every race added so far
+	// Returns: the Summary
+	GetSummary() Summary
+}
+
+// seatTotals adds up the results of one seat
+type seatTotals struct {
+	wins        int
+	positions   int
+	finished    int
+	finishRound int
+	spinOuts    int
+	heat        int
+}
+
+type stats struct {
+	bots  []string
+	games int
+	seats []seatTotals
+	cards map[string]int
+}
+
+// NewStats creates an empty Stats for a simulation
+// Input: bots - the bot names by seat
+// Returns: a new Stats
+func NewStats(bots []string) Stats {
+	return &stats{
+		bots:  append([]string(nil), bots...),
+		seats: make([]seatTotals, len(bots)),
+		cards: make(map[string]int),
+	}
+}
+
+// Add counts the result of one race
+// Input: result - the race to add
+// Returns: none
+func (s *stats) Add(result GameResult) {
+	s.games++
+	for _, seat := range result.Seats {
+		if seat.Seat < 0 || seat.Seat >= len(s.seats) {
+			continue
+		}
+		totals := &s.seats[seat.Seat]
+		if seat.Seat == result.Winner {
+			totals.wins++
+		}
+		totals.positions += seat.Position
+		if seat.FinishRound > 0 {
+			totals.finished++
+			totals.finishRound += seat.FinishRound
+		}
+		totals.spinOuts += seat.SpinOuts
+		totals.heat += seat.HeatUsed
+	}
+	for name, count := range result.Cards {
+		s.cards[name] += count
+	}
+}
+
+// GetGames returns the number of races added
+// Input: none
+// Returns: the number of races
+func (s *stats) GetGames() int {
+	return s.games
+}
+
+// GetSummary works out the averages of every race added so far
+// Input: none
+// Returns: the Summary
+func (s *stats) GetSummary() Summary {
+	summary := Summary{
+		Games: s.games,
+		Seats: make([]SeatSummary, len(s.seats)),
+		Cards: make([]CardUsage, 0, len(s.cards)),
+	}
+
+	finished, finishRound, spinOuts, heat := 0, 0, 0, 0
+	for i, totals := range s.seats {
+		seat := SeatSummary{
+			Seat:            i,
+			Bot:             s.bots[i],
+			Wins:            totals.wins,
+			WinRate:         ratio(totals.wins, s.games),
+			MeanPosition:    ratio(totals.positions, s.games),
+			MeanFinishRound: ratio(totals.finishRound, totals.finished),
+			Unfinished:      s.games - totals.finished,
+			SpinOutsPerGame: ratio(totals.spinOuts, s.games),
+			HeatPerGame:     ratio(totals.heat, s.games),
+		}
+		summary.Seats[i] = seat
+
+		finished += totals.finished
+		finishRound += totals.finishRound
+		spinOuts += totals.spinOuts
+		heat += totals.heat
+	}
+	summary.MeanFinishRound = ratio(finishRound, finished)
+	summary.SpinOutsPerGame = ratio(spinOuts, s.games)
+	summary.HeatPerGame = ratio(heat, s.games)
+
+	for name, count := range s.cards {
+		summary.Cards = append(summary.Cards, CardUsage{Name: name, Count: count, PerGame: ratio(count, s.games)})
+	}
+	sort.Slice(summary.Cards, func(i, j int) bool {
+		if summary.Cards[i].Count != summary.Cards[j].Count {
+			return summary.Cards[i].Count > summary.Cards[j].Count
+		}
+		return summary.Cards[i].Name < summary.Cards[j].Name
+	})
+	return summary
+}
+
+// WriteSummary prints a summary as aligned tables
+// Input: w - where to print
+//
+//	summary - the summary to print
+//
+// Returns: an error if writing fails
+func WriteSummary(w io.Writer, summary Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Games\t%d\n", summary.Games)
+	fmt.Fprintf(tw, "Mean finishing round\t%.2f\n", summary.MeanFinishRound)
+	fmt.Fprintf(tw, "Spin-outs per game\t%.2f\n", summary.SpinOutsPerGame)
+	fmt.Fprintf(tw, "Heat used per game\t%.2f\n", summary.HeatPerGame)
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Seat\tBot\tWin rate\tMean position\tMean finish round\tUnfinished\tSpin-outs/game\tHeat/game")
+	for _, seat := range summary.Seats {
+		fmt.Fprintf(tw, "%d\t%s\t%.1f%%\t%.2f\t%.2f\t%d\t%.2f\t%.2f\n",
+			seat.Seat+1, seat.Bot, seat.WinRate*100, seat.MeanPosition, seat.MeanFinishRound,
+			seat.Unfinished, seat.SpinOutsPerGame, seat.HeatPerGame)
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Card\tPlayed\tPer game")
+	for _, card := range summary.Cards {
+		fmt.Fprintf(tw, "%s\t%d\t%.2f\n", card.Name, card.Count, card.PerGame)
+	}
+	return tw.Flush()
+}
+
+// ratio divides two counts, returning 0 when there is nothing to divide by
+// Input: count - the numerator
+//
+//	total - the denominator
+//
+// Returns: count / total as a float
+func ratio(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
@@ -0,0 +1,101 @@
+package simulation
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Helper function to create the results of two races between two seats
+func createTestResults() []GameResult {
+	return []GameResult{
+		{
+			Seed:   1,
+			Winner: 0,
+			Seats: []SeatResult{
+				{Seat: 0, Bot: "hard", Position: 1, FinishRound: 10, SpinOuts: 0, HeatUsed: 4},
+				{Seat: 1, Bot: "easy", Position: 2, FinishRound: 12, SpinOuts: 2, HeatUsed: 6},
+			},
+			Cards: map[string]int{"Speed 4": 5, "Stress": 2},
+		},
+		{
+			Seed:   2,
+			Winner: 1,
+			Seats: []SeatResult{
+				{Seat: 0, Bot: "hard", Position: 2, FinishRound: 0, SpinOuts: 1, HeatUsed: 2},
+				{Seat: 1, Bot: "easy", Position: 1, FinishRound: 14, SpinOuts: 0, HeatUsed: 8},
+			},
+			Cards: map[string]int{"Speed 4": 1, "Speed 1": 2},
+		},
+	}
+}
+
+func TestStats_GetSummary(t *testing.T) {
+	stats := NewStats([]string{"hard", "easy"})
+	for _, result := range createTestResults() {
+		stats.Add(result)
+	}
+
+	summary := stats.GetSummary()
+	if summary.Games != 2 {
+		t.Errorf("Games = %d, want 2", summary.Games)
+	}
+	if summary.MeanFinishRound != 12 {
+		t.Errorf("MeanFinishRound = %v, want 12", summary.MeanFinishRound)
+	}
+	if summary.SpinOutsPerGame != 1.5 || summary.HeatPerGame != 10 {
+		t.Errorf("SpinOutsPerGame = %v, HeatPerGame = %v, want 1.5 and 10", summary.SpinOutsPerGame, summary.HeatPerGame)
+	}
+
+	expected := []SeatSummary{
+		{Seat: 0, Bot: "hard", Wins: 1, WinRate: 0.5, MeanPosition: 1.5, MeanFinishRound: 10, Unfinished: 1, SpinOutsPerGame: 0.5, HeatPerGame: 3},
+		{Seat: 1, Bot: "easy", Wins: 1, WinRate: 0.5, MeanPosition: 1.5, MeanFinishRound: 13, Unfinished: 0, SpinOutsPerGame: 1, HeatPerGame: 7},
+	}
+	if !reflect.DeepEqual(summary.Seats, expected) {
+		t.Errorf("Seats = %+v, want %+v", summary.Seats, expected)
+	}
+
+	cards := []CardUsage{{Name: "Speed 4", Count: 6, PerGame: 3}, {Name: "Speed 1", Count: 2, PerGame: 1}, {Name: "Stress", Count: 2, PerGame: 1}}
+	if !reflect.DeepEqual(summary.Cards, cards) {
+		t.Errorf("Cards = %+v, want %+v", summary.Cards, cards)
+	}
+}
+
+func TestStats_OrderDoesNotMatter(t *testing.T) {
+	results := createTestResults()
+	forward := NewStats([]string{"hard", "easy"})
+	backward := NewStats([]string{"hard", "easy"})
+	for i := range results {
+		forward.Add(results[i])
+		backward.Add(results[len(results)-1-i])
+	}
+
+	if !reflect.DeepEqual(forward.GetSummary(), backward.GetSummary()) {
+		t.Error("The summary should not depend on the order results are added in")
+	}
+}
+
+func TestStats_Empty(t *testing.T) {
+	summary := NewStats([]string{"hard"}).GetSummary()
+	if summary.Games != 0 || summary.MeanFinishRound != 0 || summary.Seats[0].WinRate != 0 {
+		t.Errorf("GetSummary() of no races = %+v, want zeros", summary)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	stats := NewStats([]string{"hard", "easy"})
+	for _, result := range createTestResults() {
+		stats.Add(result)
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteSummary(&buffer, stats.GetSummary()); err != nil {
+		t.Fatalf("WriteSummary() error = %v", err)
+	}
+	for _, expected := range []string{"Mean finishing round", "hard", "easy", "50.0%", "Speed 4"} {
+		if !strings.Contains(buffer.String(), expected) {
+			t.Errorf("WriteSummary() output is missing %q:\n%s", expected, buffer.String())
+		}
+	}
+}
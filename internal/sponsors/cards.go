@@ -0,0 +1,90 @@
+package sponsors
+
+import (
+	"fmt"
+	"math/rand"
+
+	"race-cars/internal/models"
+)
+
+const (
+	// SponsorCopies is the number of copies of each sponsor in the sponsor deck
+	SponsorCopies = 3
+
+	// OverLimit is how far over a corner's speed limit a car must go to earn a sponsor card
+	OverLimit = 2
+)
+
+// Sponsor describes a one-use sponsor card
+type Sponsor struct {
+	Name  string
+	Speed int
+	Icons map[models.Icon]int
+}
+
+// sponsors is the catalog of sponsor cards
+var sponsors = []Sponsor{
+	{Name: "Nitro", Speed: 0, Icons: map[models.Icon]int{models.IconBoost: 2}},
+	{Name: "Coolant", Speed: 0, Icons: map[models.Icon]int{models.IconCooling: 2}},
+	{Name: "Slick Tires", Speed: 3},
+	{Name: "Aero Package", Speed: 2, Icons: map[models.Icon]int{models.IconBoost: 1}},
+	{Name: "Team Radio", Speed: 1, Icons: map[models.Icon]int{models.IconBoost: 1, models.IconCooling: 1}},
+}
+
+// GetSponsors returns the catalog of sponsor cards
+// Input: none
+// Returns: a copy of every sponsor in the catalog
+func GetSponsors() []Sponsor {
+	result := make([]Sponsor, len(sponsors))
+	copy(result, sponsors)
+	return result
+}
+
+// NewSponsorCard creates a card from the sponsor catalog
+// Input: name - the name of the sponsor
+// Returns: a new Card, an error if the sponsor does not exist
+func NewSponsorCard(name string) (models.Card, error) {
+	for _, sponsor := range sponsors {
+		if sponsor.Name == name {
+			return sponsor.NewCard(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown sponsor %q", name)
+}
+
+// NewCard creates a card for the sponsor
+// Sponsor cards are one-use: they leave the game once played or discarded
+// Input: none
+// Returns: a new Card
+func (s Sponsor) NewCard() models.Card {
+	return models.NewOneUseCard(s.Name, s.Speed, s.Icons)
+}
+
+// NewSponsorDeck creates the shuffled deck sponsor cards are awarded from
+// Input: rng - the random source used to shuffle the deck
+// Returns: a Deck with SponsorCopies of every sponsor
+func NewSponsorDeck(rng *rand.Rand) models.Deck {
+	cards := make([]models.Card, 0, len(sponsors)*SponsorCopies)
+	for _, sponsor := range sponsors {
+		for i := 0; i < SponsorCopies; i++ {
+			cards = append(cards, sponsor.NewCard())
+		}
+	}
+
+	deck := models.NewSeededDeck(cards, rng)
+	deck.Shuffle()
+	return deck
+}
+
+// IsEarned returns whether taking a corner earns a sponsor card
+// A car earns one by taking the corner OverLimit or more over its speed limit,
+// or by slipstreaming across the line of a corner with a press area
+// Input: over - the car's speed minus the corner's speed limit
+//
+//	pressSlipstream - whether the car slipstreamed across a press corner
+//
+// Returns: a boolean
+func IsEarned(over int, pressSlipstream bool) bool {
+	return over >= OverLimit || pressSlipstream
+}
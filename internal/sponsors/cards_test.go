@@ -0,0 +1,87 @@
+package sponsors
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewSponsorCard(t *testing.T) {
+	tests := []struct {
+		name        string
+		sponsor     string
+		wantErr     bool
+		expectSpeed int
+	}{
+		{
+			name:        "Known sponsor",
+			sponsor:     "Slick Tires",
+			expectSpeed: 3,
+		},
+		{
+			name:    "Unknown sponsor",
+			sponsor: "Oil Company",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := NewSponsorCard(tt.sponsor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSponsorCard() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if card.GetName() != tt.sponsor {
+				t.Errorf("GetName() = %s, want %s", card.GetName(), tt.sponsor)
+			}
+			if card.GetSpeed() != tt.expectSpeed {
+				t.Errorf("GetSpeed() = %d, want %d", card.GetSpeed(), tt.expectSpeed)
+			}
+			if !card.IsOneUse() {
+				t.Error("Sponsor cards should be one-use")
+			}
+		})
+	}
+}
+
+func TestNewSponsorDeck(t *testing.T) {
+	deck := NewSponsorDeck(rand.New(rand.NewSource(1)))
+	again := NewSponsorDeck(rand.New(rand.NewSource(1)))
+
+	count := 0
+	for card := deck.DrawCard(); card != nil; card = deck.DrawCard() {
+		other := again.DrawCard()
+		if other == nil || other.GetName() != card.GetName() {
+			t.Fatal("Sponsor decks with the same seed should be shuffled the same way")
+		}
+		count++
+	}
+
+	if count != len(GetSponsors())*SponsorCopies {
+		t.Errorf("Sponsor deck has %d cards, want %d", count, len(GetSponsors())*SponsorCopies)
+	}
+}
+
+func TestIsEarned(t *testing.T) {
+	tests := []struct {
+		name            string
+		over            int
+		pressSlipstream bool
+		expected        bool
+	}{
+		{name: "Under the limit", over: -1, expected: false},
+		{name: "One over the limit", over: 1, expected: false},
+		{name: "Two over the limit", over: 2, expected: true},
+		{name: "Slipstream across a press corner", over: 0, pressSlipstream: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEarned(tt.over, tt.pressSlipstream); got != tt.expected {
+				t.Errorf("IsEarned() = %t, want %t", got, tt.expected)
+			}
+		})
+	}
+}
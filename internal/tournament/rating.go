@@ -0,0 +1,118 @@
+package tournament
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// BaseRating is the rating of an average entrant
+	BaseRating = 1500.0
+
+	// DefaultBootstrap is the number of resamples behind a confidence interval
+	DefaultBootstrap = 200
+
+	// Confidence is the share of resampled ratings inside a confidence interval
+	Confidence = 0.95
+
+	// eloScale is the rating difference at which the stronger entrant wins ten times as often
+	eloScale = 400.0
+
+	// fitIterations caps the iterations of the rating fit
+	fitIterations = 1000
+
+	// fitTolerance stops the rating fit once no strength changes by more than this share
+	fitTolerance = 1e-9
+)
+
+// FitRatings works out the ratings that best explain the race results, on the Elo scale
+// This is the Bradley-Terry model, where an entrant rated 400 higher is ten times as likely to win; it is fitted to
+// every result at once, so unlike running Elo updates the order of the races does not matter
+// Every entrant also gets one win and one loss against a virtual entrant rated BaseRating, which keeps the ratings of
+// entrants that always win or always lose finite and pulls entrants with few races towards the middle
+// Input: entrants - the number of entrants
+//
+//	results - the races
+//
+// Returns: the rating of every entrant
+func FitRatings(entrants int, results []MatchResult) []float64 {
+	won := make([]float64, entrants)
+	races := make([][]float64, entrants)
+	for i := range races {
+		races[i] = make([]float64, entrants)
+	}
+	for _, result := range results {
+		a, b := result.Entrants[0], result.Entrants[1]
+		won[result.Winner]++
+		races[a][b]++
+		races[b][a]++
+	}
+
+	// Minorization-maximization: each strength becomes its wins over its expected share of every race it ran
+	strength := make([]float64, entrants)
+	for i := range strength {
+		strength[i] = 1
+	}
+	for iteration := 0; iteration < fitIterations; iteration++ {
+		next := make([]float64, entrants)
+		change := 0.0
+		for i := range strength {
+			expected := 2 / (strength[i] + 1)
+			for j, count := range races[i] {
+				if count > 0 {
+					expected += count / (strength[i] + strength[j])
+				}
+			}
+			next[i] = (won[i] + 1) / expected
+			change = max(change, math.Abs(next[i]-strength[i])/strength[i])
+		}
+		strength = next
+		if change < fitTolerance {
+			break
+		}
+	}
+
+	ratings := make([]float64, entrants)
+	for i, s := range strength {
+		ratings[i] = BaseRating + eloScale*math.Log10(s)
+	}
+	return ratings
+}
+
+// ConfidenceIntervals estimates how far every rating could be off by refitting it to resampled results
+// Input: entrants - the number of entrants
+//
+//	results - the races
+//	samples - the number of resamples
+//	seed - the seed of the resampling
+//
+// Returns: the lower and upper bound of every rating's Confidence interval
+func ConfidenceIntervals(entrants int, results []MatchResult, samples int, seed int64) ([]float64, []float64) {
+	lower := make([]float64, entrants)
+	upper := make([]float64, entrants)
+	if samples < 1 || len(results) == 0 {
+		ratings := FitRatings(entrants, results)
+		return ratings, append([]float64(nil), ratings...)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	fitted := make([][]float64, entrants)
+	resample := make([]MatchResult, len(results))
+	for s := 0; s < samples; s++ {
+		for i := range resample {
+			resample[i] = results[rng.Intn(len(results))]
+		}
+		for i, rating := range FitRatings(entrants, resample) {
+			fitted[i] = append(fitted[i], rating)
+		}
+	}
+
+	tail := (1 - Confidence) / 2
+	for i, ratings := range fitted {
+		sort.Float64s(ratings)
+		lower[i] = ratings[int(math.Floor(tail*float64(samples-1)))]
+		upper[i] = ratings[int(math.Ceil((1-tail)*float64(samples-1)))]
+	}
+	return lower, upper
+}
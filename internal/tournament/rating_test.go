@@ -0,0 +1,83 @@
+package tournament
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitRatings(t *testing.T) {
+	t.Run("no races", func(t *testing.T) {
+		for i, rating := range FitRatings(3, nil) {
+			if math.Abs(rating-BaseRating) > 1e-6 {
+				t.Errorf("entrant %d rating = %.2f, want %.0f", i, rating, BaseRating)
+			}
+		}
+	})
+
+	t.Run("even record", func(t *testing.T) {
+		ratings := FitRatings(2, createResults(0, 1, 5, 5))
+		if math.Abs(ratings[0]-ratings[1]) > 1e-6 {
+			t.Errorf("ratings = %v, want equal", ratings)
+		}
+	})
+
+	t.Run("dominant entrant", func(t *testing.T) {
+		ratings := FitRatings(2, createResults(0, 1, 10, 0))
+		if ratings[0] <= ratings[1] || math.IsInf(ratings[0], 0) {
+			t.Errorf("ratings = %v, want a finite higher rating for entrant 0", ratings)
+		}
+	})
+
+	t.Run("elo scale", func(t *testing.T) {
+		// With 1000 wins to 100 the virtual races barely matter and the gap is close to 400
+		ratings := FitRatings(2, createResults(0, 1, 1000, 100))
+		if gap := ratings[0] - ratings[1]; math.Abs(gap-eloScale) > 5 {
+			t.Errorf("rating gap = %.1f, want about %.0f", gap, eloScale)
+		}
+	})
+
+	t.Run("transitive", func(t *testing.T) {
+		results := append(createResults(0, 1, 8, 2), createResults(1, 2, 8, 2)...)
+		ratings := FitRatings(3, results)
+		if !(ratings[0] > ratings[1] && ratings[1] > ratings[2]) {
+			t.Errorf("ratings = %v, want descending", ratings)
+		}
+	})
+}
+
+func TestConfidenceIntervals(t *testing.T) {
+	results := createResults(0, 1, 12, 8)
+	ratings := FitRatings(2, results)
+	lower, upper := ConfidenceIntervals(2, results, 200, 1)
+	for i := range ratings {
+		if lower[i] > ratings[i] || upper[i] < ratings[i] || lower[i] == upper[i] {
+			t.Errorf("entrant %d interval %.0f to %.0f should contain %.0f", i, lower[i], upper[i], ratings[i])
+		}
+	}
+
+	// More races narrow the interval
+	wideLower, wideUpper := lower, upper
+	lower, upper = ConfidenceIntervals(2, createResults(0, 1, 120, 80), 200, 1)
+	if upper[0]-lower[0] >= wideUpper[0]-wideLower[0] {
+		t.Errorf("interval with 200 races = %.0f, want narrower than %.0f with 20", upper[0]-lower[0], wideUpper[0]-wideLower[0])
+	}
+
+	// Same seed, same intervals
+	again, _ := ConfidenceIntervals(2, createResults(0, 1, 120, 80), 200, 1)
+	if again[0] != lower[0] {
+		t.Error("ConfidenceIntervals() should be deterministic for a seed")
+	}
+}
+
+// Helper function to create the races between two entrants
+func createResults(a int, b int, aWins int, bWins int) []MatchResult {
+	results := make([]MatchResult, 0, aWins+bWins)
+	for i := 0; i < aWins+bWins; i++ {
+		winner := a
+		if i >= aWins {
+			winner = b
+		}
+		results = append(results, MatchResult{Entrants: [2]int{a, b}, Winner: winner})
+	}
+	return results
+}
@@ -0,0 +1,90 @@
+package tournament
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// Standing is how one entrant did in a tournament
+type Standing struct {
+	Entrant string `json:"entrant"`
+
+	// Rating is on the Elo scale, with the bounds of its confidence interval
+	Rating float64 `json:"rating"`
+	Lower  float64 `json:"lower"`
+	Upper  float64 `json:"upper"`
+
+	Races   int     `json:"races"`
+	Wins    int     `json:"wins"`
+	WinRate float64 `json:"win_rate"`
+}
+
+// Report is the outcome of a tournament
+type Report struct {
+	Format Format `json:"format"`
+	Races  int    `json:"races"`
+
+	// Standings are ordered by rating, best first
+	Standings []Standing `json:"standings"`
+
+	// Matches are every race in the order they were run
+	Matches []MatchResult `json:"matches"`
+}
+
+// newReport rates the entrants of a finished tournament
+// Input: config - the tournament config
+//
+//	entrants - the parsed entrants
+//	results - every race
+//
+// Returns: the Report
+func newReport(config Config, entrants []entrant, results []MatchResult) Report {
+	ratings := FitRatings(len(entrants), results)
+	lower, upper := ConfidenceIntervals(len(entrants), results, config.Bootstrap, config.Seed)
+	won := wins(results, len(entrants))
+
+	races := make([]int, len(entrants))
+	for _, result := range results {
+		races[result.Entrants[0]]++
+		races[result.Entrants[1]]++
+	}
+
+	standings := make([]Standing, len(entrants))
+	for i, e := range entrants {
+		standings[i] = Standing{
+			Entrant: e.name,
+			Rating:  ratings[i],
+			Lower:   lower[i],
+			Upper:   upper[i],
+			Races:   races[i],
+			Wins:    won[i],
+		}
+		if races[i] > 0 {
+			standings[i].WinRate = float64(won[i]) / float64(races[i])
+		}
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Rating > standings[j].Rating
+	})
+
+	return Report{Format: config.Format, Races: len(results), Standings: standings, Matches: results}
+}
+
+// WriteStandings prints the standings as a table
+// Input: w - where the table is written
+//
+//	report - the tournament report
+//
+// Returns: an error if writing fails
+func WriteStandings(w io.Writer, report Report) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "Rank\tEntrant\tRating\t%.0f%% CI\tRaces\tWins\tWin rate\n", Confidence*100)
+	for i, standing := range report.Standings {
+		fmt.Fprintf(table, "%d\t%s\t%.0f\t%.0f to %.0f\t%d\t%d\t%.1f%%\n",
+			i+1, standing.Entrant, standing.Rating, standing.Lower, standing.Upper,
+			standing.Races, standing.Wins, standing.WinRate*100)
+	}
+	return table.Flush()
+}
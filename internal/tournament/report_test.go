@@ -0,0 +1,28 @@
+package tournament
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteStandings(t *testing.T) {
+	entrants := []entrant{{name: "easy"}, {name: "hard"}}
+	report := newReport(Config{Format: RoundRobin, Bootstrap: 20}, entrants, createResults(0, 1, 1, 3))
+
+	if report.Standings[0].Entrant != "hard" || report.Standings[0].Wins != 3 || report.Standings[0].WinRate != 0.75 {
+		t.Errorf("leader = %+v, want hard with 3 wins", report.Standings[0])
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteStandings(&buffer, report); err != nil {
+		t.Fatalf("WriteStandings() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("WriteStandings() wrote %d lines, want a header and 2 rows", len(lines))
+	}
+	if !strings.Contains(lines[0], "95% CI") || !strings.HasPrefix(lines[1], "1") || !strings.Contains(lines[1], "hard") {
+		t.Errorf("WriteStandings() =\n%s", buffer.String())
+	}
+}
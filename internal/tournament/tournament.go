@@ -0,0 +1,411 @@
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"race-cars/internal/ai"
+	"race-cars/internal/engine"
+	"race-cars/internal/legends"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// Format decides who races whom
+type Format string
+
+const (
+	// RoundRobin races every entrant against every other one
+	RoundRobin Format = "round-robin"
+
+	// Swiss races entrants with similar scores against each other, round by round, avoiding rematches
+	Swiss Format = "swiss"
+)
+
+const (
+	// DefaultGamesPerTrack is the number of races a pairing runs on every track, one from each grid position
+	DefaultGamesPerTrack = 2
+
+	// DefaultLegendLevel is the level of an entrant named "legends"
+	DefaultLegendLevel = 1
+)
+
+// Config describes a tournament
+type Config struct {
+	// Entrants are the strategies taking part: bot names like "hard" or "mcts:100", and at most one "legends" or "legends:<level>"
+	Entrants []string `json:"entrants"`
+	Format   Format   `json:"format"`
+
+	// Tracks are raced by every pairing, every built-in track when empty
+	Tracks  []tracks.Track `json:"tracks,omitempty"`
+	Laps    int            `json:"laps"`
+	Modules []string       `json:"modules,omitempty"`
+
+	// GamesPerTrack is the number of races a pairing runs on each track, 0 uses DefaultGamesPerTrack
+	// The entrants swap grid positions from one race to the next
+	GamesPerTrack int `json:"games_per_track,omitempty"`
+
+	// Rounds is the number of Swiss rounds, 0 uses enough rounds to separate the entrants
+	Rounds int `json:"rounds,omitempty"`
+
+	// Seed is the seed of the first race, race i uses Seed + i
+	Seed int64 `json:"seed"`
+
+	// MaxRounds ends each race after this many rounds, 0 uses the engine's default
+	MaxRounds int `json:"max_rounds,omitempty"`
+
+	// Workers is the number of goroutines running races, 0 uses every CPU; it never changes the results
+	Workers int `json:"workers,omitempty"`
+
+	// Bootstrap is the number of resamples for the confidence intervals, 0 uses DefaultBootstrap
+	Bootstrap int `json:"bootstrap,omitempty"`
+}
+
+// MatchResult is the outcome of one head-to-head race
+type MatchResult struct {
+	Round int    `json:"round"`
+	Track string `json:"track"`
+	Seed  int64  `json:"seed"`
+
+	// Entrants are the entrant indexes in grid order, pole position first
+	Entrants [2]int `json:"entrants"`
+
+	// Winner is the index of the entrant that finished ahead
+	Winner int `json:"winner"`
+}
+
+// entrant is a parsed tournament entrant
+type entrant struct {
+	name   string
+	legend bool
+	level  int
+}
+
+// match is a race to be run
+type match struct {
+	round    int
+	track    tracks.Track
+	seed     int64
+	entrants [2]int
+}
+
+// Validate checks that a tournament can be run
+// Input: none
+// Returns: an error describing the first problem found
+func (c Config) Validate() error {
+	if len(c.Entrants) < 2 {
+		return errors.New("tournament needs at least 2 entrants")
+	}
+	if c.Format != RoundRobin && c.Format != Swiss {
+		return fmt.Errorf("unknown format %q", c.Format)
+	}
+	if c.GamesPerTrack < 0 || c.Rounds < 0 || c.Workers < 0 || c.Bootstrap < 0 {
+		return errors.New("games per track, rounds, workers and bootstrap cannot be negative")
+	}
+
+	entrants, err := parseEntrants(c.Entrants)
+	if err != nil {
+		return err
+	}
+	legendEntrants := 0
+	for _, e := range entrants {
+		if e.legend {
+			legendEntrants++
+		}
+	}
+	if legendEntrants > 1 {
+		return errors.New("tournament can have at most 1 legends entrant, a race needs a bot")
+	}
+
+	for _, track := range c.Tracks {
+		if err := track.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run plays a tournament and rates the entrants
+// Every race depends only on its seed, and ratings are fitted to all results at once, so the Report is the same
+// for any number of workers and any order the races finish in
+// Input: config - the tournament config
+// Returns: the Report, an error if the config is invalid or a race fails
+func Run(config Config) (Report, error) {
+	if err := config.Validate(); err != nil {
+		return Report{}, err
+	}
+	entrants, _ := parseEntrants(config.Entrants)
+	if len(config.Tracks) == 0 {
+		config.Tracks = tracks.GetTracks()
+	}
+	if config.GamesPerTrack == 0 {
+		config.GamesPerTrack = DefaultGamesPerTrack
+	}
+	if config.Workers == 0 {
+		config.Workers = runtime.GOMAXPROCS(0)
+	}
+	if config.Bootstrap == 0 {
+		config.Bootstrap = DefaultBootstrap
+	}
+
+	rounds := 1
+	if config.Format == Swiss {
+		rounds = config.Rounds
+		if rounds == 0 {
+			rounds = int(math.Ceil(math.Log2(float64(len(entrants)))))
+		}
+	}
+
+	results := make([]MatchResult, 0)
+	played := make(map[[2]int]bool)
+	for round := 1; round <= rounds; round++ {
+		var pairs [][2]int
+		if config.Format == RoundRobin {
+			pairs = roundRobinPairs(len(entrants))
+		} else {
+			pairs = swissPairs(wins(results, len(entrants)), FitRatings(len(entrants), results), played)
+		}
+
+		matches := make([]match, 0)
+		for _, pair := range pairs {
+			played[pair] = true
+			for _, track := range config.Tracks {
+				for g := 0; g < config.GamesPerTrack; g++ {
+					grid := pair
+					if g%2 == 1 {
+						grid = [2]int{pair[1], pair[0]}
+					}
+					seed := config.Seed + int64(len(results)+len(matches))
+					matches = append(matches, match{round: round, track: track, seed: seed, entrants: grid})
+				}
+			}
+		}
+
+		finished, err := runMatches(config, entrants, matches)
+		if err != nil {
+			return Report{}, err
+		}
+		results = append(results, finished...)
+	}
+
+	return newReport(config, entrants, results), nil
+}
+
+// runMatches plays races on a pool of workers
+// Input: config - the tournament config
+//
+//	entrants - the parsed entrants
+//	matches - the races to run
+//
+// Returns: the results in the order of the matches, the error of the first failed match
+func runMatches(config Config, entrants []entrant, matches []match) ([]MatchResult, error) {
+	results := make([]MatchResult, len(matches))
+	errs := make([]error, len(matches))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(config.Workers, len(matches)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = playMatch(config, entrants, matches[i])
+			}
+		}()
+	}
+	for i := range matches {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// playMatch runs one head-to-head race
+// Bots take the player seats in grid order; a Legends entrant drives a Legend, which always lines up behind the players
+// Input: config - the tournament config
+//
+//	entrants - the parsed entrants
+//	m - the race to run
+//
+// Returns: the MatchResult, an error if the race cannot be set up or a bot fails
+func playMatch(config Config, entrants []entrant, m match) (MatchResult, error) {
+	colors := []models.Color{models.Red, models.Blue}
+	gameConfig := engine.Config{
+		Track:     m.track,
+		Laps:      config.Laps,
+		Seed:      m.seed,
+		Modules:   config.Modules,
+		MaxRounds: config.MaxRounds,
+	}
+
+	// seats maps the engine's seat order, players then Legends, to the entrant indexes
+	seats := make([]int, 0, 2)
+	bots := make([]ai.Bot, 0, 2)
+	for i, index := range m.entrants {
+		e := entrants[index]
+		if e.legend {
+			continue
+		}
+		bot, err := ai.NewBot(e.name, m.seed*engine.MaxSeats+int64(i))
+		if err != nil {
+			return MatchResult{}, err
+		}
+		bots = append(bots, bot)
+		seats = append(seats, index)
+		gameConfig.Seats = append(gameConfig.Seats, engine.Seat{Name: e.name, Color: colors[i]})
+	}
+	for i, index := range m.entrants {
+		e := entrants[index]
+		if e.legend {
+			seats = append(seats, index)
+			gameConfig.Legends = append(gameConfig.Legends, legends.Driver{Name: "Legend", Color: colors[i], Level: e.level})
+		}
+	}
+
+	game, err := engine.NewGame(gameConfig)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	if err := ai.PlayOut(game, bots); err != nil {
+		return MatchResult{}, fmt.Errorf("seed %d: %w", m.seed, err)
+	}
+
+	return MatchResult{
+		Round:    m.round,
+		Track:    m.track.Name,
+		Seed:     m.seed,
+		Entrants: m.entrants,
+		Winner:   seats[game.GetResults()[0].Seat],
+	}, nil
+}
+
+// roundRobinPairs lists every pair of entrants
+// Input: entrants - the number of entrants
+// Returns: the pairs, lowest index first
+func roundRobinPairs(entrants int) [][2]int {
+	pairs := make([][2]int, 0, entrants*(entrants-1)/2)
+	for i := 0; i < entrants; i++ {
+		for j := i + 1; j < entrants; j++ {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+	return pairs
+}
+
+// swissPairs pairs entrants with similar scores, avoiding pairs that have already raced where possible
+// Entrants are ranked by wins, then rating, then entry order; with an odd number the last one left sits the round out
+// Input: wins - the race wins of every entrant so far
+//
+//	ratings - the rating of every entrant so far
+//	played - the pairs that have already raced, lowest index first
+//
+// Returns: the pairs, lowest index first
+func swissPairs(wins []int, ratings []float64, played map[[2]int]bool) [][2]int {
+	order := make([]int, len(wins))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if wins[i] != wins[j] {
+			return wins[i] > wins[j]
+		}
+		return ratings[i] > ratings[j]
+	})
+
+	paired := make([]bool, len(wins))
+	pairs := make([][2]int, 0, len(wins)/2)
+	for a, i := range order {
+		if paired[i] {
+			continue
+		}
+
+		// The closest entrant below that has not raced this one, or the closest one at all
+		opponent := -1
+		for _, j := range order[a+1:] {
+			if paired[j] {
+				continue
+			}
+			if opponent < 0 {
+				opponent = j
+			}
+			if !played[pairKey(i, j)] {
+				opponent = j
+				break
+			}
+		}
+		if opponent < 0 {
+			break
+		}
+
+		paired[i], paired[opponent] = true, true
+		pairs = append(pairs, pairKey(i, opponent))
+	}
+	return pairs
+}
+
+// pairKey orders a pair lowest index first
+// Input: i, j - the entrant indexes
+// Returns: the pair
+func pairKey(i int, j int) [2]int {
+	if i > j {
+		return [2]int{j, i}
+	}
+	return [2]int{i, j}
+}
+
+// wins counts the races every entrant has won
+// Input: results - the races so far
+//
+//	entrants - the number of entrants
+//
+// Returns: the wins by entrant index
+func wins(results []MatchResult, entrants int) []int {
+	counts := make([]int, entrants)
+	for _, result := range results {
+		counts[result.Winner]++
+	}
+	return counts
+}
+
+// parseEntrants turns entrant names into entrants
+// Input: names - the entrant names
+// Returns: the entrants, an error if a name is not a known bot or Legends level
+func parseEntrants(names []string) ([]entrant, error) {
+	entrants := make([]entrant, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name == "legends" || strings.HasPrefix(name, "legends:") {
+			level := DefaultLegendLevel
+			if value, ok := strings.CutPrefix(name, "legends:"); ok {
+				parsed, err := strconv.Atoi(value)
+				if err != nil || parsed < 0 || parsed > legends.MaxLevel {
+					return nil, fmt.Errorf("legends level must be between 0 and %d", legends.MaxLevel)
+				}
+				level = parsed
+			}
+			entrants = append(entrants, entrant{name: name, legend: true, level: level})
+			continue
+		}
+
+		if _, err := ai.NewBot(name, 0); err != nil {
+			return nil, err
+		}
+		entrants = append(entrants, entrant{name: name})
+	}
+	return entrants, nil
+}
@@ -0,0 +1,195 @@
+package tournament
+
+import (
+	"reflect"
+	"testing"
+
+	"race-cars/internal/tracks"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"round robin", Config{Entrants: []string{"easy", "hard"}, Format: RoundRobin}, false},
+		{"swiss with legends", Config{Entrants: []string{"easy", "hard", "legends:2"}, Format: Swiss}, false},
+		{"one entrant", Config{Entrants: []string{"hard"}, Format: RoundRobin}, true},
+		{"unknown format", Config{Entrants: []string{"easy", "hard"}, Format: "knockout"}, true},
+		{"unknown bot", Config{Entrants: []string{"easy", "expert"}, Format: RoundRobin}, true},
+		{"legends level too high", Config{Entrants: []string{"easy", "legends:9"}, Format: RoundRobin}, true},
+		{"two legends entrants", Config{Entrants: []string{"legends", "legends:2"}, Format: RoundRobin}, true},
+		{"negative games", Config{Entrants: []string{"easy", "hard"}, Format: RoundRobin, GamesPerTrack: -1}, true},
+		{"invalid track", Config{Entrants: []string{"easy", "hard"}, Format: RoundRobin, Tracks: []tracks.Track{{}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	config := createTestConfig(t, "easy", "hard")
+	report, err := Run(config)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if report.Races != 2 || len(report.Matches) != 2 {
+		t.Fatalf("Run() ran %d races, want 2", report.Races)
+	}
+	if report.Matches[0].Entrants != [2]int{0, 1} || report.Matches[1].Entrants != [2]int{1, 0} {
+		t.Errorf("grids = %v and %v, want the entrants to swap", report.Matches[0].Entrants, report.Matches[1].Entrants)
+	}
+	for i, match := range report.Matches {
+		if match.Seed != config.Seed+int64(i) {
+			t.Errorf("race %d seed = %d, want %d", i, match.Seed, config.Seed+int64(i))
+		}
+	}
+
+	wins := 0
+	for _, standing := range report.Standings {
+		wins += standing.Wins
+		if standing.Races != 2 {
+			t.Errorf("%s raced %d times, want 2", standing.Entrant, standing.Races)
+		}
+		if standing.Lower > standing.Rating || standing.Upper < standing.Rating {
+			t.Errorf("%s rating %.0f is outside its interval %.0f to %.0f", standing.Entrant, standing.Rating, standing.Lower, standing.Upper)
+		}
+	}
+	if wins != 2 {
+		t.Errorf("standings have %d wins, want 2", wins)
+	}
+	if report.Standings[0].Rating < report.Standings[1].Rating {
+		t.Error("standings should be ordered by rating")
+	}
+}
+
+func TestRun_Deterministic(t *testing.T) {
+	config := createTestConfig(t, "easy", "normal", "hard")
+	config.Workers = 1
+	first, err := Run(config)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	config.Workers = 4
+	second, err := Run(config)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Run() should give the same report for any number of workers")
+	}
+}
+
+func TestRun_Swiss(t *testing.T) {
+	config := createTestConfig(t, "easy", "normal", "hard", "legends")
+	config.Format = Swiss
+	report, err := Run(config)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// Four entrants race two rounds of two pairings with two races each
+	if report.Races != 8 {
+		t.Fatalf("Run() ran %d races, want 8", report.Races)
+	}
+	pairs := make(map[[2]int]int)
+	for _, match := range report.Matches {
+		pairs[pairKey(match.Entrants[0], match.Entrants[1])]++
+	}
+	for pair, races := range pairs {
+		if races != 2 {
+			t.Errorf("pair %v raced %d times, want 2 with no rematch", pair, races)
+		}
+	}
+}
+
+func TestSwissPairs(t *testing.T) {
+	tests := []struct {
+		name    string
+		wins    []int
+		ratings []float64
+		played  map[[2]int]bool
+		want    [][2]int
+	}{
+		{
+			name:    "by wins",
+			wins:    []int{0, 3, 1, 2},
+			ratings: []float64{1500, 1500, 1500, 1500},
+			played:  map[[2]int]bool{},
+			want:    [][2]int{{1, 3}, {0, 2}},
+		},
+		{
+			name:    "ties by rating",
+			wins:    []int{1, 1, 1, 1},
+			ratings: []float64{1400, 1600, 1500, 1450},
+			played:  map[[2]int]bool{},
+			want:    [][2]int{{1, 2}, {0, 3}},
+		},
+		{
+			name:    "avoids rematches",
+			wins:    []int{3, 2, 1, 0},
+			ratings: []float64{1500, 1500, 1500, 1500},
+			played:  map[[2]int]bool{{0, 1}: true},
+			want:    [][2]int{{0, 2}, {1, 3}},
+		},
+		{
+			name:    "rematch when nobody else is left",
+			wins:    []int{3, 2, 1, 0},
+			ratings: []float64{1500, 1500, 1500, 1500},
+			played:  map[[2]int]bool{{0, 1}: true, {0, 2}: true, {0, 3}: true},
+			want:    [][2]int{{0, 1}, {2, 3}},
+		},
+		{
+			name:    "odd count sits one out",
+			wins:    []int{2, 1, 0},
+			ratings: []float64{1500, 1500, 1500},
+			played:  map[[2]int]bool{},
+			want:    [][2]int{{0, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := swissPairs(tt.wins, tt.ratings, tt.played); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("swissPairs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEntrants(t *testing.T) {
+	entrants, err := parseEntrants([]string{" Hard ", "legends", "legends:3"})
+	if err != nil {
+		t.Fatalf("parseEntrants() error = %v", err)
+	}
+	want := []entrant{{name: "hard"}, {name: "legends", legend: true, level: DefaultLegendLevel}, {name: "legends:3", legend: true, level: 3}}
+	if !reflect.DeepEqual(entrants, want) {
+		t.Errorf("parseEntrants() = %+v, want %+v", entrants, want)
+	}
+}
+
+// Helper function to create a short tournament on one track
+func createTestConfig(t *testing.T, entrants ...string) Config {
+	t.Helper()
+	track, err := tracks.GetTrack("USA")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+	return Config{
+		Entrants:  entrants,
+		Format:    RoundRobin,
+		Tracks:    []tracks.Track{track},
+		Laps:      1,
+		Seed:      7,
+		Bootstrap: 50,
+	}
+}
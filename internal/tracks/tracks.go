@@ -0,0 +1,204 @@
+package tracks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"race-cars/internal/models"
+)
+
+// Corner is a corner line on a track
+type Corner struct {
+	Space      int `json:"space"`
+	SpeedLimit int `json:"speed_limit"`
+
+	// Press marks a corner with a press area, where slipstreaming across the corner line earns a sponsor card
+	Press bool `json:"press,omitempty"`
+
+	// LegendLine is the space of the Legend line marker before the corner, 0 when the track has none
+	LegendLine int `json:"legend_line,omitempty"`
+}
+
+// Track describes the layout of a race track
+// Space 0 is the finish line and the spaces run in racing direction
+type Track struct {
+	Name    string   `json:"name"`
+	Length  int      `json:"length"`
+	Laps    int      `json:"laps"`
+	Corners []Corner `json:"corners"`
+}
+
+// builtIn is the catalog of tracks that ship with the game
+var builtIn = []Track{
+	{
+		Name:   "USA",
+		Length: 48,
+		Laps:   2,
+		Corners: []Corner{
+			{Space: 10, SpeedLimit: 6, LegendLine: 6},
+			{Space: 19, SpeedLimit: 3, Press: true, LegendLine: 15},
+			{Space: 33, SpeedLimit: 4, LegendLine: 28},
+		},
+	},
+	{
+		Name:   "Italy",
+		Length: 54,
+		Laps:   2,
+		Corners: []Corner{
+			{Space: 8, SpeedLimit: 5, LegendLine: 4},
+			{Space: 17, SpeedLimit: 2, Press: true, LegendLine: 13},
+			{Space: 27, SpeedLimit: 4, LegendLine: 23},
+			{Space: 39, SpeedLimit: 3, Press: true, LegendLine: 34},
+			{Space: 47, SpeedLimit: 5, LegendLine: 43},
+		},
+	},
+	{
+		Name:   "France",
+		Length: 52,
+		Laps:   2,
+		Corners: []Corner{
+			{Space: 7, SpeedLimit: 3, LegendLine: 3},
+			{Space: 15, SpeedLimit: 1, Press: true, LegendLine: 11},
+			{Space: 26, SpeedLimit: 5, LegendLine: 21},
+			{Space: 40, SpeedLimit: 3, LegendLine: 35},
+		},
+	},
+	{
+		Name:   "Great Britain",
+		Length: 56,
+		Laps:   2,
+		Corners: []Corner{
+			{Space: 9, SpeedLimit: 4, LegendLine: 5},
+			{Space: 18, SpeedLimit: 7, LegendLine: 14},
+			{Space: 24, SpeedLimit: 2, Press: true, LegendLine: 21},
+			{Space: 36, SpeedLimit: 5, LegendLine: 31},
+			{Space: 49, SpeedLimit: 3, Press: true, LegendLine: 44},
+		},
+	},
+}
+
+// IsPressCorner returns whether a space holds a corner with a press area
+// Input: space - the index of the space
+// Returns: a boolean
+func (t Track) IsPressCorner(space int) bool {
+	for _, corner := range t.Corners {
+		if corner.Space == space {
+			return corner.Press
+		}
+	}
+	return false
+}
+
+// GetTracks returns the built-in tracks
+// Input: none
+// Returns: a copy of every built-in track
+func GetTracks() []Track {
+	result := make([]Track, len(builtIn))
+	for i, track := range builtIn {
+		result[i] = track.copy()
+	}
+	return result
+}
+
+// GetTrack returns a built-in track by name
+// Input: name - the name of the track
+// Returns: the track, an error if there is no track with that name
+func GetTrack(name string) (Track, error) {
+	for _, track := range builtIn {
+		if track.Name == name {
+			return track.copy(), nil
+		}
+	}
+	return Track{}, fmt.Errorf("unknown track %q", name)
+}
+
+// Load returns a built-in track, or reads a custom one from a JSON file
+// Input: name - the name of a built-in track, used when there is no file
+//
+//	path - the JSON file with a custom track, empty for the built-in one
+//
+// Returns: the track, an error if there is no such track or the file cannot be read or holds an invalid track
+func Load(name string, path string) (Track, error) {
+	if path == "" {
+		return GetTrack(name)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Track{}, err
+	}
+	var track Track
+	if err := json.Unmarshal(data, &track); err != nil {
+		return Track{}, fmt.Errorf("error reading track %s: %w", path, err)
+	}
+	return track, track.Validate()
+}
+
+// Validate checks that the track can be turned into a board
+// Input: none
+// Returns: an error describing the first problem found
+func (t Track) Validate() error {
+	if t.Length < 2 {
+		return errors.New("track needs at least 2 spaces")
+	}
+	if t.Laps < 1 {
+		return errors.New("track needs at least 1 lap")
+	}
+
+	previous := 0
+	for _, corner := range t.Corners {
+		if corner.Space <= previous || corner.Space >= t.Length {
+			return fmt.Errorf("corner on space %d is out of order or off the track", corner.Space)
+		}
+		if corner.SpeedLimit < 1 {
+			return fmt.Errorf("corner on space %d needs a speed limit of at least 1", corner.Space)
+		}
+		if corner.LegendLine != 0 && (corner.LegendLine <= previous || corner.LegendLine >= corner.Space) {
+			return fmt.Errorf("legend line of the corner on space %d must be between it and the previous corner", corner.Space)
+		}
+		previous = corner.Space
+	}
+
+	return nil
+}
+
+// NewBoard builds a board for the track with the spaces linked into a loop
+// Input: laps - the number of laps to race, 0 uses the track's default
+// Returns: a new Board, an error if the track is invalid
+func (t Track) NewBoard(laps int) (models.Board, error) {
+	if laps == 0 {
+		laps = t.Laps
+	}
+	if laps < 1 {
+		return nil, errors.New("race needs at least 1 lap")
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	limits := make(map[int]int, len(t.Corners))
+	for _, corner := range t.Corners {
+		limits[corner.Space] = corner.SpeedLimit
+	}
+
+	spaces := make([]models.Space, t.Length)
+	for i := range spaces {
+		spaces[i] = models.NewSpace(nil, nil, limits[i], i == 0)
+	}
+	for i, space := range spaces {
+		space.SetNext(spaces[(i+1)%len(spaces)])
+		space.SetPrevious(spaces[(i-1+len(spaces))%len(spaces)])
+	}
+
+	return models.NewBoard(spaces, laps), nil
+}
+
+// copy returns a deep copy of the track so the catalog cannot be modified
+func (t Track) copy() Track {
+	corners := make([]Corner, len(t.Corners))
+	copy(corners, t.Corners)
+	t.Corners = corners
+	return t
+}
@@ -0,0 +1,205 @@
+package tracks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetTracks_AreValid(t *testing.T) {
+	for _, track := range GetTracks() {
+		t.Run(track.Name, func(t *testing.T) {
+			if err := track.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGetTrack(t *testing.T) {
+	track, err := GetTrack("Italy")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+	if track.Name != "Italy" {
+		t.Errorf("GetTrack() name = %s, want Italy", track.Name)
+	}
+
+	// Changing the returned track must not change the catalog
+	track.Corners[0].SpeedLimit = 99
+	again, _ := GetTrack("Italy")
+	if again.Corners[0].SpeedLimit == 99 {
+		t.Error("GetTrack() should return a copy of the track")
+	}
+
+	if _, err := GetTrack("Atlantis"); err == nil {
+		t.Error("GetTrack() with an unknown name should fail")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"oval.json":    `{"name": "Oval", "length": 20, "laps": 2, "corners": [{"space": 10, "speed_limit": 3}]}`,
+		"broken.json":  `{"name": "Oval", "length":`,
+		"invalid.json": `{"name": "Dot", "length": 1, "laps": 1}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		track    string
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "Built-in track", track: "Italy", expected: "Italy"},
+		{name: "Unknown built-in track", track: "Atlantis", wantErr: true},
+		{name: "Track file overrides the name", track: "Italy", path: filepath.Join(dir, "oval.json"), expected: "Oval"},
+		{name: "Missing file", path: filepath.Join(dir, "missing.json"), wantErr: true},
+		{name: "Malformed file", path: filepath.Join(dir, "broken.json"), wantErr: true},
+		{name: "Invalid track", path: filepath.Join(dir, "invalid.json"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track, err := Load(tt.track, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && track.Name != tt.expected {
+				t.Errorf("Load() name = %s, want %s", track.Name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTrack_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		track   Track
+		wantErr bool
+	}{
+		{
+			name:  "Valid track",
+			track: Track{Name: "Oval", Length: 20, Laps: 1, Corners: []Corner{{Space: 5, SpeedLimit: 3}, {Space: 15, SpeedLimit: 3}}},
+		},
+		{
+			name:    "Too short",
+			track:   Track{Name: "Dot", Length: 1, Laps: 1},
+			wantErr: true,
+		},
+		{
+			name:    "No laps",
+			track:   Track{Name: "Oval", Length: 20, Laps: 0},
+			wantErr: true,
+		},
+		{
+			name:    "Corner on the finish line",
+			track:   Track{Name: "Oval", Length: 20, Laps: 1, Corners: []Corner{{Space: 0, SpeedLimit: 3}}},
+			wantErr: true,
+		},
+		{
+			name:    "Corners out of order",
+			track:   Track{Name: "Oval", Length: 20, Laps: 1, Corners: []Corner{{Space: 15, SpeedLimit: 3}, {Space: 5, SpeedLimit: 3}}},
+			wantErr: true,
+		},
+		{
+			name:  "Legend lines before the corners",
+			track: Track{Name: "Oval", Length: 20, Laps: 1, Corners: []Corner{{Space: 5, SpeedLimit: 3, LegendLine: 2}, {Space: 15, SpeedLimit: 3, LegendLine: 11}}},
+		},
+		{
+			name:    "Legend line past its corner",
+			track:   Track{Name: "Oval", Length: 20, Laps: 1, Corners: []Corner{{Space: 5, SpeedLimit: 3, LegendLine: 7}}},
+			wantErr: true,
+		},
+		{
+			name:    "Legend line before the previous corner",
+			track:   Track{Name: "Oval", Length: 20, Laps: 1, Corners: []Corner{{Space: 5, SpeedLimit: 3}, {Space: 15, SpeedLimit: 3, LegendLine: 4}}},
+			wantErr: true,
+		},
+		{
+			name:    "Corner without a speed limit",
+			track:   Track{Name: "Oval", Length: 20, Laps: 1, Corners: []Corner{{Space: 5, SpeedLimit: 0}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.track.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrack_NewBoard(t *testing.T) {
+	track, _ := GetTrack("USA")
+	board, err := track.NewBoard(0)
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	spaces := board.GetSpaces()
+	if len(spaces) != track.Length {
+		t.Fatalf("Board has %d spaces, want %d", len(spaces), track.Length)
+	}
+	if board.GetNumberOfLaps() != track.Laps {
+		t.Errorf("GetNumberOfLaps() = %d, want %d", board.GetNumberOfLaps(), track.Laps)
+	}
+	if !spaces[0].IsFinishLine() {
+		t.Error("Space 0 should be the finish line")
+	}
+	if spaces[len(spaces)-1].GetNext() != spaces[0] || spaces[0].GetPrevious() != spaces[len(spaces)-1] {
+		t.Error("Spaces should be linked into a loop")
+	}
+	for _, corner := range track.Corners {
+		if spaces[corner.Space].GetCorner() != corner.SpeedLimit {
+			t.Errorf("Space %d corner = %d, want %d", corner.Space, spaces[corner.Space].GetCorner(), corner.SpeedLimit)
+		}
+	}
+	if len(board.GetSectors()) != len(track.Corners) {
+		t.Errorf("Board has %d sectors, want %d", len(board.GetSectors()), len(track.Corners))
+	}
+
+	custom, err := track.NewBoard(3)
+	if err != nil {
+		t.Fatalf("NewBoard(3) error = %v", err)
+	}
+	if custom.GetNumberOfLaps() != 3 {
+		t.Errorf("GetNumberOfLaps() = %d, want 3", custom.GetNumberOfLaps())
+	}
+	if _, err := track.NewBoard(-1); err == nil {
+		t.Error("NewBoard(-1) should fail")
+	}
+}
+
+func TestTrack_IsPressCorner(t *testing.T) {
+	track := Track{
+		Name:    "Test",
+		Length:  20,
+		Laps:    1,
+		Corners: []Corner{{Space: 5, SpeedLimit: 3}, {Space: 12, SpeedLimit: 2, Press: true}},
+	}
+
+	tests := []struct {
+		space    int
+		expected bool
+	}{
+		{space: 5, expected: false},
+		{space: 12, expected: true},
+		{space: 7, expected: false},
+	}
+
+	for _, tt := range tests {
+		if got := track.IsPressCorner(tt.space); got != tt.expected {
+			t.Errorf("IsPressCorner(%d) = %t, want %t", tt.space, got, tt.expected)
+		}
+	}
+}
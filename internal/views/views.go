@@ -0,0 +1,225 @@
+package views
+
+import (
+	"fmt"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+)
+
+// CardView is a card someone is allowed to see
+type CardView struct {
+	// ID is the position of the card in the hand or draft market, used to pick it in an action; omitted elsewhere
+	ID *int `json:"id,omitempty"`
+
+	Name     string         `json:"name"`
+	Speed    int            `json:"speed"`
+	Icons    map[string]int `json:"icons,omitempty"`
+	Playable bool           `json:"playable"`
+}
+
+// CarView is what everyone can see of a racer: the car, the size of the hand and deck and the face-up cards
+// Hand contents and deck order are never part of it
+type CarView struct {
+	Seat     int    `json:"seat"`
+	Name     string `json:"name"`
+	Color    string `json:"color"`
+	Legend   bool   `json:"legend,omitempty"`
+	Position int    `json:"position"`
+	Space    int    `json:"space"`
+	Lap      int    `json:"lap"`
+	Gear     int    `json:"gear"`
+	Speed    int    `json:"speed"`
+
+	// Engine is the number of Heat cards left in the engine
+	Engine int `json:"engine"`
+
+	HandSize int `json:"hand_size"`
+	DeckSize int `json:"deck_size"`
+
+	// PlayedCount is the number of cards played this round, face down until the seat reveals them
+	PlayedCount int `json:"played_count"`
+
+	// Played are the cards played this round once they are revealed
+	Played []CardView `json:"played,omitempty"`
+
+	// DiscardTop is the card on top of the face-up discard pile, nil when the pile is empty
+	DiscardTop *CardView `json:"discard_top,omitempty"`
+
+	// Discard is the whole discard pile, the top card first
+	Discard []CardView `json:"discard"`
+}
+
+// SpectatorView is the public state of a race, safe to show anyone
+type SpectatorView struct {
+	Round      int             `json:"round"`
+	Phase      engine.Phase    `json:"phase"`
+	ActiveSeat int             `json:"active_seat"`
+	Cars       []CarView       `json:"cars"`
+	Market     []CardView      `json:"market,omitempty"`
+	Results    []engine.Result `json:"results,omitempty"`
+	Events     []engine.Event  `json:"events"`
+}
+
+// PlayerView is the state of a race as one player sees it: the public state with their own hand and choices
+type PlayerView struct {
+	SpectatorView
+	Seat         int             `json:"seat"`
+	Hand         []CardView      `json:"hand"`
+	Icons        map[string]int  `json:"icons,omitempty"`
+	LegalActions []engine.Action `json:"legal_actions"`
+
+	// Plan is the plan the player submitted this round, until every seat has planned and it is revealed
+	Plan *engine.Action `json:"plan,omitempty"`
+}
+
+// NewSpectatorView projects a race onto what everyone can see
+// Played cards show once the seat reveals them; until then only their number does
+// Input: game - the race
+// Returns: the SpectatorView
+func NewSpectatorView(game engine.Game) SpectatorView {
+	view := SpectatorView{
+		Round:      game.GetRound(),
+		Phase:      game.GetPhase(),
+		ActiveSeat: game.GetActiveSeat(),
+		Cars:       make([]CarView, 0),
+		Results:    game.GetResults(),
+		Events:     game.GetEvents(),
+	}
+
+	revealed := make(map[int]bool)
+	for _, event := range view.Events {
+		if event.Type == engine.EventCardsRevealed && event.Round == view.Round {
+			revealed[event.Seat] = true
+		}
+	}
+
+	board := game.GetBoard()
+	racers := game.GetRacers()
+	positions := make(map[models.Car]int, len(racers))
+	for i, racer := range board.OrderRacers(racers) {
+		positions[racer.GetCar()] = i + 1
+	}
+
+	players := game.GetPlayers()
+	for seat, racer := range racers {
+		car := racer.GetCar()
+		space, _ := board.FindCar(car)
+		carView := CarView{
+			Seat:     seat,
+			Name:     racer.GetName(),
+			Color:    car.GetColor(),
+			Legend:   seat >= len(players),
+			Position: positions[car],
+			Space:    space,
+			Lap:      car.GetLap(),
+			Gear:     car.GetGear(),
+			Speed:    car.GetSpeed(),
+			Engine:   car.GetEngine(),
+			Discard:  make([]CardView, 0),
+		}
+		if seat < len(players) {
+			player := players[seat]
+			carView.HandSize = player.GetHand().Size()
+			carView.DeckSize = player.GetDeck().Size()
+
+			played := player.GetPlayedCards()
+			carView.PlayedCount = len(played)
+			if revealed[seat] {
+				carView.Played = newCardViews(played, false)
+			}
+
+			discard := player.GetDiscardPile().GetCards()
+			for i := len(discard) - 1; i >= 0; i-- {
+				carView.Discard = append(carView.Discard, newCardView(discard[i], nil))
+			}
+			if len(carView.Discard) > 0 {
+				top := carView.Discard[0]
+				carView.DiscardTop = &top
+			}
+		}
+		view.Cars = append(view.Cars, carView)
+	}
+
+	if draft := game.GetDraft(); draft != nil && view.Phase == engine.PhaseDraft {
+		view.Market = newCardViews(draft.GetMarket(), true)
+	}
+	return view
+}
+
+// NewPlayerView projects a race onto what one player can see: the public state with their own cards and choices
+// Input: game - the race
+//
+//	seat - the player's seat
+//
+// Returns: the PlayerView, an error if the seat is not a player's
+func NewPlayerView(game engine.Game, seat int) (PlayerView, error) {
+	players := game.GetPlayers()
+	if seat < 0 || seat >= len(players) {
+		return PlayerView{}, fmt.Errorf("seat %d is not a player", seat)
+	}
+
+	view := PlayerView{
+		SpectatorView: NewSpectatorView(game),
+		Seat:          seat,
+		Hand:          newCardViews(players[seat].GetHand().GetCards(), true),
+		LegalActions:  game.LegalActions(seat),
+	}
+
+	// A player knows the cards they played before revealing them, and their plan before every seat has planned
+	view.Cars[seat].Played = newCardViews(players[seat].GetPlayedCards(), false)
+	if plan, ok := game.GetPlan(seat); ok {
+		view.Plan = &plan
+		hand := players[seat].GetHand()
+		for _, index := range plan.Cards {
+			view.Cars[seat].Played = append(view.Cars[seat].Played, newCardView(hand.GetCard(index), nil))
+		}
+	}
+	for icon, count := range players[seat].GetIcons() {
+		if count > 0 {
+			if view.Icons == nil {
+				view.Icons = make(map[string]int)
+			}
+			view.Icons[icon.String()] = count
+		}
+	}
+	return view, nil
+}
+
+// newCardViews describes cards in order
+// Input: cards - the cards
+//
+//	withIDs - whether to number the cards so they can be picked in an action
+//
+// Returns: the CardViews
+func newCardViews(cards []models.Card, withIDs bool) []CardView {
+	views := make([]CardView, len(cards))
+	for i, card := range cards {
+		var id *int
+		if withIDs {
+			id = new(int)
+			*id = i
+		}
+		views[i] = newCardView(card, id)
+	}
+	return views
+}
+
+// newCardView describes a card
+// Input: card - the card
+//
+//	id - the card's ID, nil for cards that cannot be picked
+//
+// Returns: the CardView
+func newCardView(card models.Card, id *int) CardView {
+	view := CardView{ID: id, Name: card.GetName(), Speed: card.GetSpeed(), Playable: card.IsPlayable()}
+	for icon, count := range card.GetIcons() {
+		if count > 0 {
+			if view.Icons == nil {
+				view.Icons = make(map[string]int)
+			}
+			view.Icons[icon.String()] = count
+		}
+	}
+	return view
+}
@@ -0,0 +1,203 @@
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"race-cars/internal/engine"
+	"race-cars/internal/models"
+	"race-cars/internal/tracks"
+)
+
+// Helper function to create a two-seat race on USA
+func createTestGame(t *testing.T) engine.Game {
+	track, err := tracks.GetTrack("USA")
+	if err != nil {
+		t.Fatalf("GetTrack() error = %v", err)
+	}
+	game, err := engine.NewGame(engine.Config{
+		Track: track,
+		Laps:  1,
+		Seed:  1,
+		Seats: []engine.Seat{{Name: "Ada", Color: models.Red}, {Name: "Brian", Color: models.Blue}},
+	})
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+	return game
+}
+
+// Helper function to put a uniquely named card in every player's hand and on top of every deck
+// Any view containing one of these names has leaked hidden information
+func plantSecrets(game engine.Game) {
+	for seat, player := range game.GetPlayers() {
+		player.GetHand().AddCards([]models.Card{models.NewCard(fmt.Sprintf("Secret hand %d", seat), 4, nil, true, true, true)})
+		player.GetDeck().AddCardsToTop([]models.Card{models.NewCard(fmt.Sprintf("Secret deck %d", seat), 4, nil, true, true, true)})
+	}
+}
+
+// Helper function to turn a view into the JSON sent to clients
+func marshal(t *testing.T, view interface{}) string {
+	data, err := json.Marshal(view)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(data)
+}
+
+func TestNewSpectatorView_HidesHandsAndDecks(t *testing.T) {
+	game := createTestGame(t)
+	plantSecrets(game)
+
+	view := NewSpectatorView(game)
+	if data := marshal(t, view); strings.Contains(data, "Secret") {
+		t.Fatalf("spectator view leaks hidden cards: %s", data)
+	}
+
+	for seat, player := range game.GetPlayers() {
+		car := view.Cars[seat]
+		if car.HandSize != player.GetHand().Size() || car.DeckSize != player.GetDeck().Size() {
+			t.Errorf("seat %d shows hand %d, deck %d, want %d and %d",
+				seat, car.HandSize, car.DeckSize, player.GetHand().Size(), player.GetDeck().Size())
+		}
+		if car.Engine != player.GetCar().GetEngine() || car.Gear != 1 || car.Position < 1 {
+			t.Errorf("seat %d shows engine %d, gear %d, position %d", seat, car.Engine, car.Gear, car.Position)
+		}
+	}
+}
+
+func TestNewPlayerView_ShowsOnlyOwnHand(t *testing.T) {
+	game := createTestGame(t)
+	plantSecrets(game)
+
+	for seat := range game.GetPlayers() {
+		view, err := NewPlayerView(game, seat)
+		if err != nil {
+			t.Fatalf("NewPlayerView() error = %v", err)
+		}
+		data := marshal(t, view)
+
+		own := fmt.Sprintf("Secret hand %d", seat)
+		if !strings.Contains(data, own) {
+			t.Errorf("seat %d does not see %q in its hand", seat, own)
+		}
+		for other := range game.GetPlayers() {
+			if hidden := fmt.Sprintf("Secret hand %d", other); other != seat && strings.Contains(data, hidden) {
+				t.Errorf("seat %d sees %q", seat, hidden)
+			}
+			if hidden := fmt.Sprintf("Secret deck %d", other); strings.Contains(data, hidden) {
+				t.Errorf("seat %d sees %q", seat, hidden)
+			}
+		}
+		if len(view.LegalActions) == 0 {
+			t.Errorf("seat %d has no legal actions", seat)
+		}
+	}
+
+	if _, err := NewPlayerView(game, 2); err == nil {
+		t.Error("NewPlayerView() for a seat without a player error = nil")
+	}
+}
+
+func TestNewSpectatorView_RevealsPlayedCards(t *testing.T) {
+	game := createTestGame(t)
+	players := game.GetPlayers()
+	players[1].GetHand().AddCards([]models.Card{models.NewCard("Secret played", 4, nil, true, true, true)})
+
+	if err := game.Submit(1, engine.Action{Type: engine.ActionPlan, Gear: 1, Cards: []int{players[1].GetHand().Size() - 1}}); err != nil {
+		t.Fatalf("Submit() plan error = %v", err)
+	}
+
+	view := NewSpectatorView(game)
+	if data := marshal(t, view); strings.Contains(data, "Secret played") {
+		t.Fatalf("spectator view shows a card played face down: %s", data)
+	}
+	if view.Cars[1].PlayedCount != 0 {
+		t.Errorf("played_count before every seat planned = %d, want 0", view.Cars[1].PlayedCount)
+	}
+	own, _ := NewPlayerView(game, 1)
+	if len(own.Cars[1].Played) != 1 || own.Cars[1].Played[0].Name != "Secret played" {
+		t.Errorf("seat 1 sees its played cards as %+v, want the secret card", own.Cars[1].Played)
+	}
+
+	if err := game.Submit(0, game.LegalActions(0)[0]); err != nil {
+		t.Fatalf("Submit() plan error = %v", err)
+	}
+	if count := NewSpectatorView(game).Cars[1].PlayedCount; count != 1 {
+		t.Errorf("played_count once every seat planned = %d, want 1", count)
+	}
+	for game.GetActiveSeat() != 1 || game.GetPhase() != engine.PhaseReact {
+		seat := game.GetActiveSeat()
+		if seat < 0 {
+			t.Fatalf("race is in the %s phase without an active seat", game.GetPhase())
+		}
+		if err := game.Submit(seat, game.LegalActions(seat)[0]); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	revealed := NewSpectatorView(game).Cars[1].Played
+	if len(revealed) == 0 || revealed[0].Name != "Secret played" {
+		t.Errorf("revealed cards = %+v, want the secret card", revealed)
+	}
+}
+
+func TestNewPlayerView_HidesPendingPlans(t *testing.T) {
+	game := createTestGame(t)
+	before := NewSpectatorView(game).Cars[0]
+
+	var shift engine.Action
+	for _, action := range game.LegalActions(0) {
+		if action.Gear != before.Gear && len(action.Cards) > 0 {
+			shift = action
+			break
+		}
+	}
+	if shift.Type == "" {
+		t.Fatal("seat 0 has no plan that shifts gear")
+	}
+	if err := game.Submit(0, shift); err != nil {
+		t.Fatalf("Submit() plan error = %v", err)
+	}
+
+	own, _ := NewPlayerView(game, 0)
+	if own.Plan == nil || own.Plan.Gear != shift.Gear {
+		t.Errorf("seat 0 sees its plan as %+v, want gear %d", own.Plan, shift.Gear)
+	}
+
+	other, _ := NewPlayerView(game, 1)
+	for name, view := range map[string]SpectatorView{"seat 1": other.SpectatorView, "spectator": NewSpectatorView(game)} {
+		car := view.Cars[0]
+		if car.Gear != before.Gear || car.Engine != before.Engine || car.HandSize != before.HandSize || car.PlayedCount != 0 {
+			t.Errorf("%s sees seat 0 as %+v while it plans, want %+v", name, car, before)
+		}
+		for _, event := range view.Events {
+			if event.Type == engine.EventGearShifted {
+				t.Errorf("%s sees %+v before every seat planned", name, event)
+			}
+		}
+	}
+	if other.Plan != nil {
+		t.Errorf("seat 1 sees plan %+v, want none", other.Plan)
+	}
+}
+
+func TestNewSpectatorView_DiscardPile(t *testing.T) {
+	game := createTestGame(t)
+	pile := game.GetPlayers()[0].GetDiscardPile()
+	pile.AddCard(models.NewStressCard())
+	pile.AddCard(models.NewHeatCard())
+
+	car := NewSpectatorView(game).Cars[0]
+	if len(car.Discard) != 2 || car.Discard[0].Name != models.Heat || car.Discard[1].Name != models.Stress {
+		t.Fatalf("discard = %+v, want Heat on top of Stress", car.Discard)
+	}
+	if car.DiscardTop == nil || car.DiscardTop.Name != models.Heat {
+		t.Errorf("discard_top = %+v, want Heat", car.DiscardTop)
+	}
+	if car.Discard[0].ID != nil {
+		t.Errorf("discarded card has ID %d, want none", *car.Discard[0].ID)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"race-cars/internal/config"
 	"race-cars/internal/middleware"
+	"race-cars/internal/repository"
 	"race-cars/internal/routes"
 
 	"github.com/gorilla/mux"
@@ -23,6 +24,12 @@ func main() {
 		log.Fatal("Error initializing database:", err)
 	}
 
+	// Create the repositories for the configured database
+	repos, err := repository.New(config.GetDBDriver(), config.DB)
+	if err != nil {
+		log.Fatal("Error creating repositories:", err)
+	}
+
 	// Create router
 	router := mux.NewRouter()
 
@@ -32,7 +39,9 @@ func main() {
 	router.Use(middleware.Recovery)
 
 	// Setup routes
-	routes.SetupRoutes(router)
+	if err := routes.SetupRoutes(router, repos); err != nil {
+		log.Fatal("Error setting up routes:", err)
+	}
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -0,0 +1,21 @@
+-- Car catalog; every car races in one of the game's car colors
+CREATE TABLE IF NOT EXISTS cars (
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    brand       VARCHAR(100) NOT NULL,
+    model       VARCHAR(100) NOT NULL,
+    year        INTEGER NOT NULL,
+    engine_size NUMERIC(4, 1) NOT NULL DEFAULT 0 CHECK (engine_size >= 0),
+    horsepower  INTEGER NOT NULL DEFAULT 0 CHECK (horsepower >= 0),
+    top_speed   INTEGER NOT NULL DEFAULT 0 CHECK (top_speed >= 0),
+    weight      INTEGER NOT NULL DEFAULT 0 CHECK (weight >= 0),
+    category    VARCHAR(100) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url   TEXT NOT NULL DEFAULT '',
+    color       VARCHAR(16) NOT NULL
+        CHECK (color IN ('Red', 'Blue', 'Green', 'Yellow', 'Orange', 'Black', 'Gray')),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cars_color ON cars (color);
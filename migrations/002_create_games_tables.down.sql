@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS game_events;
+DROP TABLE IF EXISTS game_moves;
+DROP TABLE IF EXISTS game_seats;
+DROP TABLE IF EXISTS games;
@@ -0,0 +1,42 @@
+-- Games; a race is saved as its settings, seed included, and the moves played, and rebuilt by replaying them
+CREATE TABLE IF NOT EXISTS games (
+    id         VARCHAR(16) PRIMARY KEY,
+    settings   JSONB NOT NULL,
+    status     VARCHAR(16) NOT NULL CHECK (status IN ('waiting', 'racing', 'finished')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_games_status ON games (status);
+
+-- Players who joined a game; only the SHA-256 hash of each seat token is kept
+CREATE TABLE IF NOT EXISTS game_seats (
+    game_id    VARCHAR(16) NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+    seat       INTEGER NOT NULL CHECK (seat >= 0),
+    name       VARCHAR(100) NOT NULL,
+    color      VARCHAR(16) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    PRIMARY KEY (game_id, seat),
+    UNIQUE (game_id, color)
+);
+
+-- Every action played, in order
+CREATE TABLE IF NOT EXISTS game_moves (
+    game_id    VARCHAR(16) NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+    sequence   INTEGER NOT NULL CHECK (sequence > 0),
+    seat       INTEGER NOT NULL CHECK (seat >= 0),
+    action     JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (game_id, sequence)
+);
+
+-- The public event log of every race
+CREATE TABLE IF NOT EXISTS game_events (
+    game_id    VARCHAR(16) NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+    sequence   INTEGER NOT NULL CHECK (sequence > 0),
+    round      INTEGER NOT NULL,
+    type       VARCHAR(32) NOT NULL,
+    event      JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (game_id, sequence)
+);
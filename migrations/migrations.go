@@ -0,0 +1,123 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// Drivers the migrations are written for
+const (
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// files are the SQL migrations, numbered NNN_name.up.sql with a matching NNN_name.down.sql that undoes it
+// The Postgres migrations are at the top, the same schema for SQLite is in sqlite/
+//
+//go:embed *.sql sqlite/*.sql
+var files embed.FS
+
+// New creates a migrator for a database using the migrations built into the binary
+// Closing the migrator leaves the database open
+// Input: driver - Postgres or SQLite
+//
+//	db - the database
+//
+// Returns: the migrator, an error if the driver is unknown or the database cannot be reached
+func New(driver string, db *sql.DB) (*migrate.Migrate, error) {
+	dir, err := directory(driver)
+	if err != nil {
+		return nil, err
+	}
+	source, err := iofs.New(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+
+	instance, err := open(driver, db)
+	if err != nil {
+		source.Close()
+		return nil, fmt.Errorf("error preparing migrations: %w", err)
+	}
+
+	migrator, err := migrate.NewWithInstance("iofs", source, driver, instance)
+	if err != nil {
+		instance.Close()
+		return nil, fmt.Errorf("error preparing migrations: %w", err)
+	}
+	return migrator, nil
+}
+
+// Up applies every migration the database does not have yet
+// Input: driver - Postgres or SQLite
+//
+//	db - the database
+//
+// Returns: an error if a migration fails; a database that is up to date is not an error
+func Up(driver string, db *sql.DB) error {
+	migrator, err := New(driver, db)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("error applying migrations: %w", err)
+	}
+	return nil
+}
+
+// directory returns where the migrations for a driver are embedded
+func directory(driver string) (string, error) {
+	switch driver {
+	case Postgres:
+		return ".", nil
+	case SQLite:
+		return "sqlite", nil
+	}
+	return "", fmt.Errorf("no migrations for database driver %q", driver)
+}
+
+// open wraps a database for the migrator
+// Postgres gets a connection of its own, which closing releases; SQLite keeps a single connection,
+// so the database is shared and closing the migrator must not close it
+func open(driver string, db *sql.DB) (database.Driver, error) {
+	if driver == SQLite {
+		instance, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+		if err != nil {
+			return nil, err
+		}
+		return keepOpen{instance}, nil
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return instance, nil
+}
+
+// keepOpen is a migration driver whose Close leaves the database open
+type keepOpen struct {
+	database.Driver
+}
+
+// Close does nothing, the database belongs to the caller
+func (keepOpen) Close() error {
+	return nil
+}
@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+func TestMigrations(t *testing.T) {
+	for _, driver := range []string{Postgres, SQLite} {
+		t.Run(driver, func(t *testing.T) {
+			dir, err := directory(driver)
+			if err != nil {
+				t.Fatalf("directory() error = %v", err)
+			}
+			checkMigrations(t, dir)
+		})
+	}
+}
+
+func TestMigrationsMatch(t *testing.T) {
+	postgresNames := migrationNames(t, ".")
+	sqliteNames := migrationNames(t, "sqlite")
+	if len(postgresNames) != len(sqliteNames) {
+		t.Fatalf("got %d Postgres and %d SQLite migrations, want the same", len(postgresNames), len(sqliteNames))
+	}
+	for i := range postgresNames {
+		if postgresNames[i] != sqliteNames[i] {
+			t.Errorf("migration %d is %s for Postgres and %s for SQLite", i+1, postgresNames[i], sqliteNames[i])
+		}
+	}
+}
+
+func TestMigrationsUnknownDriver(t *testing.T) {
+	if _, err := New("mysql", nil); err == nil {
+		t.Error("New() error = nil, want an error for an unknown driver")
+	}
+}
+
+// Helper function to check the migrations in a directory are numbered without gaps and each has an up and a down file
+func checkMigrations(t *testing.T, dir string) {
+	t.Helper()
+	source, err := iofs.New(files, dir)
+	if err != nil {
+		t.Fatalf("iofs.New() error = %v", err)
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		t.Fatalf("First() error = %v", err)
+	}
+	for want := uint(1); ; want++ {
+		if version != want {
+			t.Fatalf("migration %d follows %d, want them numbered without gaps", version, want-1)
+		}
+		for _, read := range []func(uint) (io.ReadCloser, string, error){source.ReadUp, source.ReadDown} {
+			body, name, err := read(version)
+			if err != nil {
+				t.Fatalf("migration %d is missing a file: %v", version, err)
+			}
+			data, _ := io.ReadAll(body)
+			body.Close()
+			if len(data) == 0 {
+				t.Errorf("migration %s is empty", name)
+			}
+		}
+
+		version, err = source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+	}
+}
+
+// Helper function to list the migration files in a directory
+func migrationNames(t *testing.T, dir string) []string {
+	t.Helper()
+	names, err := fs.Glob(files, path.Join(dir, "*.sql"))
+	if err != nil {
+		t.Fatalf("fs.Glob() error = %v", err)
+	}
+	for i, name := range names {
+		names[i] = path.Base(name)
+	}
+	return names
+}
@@ -0,0 +1,21 @@
+-- Car catalog; every car races in one of the game's car colors
+CREATE TABLE IF NOT EXISTS cars (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        TEXT NOT NULL,
+    brand       TEXT NOT NULL,
+    model       TEXT NOT NULL,
+    year        INTEGER NOT NULL,
+    engine_size REAL NOT NULL DEFAULT 0 CHECK (engine_size >= 0),
+    horsepower  INTEGER NOT NULL DEFAULT 0 CHECK (horsepower >= 0),
+    top_speed   INTEGER NOT NULL DEFAULT 0 CHECK (top_speed >= 0),
+    weight      INTEGER NOT NULL DEFAULT 0 CHECK (weight >= 0),
+    category    TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url   TEXT NOT NULL DEFAULT '',
+    color       TEXT NOT NULL
+        CHECK (color IN ('Red', 'Blue', 'Green', 'Yellow', 'Orange', 'Black', 'Gray')),
+    created_at  DATETIME NOT NULL,
+    updated_at  DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_cars_color ON cars (color);
@@ -0,0 +1,43 @@
+-- Games; a race is saved as its settings, seed included, and the moves played, and rebuilt by replaying them
+-- Settings, actions and events are JSON text
+CREATE TABLE IF NOT EXISTS games (
+    id         TEXT PRIMARY KEY,
+    settings   TEXT NOT NULL,
+    status     TEXT NOT NULL CHECK (status IN ('waiting', 'racing', 'finished')),
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_games_status ON games (status);
+
+-- Players who joined a game; only the SHA-256 hash of each seat token is kept
+CREATE TABLE IF NOT EXISTS game_seats (
+    game_id    TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+    seat       INTEGER NOT NULL CHECK (seat >= 0),
+    name       TEXT NOT NULL,
+    color      TEXT NOT NULL,
+    token_hash TEXT NOT NULL,
+    PRIMARY KEY (game_id, seat),
+    UNIQUE (game_id, color)
+);
+
+-- Every action played, in order
+CREATE TABLE IF NOT EXISTS game_moves (
+    game_id    TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+    sequence   INTEGER NOT NULL CHECK (sequence > 0),
+    seat       INTEGER NOT NULL CHECK (seat >= 0),
+    action     TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (game_id, sequence)
+);
+
+-- The public event log of every race
+CREATE TABLE IF NOT EXISTS game_events (
+    game_id    TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+    sequence   INTEGER NOT NULL CHECK (sequence > 0),
+    round      INTEGER NOT NULL,
+    type       TEXT NOT NULL,
+    event      TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (game_id, sequence)
+);